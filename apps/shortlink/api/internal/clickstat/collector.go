@@ -0,0 +1,250 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+// Package clickstat 收集短链重定向的点击事件，批量上报给短链 RPC 服务聚合.
+//
+// 重定向请求只把原始的请求信息写入带缓冲的通道，User-Agent 解析、IP 地理位置查询和上报
+// 都在后台协程中完成，不影响重定向的响应时间. 通道写满时直接丢弃事件.
+package clickstat
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/pb/rpc"
+	"github.com/clin211/miniblog-v3/pkg/geo"
+	"github.com/clin211/miniblog-v3/pkg/useragent"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/threading"
+)
+
+const (
+	// maxUserAgentLength 保留的 User-Agent 最大长度，避免超长请求头占用缓冲区内存
+	maxUserAgentLength = 512
+	// reportTimeout 单次上报的超时时间
+	reportTimeout = 5 * time.Second
+)
+
+// Config 为点击事件收集配置.
+type Config struct {
+	BufferSize    int           `json:",default=10000"` // 事件缓冲区大小，写满后丢弃新的事件
+	BatchSize     int           `json:",default=500"`   // 单次上报的最大事件数
+	FlushInterval time.Duration `json:",default=5s"`    // 上报间隔，缓冲的事件不足一批时按间隔上报
+	GeoDB         string        `json:",optional"`      // 离线 IP 库路径，为空时不做地理位置查询
+}
+
+// rawEvent 为重定向时记录的原始请求信息.
+type rawEvent struct {
+	code      string
+	timestamp int64
+	referrer  string
+	userAgent string
+	ip        string
+}
+
+// Collector 收集点击事件并批量上报.
+type Collector struct {
+	c       Config
+	client  rpc.ShortLinkClient
+	locator geo.Locator
+
+	events   chan rawEvent
+	dropped  atomic.Int64
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewCollector 创建点击事件收集器，locator 为 nil 时不做地理位置查询.
+func NewCollector(c Config, client rpc.ShortLinkClient, locator geo.Locator) *Collector {
+	if locator == nil {
+		locator = geo.Nop{}
+	}
+	return &Collector{
+		c:       c,
+		client:  client,
+		locator: locator,
+		events:  make(chan rawEvent, c.BufferSize),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// Start 启动后台上报协程.
+func (c *Collector) Start() {
+	threading.GoSafe(c.run)
+}
+
+// Stop 停止收集，上报缓冲区中剩余的事件后返回.
+func (c *Collector) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+		<-c.done
+	})
+}
+
+// Collect 记录一次成功的重定向，不会阻塞请求.
+func (c *Collector) Collect(r *http.Request, code string) {
+	ua := r.UserAgent()
+	if len(ua) > maxUserAgentLength {
+		ua = ua[:maxUserAgentLength]
+	}
+
+	e := rawEvent{
+		code:      code,
+		timestamp: time.Now().Unix(),
+		referrer:  r.Referer(),
+		userAgent: ua,
+		ip:        ClientIP(r),
+	}
+	select {
+	case c.events <- e:
+	default:
+		c.dropped.Add(1)
+	}
+}
+
+// run 按批次或时间间隔上报事件，停止时上报缓冲区中剩余的事件后退出.
+func (c *Collector) run() {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.c.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*rpc.ClickEvent, 0, c.c.BatchSize)
+	flush := func() {
+		if n := c.dropped.Swap(0); n > 0 {
+			logx.Errorw("点击事件缓冲区已满，丢弃事件", logx.Field("dropped", n))
+		}
+		if len(batch) == 0 {
+			return
+		}
+		c.report(batch)
+		batch = make([]*rpc.ClickEvent, 0, c.c.BatchSize)
+	}
+
+	for {
+		select {
+		case e := <-c.events:
+			batch = append(batch, c.enrich(e))
+			if len(batch) >= c.c.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-c.stop:
+			for {
+				select {
+				case e := <-c.events:
+					batch = append(batch, c.enrich(e))
+					if len(batch) >= c.c.BatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// report 上报一批事件，失败时只记录日志，统计数据允许少量丢失.
+func (c *Collector) report(batch []*rpc.ClickEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), reportTimeout)
+	defer cancel()
+
+	if _, err := c.client.RecordClicks(ctx, &rpc.RecordClicksRequest{Events: batch}); err != nil {
+		logx.Errorw("上报点击事件失败",
+			logx.Field("events", len(batch)),
+			logx.Field("error", err))
+	}
+}
+
+// enrich 解析 User-Agent 和来源站点，查询 IP 地理位置后截断 IP.
+func (c *Collector) enrich(e rawEvent) *rpc.ClickEvent {
+	ua := useragent.Parse(e.userAgent)
+	event := &rpc.ClickEvent{
+		Code:      e.code,
+		Timestamp: e.timestamp,
+		Referrer:  referrerHost(e.referrer),
+		Browser:   ua.Browser,
+		Os:        ua.OS,
+		Device:    ua.Device,
+	}
+
+	addr, err := netip.ParseAddr(e.ip)
+	if err != nil {
+		return event
+	}
+	addr = addr.Unmap()
+	if loc, ok := c.locator.Lookup(addr); ok {
+		event.Country = loc.Country
+	}
+	event.Ip = truncateIP(addr).String()
+	return event
+}
+
+// ClientIP 返回客户端 IP. 优先使用 nginx 设置的 X-Real-IP，X-Forwarded-For 可以被客户端伪造，仅作为兜底.
+func ClientIP(r *http.Request) string {
+	if v := r.Header.Get("X-Real-IP"); v != "" {
+		return strings.TrimSpace(v)
+	}
+	if v := r.Header.Get("X-Forwarded-For"); v != "" {
+		ip, _, _ := strings.Cut(v, ",")
+		return strings.TrimSpace(ip)
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// GatewayIP 返回网关识别出的客户端 IP，用于限制密码错误次数等安全相关的场景.
+// 只信任 nginx 用 $remote_addr 覆盖的 X-Real-IP，没有时使用连接的对端地址，不使用客户端可以伪造的 X-Forwarded-For.
+func GatewayIP(r *http.Request) string {
+	if v := r.Header.Get("X-Real-IP"); v != "" {
+		return strings.TrimSpace(v)
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// truncateIP 截断 IP 以保护用户隐私，IPv4 保留前 24 位，IPv6 保留前 48 位.
+func truncateIP(addr netip.Addr) netip.Addr {
+	bits := 48
+	if addr.Is4() {
+		bits = 24
+	}
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return netip.Addr{}
+	}
+	return prefix.Addr()
+}
+
+// referrerHost 返回来源页面的域名，直接访问或无法解析时返回空字符串.
+func referrerHost(referrer string) string {
+	if referrer == "" {
+		return ""
+	}
+	u, err := url.Parse(referrer)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package config
+
+import (
+	"time"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/clickstat"
+	"github.com/clin211/miniblog-v3/pkg/robots"
+
+	"github.com/zeromicro/go-zero/rest"
+	"github.com/zeromicro/go-zero/zrpc"
+)
+
+type Config struct {
+	rest.RestConf
+	ShortLinkRpc zrpc.RpcClientConf
+
+	// 内部服务调用配置，上报点击事件时携带服务密钥
+	ServiceAuth struct {
+		Secret string // 服务密钥，必须与 shortlink-rpc 配置的密钥一致
+	}
+
+	// 重定向本地缓存配置，缓存热点短码的解析结果
+	LocalCache struct {
+		Limit  int           `json:",default=10000"` // 最大缓存条数，超出后按 LRU 淘汰
+		Expire time.Duration `json:",default=5s"`    // 缓存有效期，决定删除或修改短链后其他实例的最长滞后时间
+	}
+
+	// 点击统计配置，重定向时收集点击事件并批量上报
+	ClickStats clickstat.Config
+
+	// 二维码配置
+	QRCode struct {
+		DefaultSize  int           `json:",default=256"`               // 默认图片边长，单位为像素
+		MinSize      int           `json:",default=64"`                // 最小图片边长
+		MaxSize      int           `json:",default=1024"`              // 最大图片边长
+		DefaultLevel string        `json:",default=M,options=L|M|Q|H"` // 默认纠错等级
+		LogoPath     string        `json:",optional"`                  // 中间 logo 的图片路径，支持 PNG 和 JPEG，为空时不支持 logo
+		CacheLimit   int           `json:",default=1000"`              // 最大缓存条数，超出后按 LRU 淘汰
+		CacheExpire  time.Duration `json:",default=1h"`                // 缓存有效期，同时作为浏览器缓存时间
+	}
+
+	// robots.txt 配置，不同环境使用不同的配置文件
+	Robots robots.Config
+}
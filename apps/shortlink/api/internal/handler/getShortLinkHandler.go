@@ -0,0 +1,34 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package handler
+
+import (
+	"net/http"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/logic"
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/svc"
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/types"
+	"github.com/clin211/miniblog-v3/pkg/response"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func GetShortLinkHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.GetShortLinkRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			response.WriteResponse(r.Context(), w, err)
+			return
+		}
+
+		l := logic.NewGetShortLinkLogic(r.Context(), svcCtx)
+		resp, err := l.GetShortLink(&req)
+		if err != nil {
+			response.WriteResponse(r.Context(), w, err)
+		} else {
+			response.WriteResponse(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/logic"
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/svc"
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/types"
+	"github.com/clin211/miniblog-v3/pkg/response"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func HealthHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.HealthRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			response.WriteResponse(r.Context(), w, err)
+			return
+		}
+
+		l := logic.NewHealthLogic(r.Context(), svcCtx)
+		resp, err := l.Health(&req)
+		if err != nil {
+			response.WriteResponse(r.Context(), w, err)
+		} else {
+			response.WriteResponse(r.Context(), w, resp)
+		}
+	}
+}
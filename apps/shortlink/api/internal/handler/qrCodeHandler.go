@@ -0,0 +1,42 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/logic"
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/svc"
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/types"
+	"github.com/clin211/miniblog-v3/pkg/response"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func QRCodeHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.QRCodeRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			response.WriteResponse(r.Context(), w, err)
+			return
+		}
+
+		l := logic.NewQRCodeLogic(r.Context(), svcCtx)
+		img, err := l.QRCode(&req)
+		if err != nil {
+			response.WriteResponse(r.Context(), w, err)
+			return
+		}
+
+		// 同一组参数生成的图片不变，允许浏览器在本地缓存有效期内复用
+		w.Header().Set("Content-Type", img.ContentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(img.Data)))
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(svcCtx.Config.QRCode.CacheExpire.Seconds())))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(img.Data)
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/clickstat"
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/logic"
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/svc"
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/types"
+	"github.com/clin211/miniblog-v3/pkg/known"
+	"github.com/clin211/miniblog-v3/pkg/response"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func RedirectHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.RedirectRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			response.WriteResponse(r.Context(), w, err)
+			return
+		}
+
+		// 访问者IP传给 RPC，用于限制同一IP的密码错误次数
+		ctx := context.WithValue(r.Context(), known.XClientIP, clickstat.GatewayIP(r))
+		l := logic.NewRedirectLogic(ctx, svcCtx)
+		resp, err := l.Redirect(&req)
+		if err != nil {
+			response.WriteResponse(r.Context(), w, err)
+			return
+		}
+
+		// 记录点击事件，只写入缓冲通道，不影响重定向耗时
+		svcCtx.ClickCollector.Collect(r, req.Code)
+
+		// 使用 302 临时重定向，避免浏览器缓存导致短链删除或过期后仍然跳转
+		w.Header().Set("Cache-Control", "no-store")
+		http.Redirect(w, r, resp.Location, http.StatusFound)
+	}
+}
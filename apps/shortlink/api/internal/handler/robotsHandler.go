@@ -0,0 +1,18 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package handler
+
+import (
+	"net/http"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/svc"
+	"github.com/clin211/miniblog-v3/pkg/robots"
+)
+
+// RobotsHandler 输出 robots.txt，内容在启动时按配置渲染一次
+func RobotsHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return robots.Handler(svcCtx.Config.Robots)
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"context"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/svc"
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/types"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/pb/rpc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CreateShortLinkLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCreateShortLinkLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateShortLinkLogic {
+	return &CreateShortLinkLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CreateShortLinkLogic) CreateShortLink(req *types.CreateShortLinkRequest) (resp *types.CreateShortLinkResponse, err error) {
+	rpcCtx, err := outgoingContext(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcResp, err := l.svcCtx.ShortLinkRpc.CreateShortLink(rpcCtx, &rpc.CreateShortLinkRequest{
+		OriginalUrl: req.OriginalUrl,
+		ExpireAt:    req.ExpireAt,
+		Alias:       req.Alias,
+		MaxClicks:   req.MaxClicks,
+		Password:    req.Password,
+		ExternalKey: req.ExternalKey,
+	})
+	if err != nil {
+		l.Errorw("调用RPC服务失败", logx.Field("error", err))
+		return nil, fromRPCError(err)
+	}
+
+	// 复用已有短链时原始 URL 可能已被修改，清除本实例的本地缓存
+	if rpcResp.Reused {
+		l.svcCtx.LinkCache.Del(rpcResp.Code)
+	}
+
+	return &types.CreateShortLinkResponse{
+		Code:        rpcResp.Code,
+		ShortUrl:    rpcResp.ShortUrl,
+		OriginalUrl: rpcResp.OriginalUrl,
+		ExpireAt:    rpcResp.ExpireAt,
+		Reused:      rpcResp.Reused,
+	}, nil
+}
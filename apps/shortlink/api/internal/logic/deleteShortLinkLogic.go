@@ -0,0 +1,51 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"context"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/svc"
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/types"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/pb/rpc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteShortLinkLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeleteShortLinkLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteShortLinkLogic {
+	return &DeleteShortLinkLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DeleteShortLinkLogic) DeleteShortLink(req *types.DeleteShortLinkRequest) (resp *types.DeleteShortLinkResponse, err error) {
+	rpcCtx, err := outgoingContext(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := l.svcCtx.ShortLinkRpc.DeleteShortLink(rpcCtx, &rpc.DeleteShortLinkRequest{
+		Code: req.Code,
+	}); err != nil {
+		l.Errorw("调用RPC服务失败",
+			logx.Field("code", req.Code),
+			logx.Field("error", err))
+		return nil, fromRPCError(err)
+	}
+
+	// 立即清除本实例的本地缓存，其他实例在缓存过期后生效
+	l.svcCtx.LinkCache.Del(req.Code)
+
+	return &types.DeleteShortLinkResponse{}, nil
+}
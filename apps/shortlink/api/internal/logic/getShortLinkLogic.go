@@ -0,0 +1,61 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"context"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/svc"
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/types"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/pb/rpc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetShortLinkLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetShortLinkLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetShortLinkLogic {
+	return &GetShortLinkLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetShortLinkLogic) GetShortLink(req *types.GetShortLinkRequest) (resp *types.GetShortLinkResponse, err error) {
+	rpcCtx, err := outgoingContext(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcResp, err := l.svcCtx.ShortLinkRpc.GetShortLink(rpcCtx, &rpc.GetShortLinkRequest{
+		Code: req.Code,
+	})
+	if err != nil {
+		l.Errorw("调用RPC服务失败",
+			logx.Field("code", req.Code),
+			logx.Field("error", err))
+		return nil, fromRPCError(err)
+	}
+
+	return &types.GetShortLinkResponse{
+		Code:              rpcResp.Code,
+		ShortUrl:          rpcResp.ShortUrl,
+		OriginalUrl:       rpcResp.OriginalUrl,
+		State:             int(rpcResp.State),
+		ExpireAt:          rpcResp.ExpireAt,
+		CreatedAt:         rpcResp.CreatedAt,
+		UpdatedAt:         rpcResp.UpdatedAt,
+		IsAlias:           rpcResp.IsAlias,
+		MaxClicks:         rpcResp.MaxClicks,
+		Clicks:            rpcResp.Clicks,
+		PasswordProtected: rpcResp.PasswordProtected,
+	}, nil
+}
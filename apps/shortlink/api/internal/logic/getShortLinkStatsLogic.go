@@ -0,0 +1,92 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"context"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/svc"
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/types"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/pb/rpc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const (
+	// granularityHour 按小时聚合
+	granularityHour = "hour"
+	// granularityDay 按天聚合
+	granularityDay = "day"
+)
+
+type GetShortLinkStatsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetShortLinkStatsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetShortLinkStatsLogic {
+	return &GetShortLinkStatsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetShortLinkStatsLogic) GetShortLinkStats(req *types.GetShortLinkStatsRequest) (resp *types.GetShortLinkStatsResponse, err error) {
+	rpcCtx, err := outgoingContext(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	granularity := rpc.StatsGranularity_STATS_GRANULARITY_HOUR
+	if req.Granularity == granularityDay {
+		granularity = rpc.StatsGranularity_STATS_GRANULARITY_DAY
+	}
+
+	rpcResp, err := l.svcCtx.ShortLinkRpc.GetShortLinkStats(rpcCtx, &rpc.GetShortLinkStatsRequest{
+		Code:        req.Code,
+		Granularity: granularity,
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+		Top:         req.Top,
+	})
+	if err != nil {
+		l.Errorw("调用RPC服务失败",
+			logx.Field("code", req.Code),
+			logx.Field("error", err))
+		return nil, fromRPCError(err)
+	}
+
+	resp = &types.GetShortLinkStatsResponse{
+		Granularity:  granularityHour,
+		StartTime:    rpcResp.StartTime,
+		EndTime:      rpcResp.EndTime,
+		TotalClicks:  rpcResp.TotalClicks,
+		Series:       make([]types.StatsPoint, 0, len(rpcResp.Series)),
+		TopReferrers: toStatsItems(rpcResp.TopReferrers),
+		TopBrowsers:  toStatsItems(rpcResp.TopBrowsers),
+		TopOs:        toStatsItems(rpcResp.TopOs),
+		TopDevices:   toStatsItems(rpcResp.TopDevices),
+		TopCountries: toStatsItems(rpcResp.TopCountries),
+	}
+	if rpcResp.Granularity == rpc.StatsGranularity_STATS_GRANULARITY_DAY {
+		resp.Granularity = granularityDay
+	}
+	for _, p := range rpcResp.Series {
+		resp.Series = append(resp.Series, types.StatsPoint{Time: p.Time, Clicks: p.Clicks})
+	}
+	return resp, nil
+}
+
+// toStatsItems 转换排行榜，保证空排行返回空数组而不是 null.
+func toStatsItems(items []*rpc.StatsItem) []types.StatsItem {
+	result := make([]types.StatsItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, types.StatsItem{Name: item.Name, Clicks: item.Clicks})
+	}
+	return result
+}
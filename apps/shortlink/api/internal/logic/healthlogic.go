@@ -0,0 +1,31 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/svc"
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type HealthLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewHealthLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HealthLogic {
+	return &HealthLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *HealthLogic) Health(req *types.HealthRequest) (resp *types.HealthResponse, err error) {
+	resp = &types.HealthResponse{
+		Status: "ok",
+	}
+	return
+}
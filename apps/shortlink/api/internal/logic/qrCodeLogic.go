@@ -0,0 +1,132 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/svc"
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/types"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/pb/rpc"
+	"github.com/clin211/miniblog-v3/pkg/errorx"
+	"github.com/clin211/miniblog-v3/pkg/qrcode"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// qrFormatSVG SVG 格式，其余情况输出 PNG
+const qrFormatSVG = "svg"
+
+// QRCodeImage 为渲染好的二维码图片.
+type QRCodeImage struct {
+	ContentType string // 图片的 MIME 类型
+	Data        []byte // 图片内容
+}
+
+type QRCodeLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewQRCodeLogic(ctx context.Context, svcCtx *svc.ServiceContext) *QRCodeLogic {
+	return &QRCodeLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// QRCode 渲染短链二维码，二维码内容为短链 URL. 渲染结果按短码和参数缓存在本地.
+//
+// 二维码只对正常状态的短链生成，查询短链状态时不校验密码也不占用点击次数.
+func (l *QRCodeLogic) QRCode(req *types.QRCodeRequest) (*QRCodeImage, error) {
+	conf := l.svcCtx.Config.QRCode
+
+	// 1. 参数验证
+	size := req.Size
+	if size == 0 {
+		size = conf.DefaultSize
+	}
+	if size < conf.MinSize || size > conf.MaxSize {
+		return nil, errorx.ErrInvalidParameter.WithMessage("二维码尺寸必须在%d-%d像素之间", conf.MinSize, conf.MaxSize)
+	}
+	levelName := req.Level
+	if levelName == "" {
+		levelName = conf.DefaultLevel
+	}
+	level, err := qrcode.ParseLevel(levelName)
+	if err != nil {
+		return nil, errorx.ErrInvalidParameter.WithMessage("纠错等级不正确")
+	}
+	if req.Logo {
+		if l.svcCtx.QRLogo == nil {
+			return nil, errorx.ErrInvalidParameter.WithMessage("未配置二维码 logo")
+		}
+		// logo 会遮挡中间的模块，纠错等级过低时无法识别
+		level = max(level, qrcode.Quartile)
+	}
+
+	// 2. 查询短链状态
+	rpcResp, err := l.svcCtx.ShortLinkRpc.ResolveShortLink(l.ctx, &rpc.ResolveShortLinkRequest{
+		Code: req.Code,
+		Peek: true,
+	})
+	if err != nil {
+		l.Errorw("解析短链失败",
+			logx.Field("code", req.Code),
+			logx.Field("error", err))
+		return nil, fromRPCError(err)
+	}
+	if rpcResp.State != rpc.ShortLinkState_SHORT_LINK_STATE_ACTIVE {
+		return nil, errorx.ErrShortLinkGone
+	}
+
+	// 3. 渲染二维码，相同参数的请求直接使用缓存
+	key := fmt.Sprintf("%s:%s:%d:%s:%t", req.Code, req.Format, size, level, req.Logo)
+	val, err := l.svcCtx.QRCache.Take(key, func() (any, error) {
+		return l.render(rpcResp.ShortUrl, req.Format, size, level, req.Logo)
+	})
+	if errors.Is(err, qrcode.ErrSizeTooSmall) {
+		return nil, errorx.ErrInvalidParameter.WithMessage("二维码尺寸过小，无法容纳短链内容")
+	}
+	if err != nil {
+		l.Errorw("生成二维码失败",
+			logx.Field("code", req.Code),
+			logx.Field("error", err))
+		return nil, errorx.InternalServerError.WithMessage("生成二维码失败")
+	}
+	return val.(*QRCodeImage), nil
+}
+
+// render 将内容渲染为指定格式的二维码图片.
+func (l *QRCodeLogic) render(content, format string, size int, level qrcode.Level, withLogo bool) (*QRCodeImage, error) {
+	q, err := qrcode.New(content, level)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := qrcode.RenderOptions{Size: size}
+	if withLogo {
+		opts.Logo = l.svcCtx.QRLogo
+	}
+
+	if format == qrFormatSVG {
+		data, err := q.SVG(opts)
+		if err != nil {
+			return nil, err
+		}
+		return &QRCodeImage{ContentType: "image/svg+xml", Data: data}, nil
+	}
+
+	data, err := q.PNG(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &QRCodeImage{ContentType: "image/png", Data: data}, nil
+}
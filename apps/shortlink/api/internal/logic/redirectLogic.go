@@ -0,0 +1,126 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/svc"
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/types"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/pb/rpc"
+	"github.com/clin211/miniblog-v3/pkg/errorx"
+	"github.com/clin211/miniblog-v3/pkg/known"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// resolvedLink 短链解析结果.
+type resolvedLink struct {
+	found       bool               // 短码是否存在
+	originalURL string             // 原始URL，仅正常状态时有值
+	state       rpc.ShortLinkState // 状态
+	expireAt    int64              // 过期时间，Unix 时间戳（秒），0 表示永不过期
+	restricted  bool               // 是否设置了密码或最大点击次数
+}
+
+type RedirectLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewRedirectLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RedirectLogic {
+	return &RedirectLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// Redirect 解析短码，先查本地 LRU 缓存，未命中时调用 RPC（RPC 侧由 Redis 缓存兜底）.
+// 本地缓存只保存存在的短链，有效期只有几秒：本实例删除或修改短链时立即清除，其他实例在缓存过期后生效.
+// 不存在的短码不在本地缓存，避免新建的别名在缓存期间仍然返回 404，穷举短码的请求由 RPC 侧的 Redis 缓存拦截.
+// 设置了密码或最大点击次数的短链不会缓存，每次访问都由 RPC 校验密码并原子地占用点击次数.
+func (l *RedirectLogic) Redirect(req *types.RedirectRequest) (resp *types.RedirectResponse, err error) {
+	if req.Code == "" {
+		return nil, errorx.ErrShortLinkNotFound
+	}
+
+	link, err := l.resolve(req)
+	if err != nil {
+		err = fromRPCError(err)
+		// 密码错误次数过多是正常的拒绝，不记录错误日志
+		if !errors.Is(err, errorx.ErrShortLinkPasswordLocked) {
+			l.Errorw("解析短链失败",
+				logx.Field("code", req.Code),
+				logx.Field("error", err))
+		}
+		return nil, err
+	}
+
+	if !link.found {
+		return nil, errorx.ErrShortLinkNotFound
+	}
+	switch link.state {
+	case rpc.ShortLinkState_SHORT_LINK_STATE_ACTIVE:
+	case rpc.ShortLinkState_SHORT_LINK_STATE_LOCKED:
+		if req.Password == "" {
+			return nil, errorx.ErrShortLinkPasswordRequired
+		}
+		return nil, errorx.ErrShortLinkPasswordIncorrect
+	default:
+		return nil, errorx.ErrShortLinkGone
+	}
+	// 缓存期间短链可能已经过期
+	if link.expireAt > 0 && time.Now().Unix() >= link.expireAt {
+		return nil, errorx.ErrShortLinkGone
+	}
+
+	return &types.RedirectResponse{
+		Location: link.originalURL,
+	}, nil
+}
+
+// resolve 返回短链解析结果，仅存在且不受限的短链会写入本地缓存.
+func (l *RedirectLogic) resolve(req *types.RedirectRequest) (*resolvedLink, error) {
+	if val, ok := l.svcCtx.LinkCache.Get(req.Code); ok {
+		return val.(*resolvedLink), nil
+	}
+
+	link := &resolvedLink{}
+	// 访问者IP通过元数据转发，RPC 只在请求携带服务密钥时信任它
+	ctx := l.ctx
+	if clientIP, _ := l.ctx.Value(known.XClientIP).(string); clientIP != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, known.XClientIP, clientIP)
+	}
+	rpcResp, err := l.svcCtx.ShortLinkRpc.ResolveShortLink(ctx, &rpc.ResolveShortLinkRequest{
+		Code:     req.Code,
+		Password: req.Password,
+	})
+	if err != nil {
+		if status.Code(err) != codes.NotFound {
+			return nil, err
+		}
+	} else {
+		link = &resolvedLink{
+			found:       true,
+			originalURL: rpcResp.OriginalUrl,
+			state:       rpcResp.State,
+			expireAt:    rpcResp.ExpireAt,
+			restricted:  rpcResp.Restricted,
+		}
+	}
+
+	if link.found && !link.restricted {
+		l.svcCtx.LinkCache.Set(req.Code, link)
+	}
+	return link, nil
+}
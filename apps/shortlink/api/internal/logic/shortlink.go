@@ -0,0 +1,51 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"context"
+
+	"github.com/clin211/miniblog-v3/pkg/errorx"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// outgoingContext 创建携带 token 的 gRPC 上下文，token 由认证中间件写入.
+func outgoingContext(ctx context.Context) (context.Context, error) {
+	token, ok := ctx.Value("auth_token").(string)
+	if !ok {
+		return nil, errorx.ErrTokenInvalid
+	}
+
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + token,
+	})
+	return metadata.NewOutgoingContext(ctx, md), nil
+}
+
+// fromRPCError 将短链 RPC 返回的 gRPC 错误转换为 errorx 错误.
+// RPC 返回的 errorx 错误原样还原；其他错误按 gRPC 错误码推断时，
+// NotFound、PermissionDenied 和 AlreadyExists 映射为短链模块的错误，比通用转换的错误码更具体.
+func fromRPCError(err error) error {
+	if st, ok := status.FromError(err); ok {
+		if e, ok := errorx.FromGRPCStatus(st); ok {
+			return e
+		}
+	}
+
+	switch status.Code(err) {
+	case codes.NotFound:
+		return errorx.ErrShortLinkNotFound
+	case codes.PermissionDenied:
+		return errorx.ErrShortLinkForbidden
+	case codes.AlreadyExists:
+		return errorx.ErrShortLinkAliasExists
+	default:
+		return errorx.FromGRPCError(err)
+	}
+}
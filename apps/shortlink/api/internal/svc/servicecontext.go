@@ -0,0 +1,103 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package svc
+
+import (
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/clickstat"
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/config"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/pb/rpc"
+	"github.com/clin211/miniblog-v3/pkg/geo"
+	"github.com/clin211/miniblog-v3/pkg/middleware"
+	"github.com/zeromicro/go-zero/core/collection"
+	"github.com/zeromicro/go-zero/core/proc"
+	"github.com/zeromicro/go-zero/rest"
+	"github.com/zeromicro/go-zero/zrpc"
+)
+
+type ServiceContext struct {
+	Config          config.Config
+	ShortLinkRpc    rpc.ShortLinkClient
+	AuthnMiddleware rest.Middleware
+	// LinkCache 本地 LRU 缓存，保存热点短码的解析结果
+	LinkCache *collection.Cache
+	// ClickCollector 收集重定向的点击事件
+	ClickCollector *clickstat.Collector
+	// QRCache 本地 LRU 缓存，按参数缓存渲染好的二维码图片
+	QRCache *collection.Cache
+	// QRLogo 二维码中间的 logo，未配置时为 nil
+	QRLogo image.Image
+}
+
+func NewServiceContext(c config.Config) *ServiceContext {
+	linkCache, err := collection.NewCache(c.LocalCache.Expire,
+		collection.WithLimit(c.LocalCache.Limit),
+		collection.WithName("shortlink"))
+	if err != nil {
+		panic(err)
+	}
+
+	// 配置了离线 IP 库时按 IP 统计国家或地区
+	var locator geo.Locator = geo.Nop{}
+	if c.ClickStats.GeoDB != "" {
+		offline, err := geo.NewOffline(c.ClickStats.GeoDB)
+		if err != nil {
+			panic(err)
+		}
+		locator = offline
+	}
+
+	qrCache, err := collection.NewCache(c.QRCode.CacheExpire,
+		collection.WithLimit(c.QRCode.CacheLimit),
+		collection.WithName("qrcode"))
+	if err != nil {
+		panic(err)
+	}
+	var qrLogo image.Image
+	if c.QRCode.LogoPath != "" {
+		qrLogo = mustLoadImage(c.QRCode.LogoPath)
+	}
+
+	// 将协商出的语言传递给RPC服务，RPC返回的错误信息按该语言输出；
+	// 同时携带服务密钥，RPC服务只接受携带密钥的点击事件上报
+	shortLinkRpc := rpc.NewShortLinkClient(zrpc.MustNewClient(c.ShortLinkRpc,
+		zrpc.WithUnaryClientInterceptor(middleware.LocaleClientInterceptor()),
+		zrpc.WithUnaryClientInterceptor(middleware.ServiceClientInterceptor(c.ServiceAuth.Secret))).Conn())
+
+	// 启动点击事件上报协程，服务退出时上报缓冲区中剩余的事件
+	clickCollector := clickstat.NewCollector(c.ClickStats, shortLinkRpc, locator)
+	clickCollector.Start()
+	proc.AddShutdownListener(clickCollector.Stop)
+
+	return &ServiceContext{
+		Config:          c,
+		ShortLinkRpc:    shortLinkRpc,
+		AuthnMiddleware: middleware.NewAuthnMiddleware().Handle,
+		LinkCache:       linkCache,
+		ClickCollector:  clickCollector,
+		QRCache:         qrCache,
+		QRLogo:          qrLogo,
+	}
+}
+
+// mustLoadImage 加载 PNG 或 JPEG 图片，失败时 panic.
+func mustLoadImage(path string) image.Image {
+	f, err := os.Open(path)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		panic(err)
+	}
+	return img
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/config"
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/handler"
+	"github.com/clin211/miniblog-v3/apps/shortlink/api/internal/svc"
+	"github.com/clin211/miniblog-v3/pkg/middleware"
+
+	"github.com/zeromicro/go-zero/core/conf"
+	"github.com/zeromicro/go-zero/rest"
+)
+
+var configFile = flag.String("f", "etc/shortlink.yaml", "the config file")
+
+func main() {
+	flag.Parse()
+
+	var c config.Config
+	conf.MustLoad(*configFile, &c)
+
+	server := rest.MustNewServer(c.RestConf)
+	defer server.Stop()
+
+	// 为每个请求分配请求 ID，错误响应通过它关联服务端日志
+	server.Use(middleware.RequestIDMiddleware)
+	// 根据 Accept-Language 协商响应语言
+	server.Use(middleware.LocaleMiddleware)
+	// Accept 为 application/problem+json 时按 RFC 7807 输出错误
+	server.Use(middleware.ProblemMiddleware)
+
+	ctx := svc.NewServiceContext(c)
+	handler.RegisterHandlers(server, ctx)
+
+	fmt.Printf("Starting server at %s:%d...\n", c.Host, c.Port)
+	server.Start()
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package models
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+var _ ShortLinkClicksModel = (*customShortLinkClicksModel)(nil)
+
+type (
+	// ShortLinkClicksModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customShortLinkClicksModel.
+	ShortLinkClicksModel interface {
+		shortLinkClicksModel
+		// Take 在点击次数未达到 maxClicks 时为短链占用一次点击，返回是否占用成功
+		Take(ctx context.Context, code string, maxClicks int64) (bool, error)
+		// Count 返回短链已占用的点击次数，没有记录时返回 0
+		Count(ctx context.Context, code string) (int64, error)
+	}
+
+	customShortLinkClicksModel struct {
+		*defaultShortLinkClicksModel
+	}
+)
+
+// NewShortLinkClicksModel returns a model for the database table.
+func NewShortLinkClicksModel(conn sqlx.SqlConn) ShortLinkClicksModel {
+	return &customShortLinkClicksModel{
+		defaultShortLinkClicksModel: newShortLinkClicksModel(conn),
+	}
+}
+
+func (m *customShortLinkClicksModel) Take(ctx context.Context, code string, maxClicks int64) (bool, error) {
+	// 判断和递增在一条语句中完成，并发访问时不会超出上限. 达到上限时行不变，影响行数为 0
+	query := fmt.Sprintf("insert into %s (`code`, `clicks`) values (?, 1) on duplicate key update `clicks` = if(`clicks` < ?, `clicks` + 1, `clicks`)", m.table)
+	ret, err := m.conn.ExecCtx(ctx, query, code, maxClicks)
+	if err != nil {
+		return false, err
+	}
+	n, err := ret.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (m *customShortLinkClicksModel) Count(ctx context.Context, code string) (int64, error) {
+	row, err := m.FindOneByCode(ctx, code)
+	switch err {
+	case nil:
+		return row.Clicks, nil
+	case ErrNotFound:
+		return 0, nil
+	default:
+		return 0, err
+	}
+}
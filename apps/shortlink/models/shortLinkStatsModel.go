@@ -0,0 +1,112 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+const (
+	// StatsGranularityHour 按小时聚合
+	StatsGranularityHour = 1
+	// StatsGranularityDay 按天聚合
+	StatsGranularityDay = 2
+
+	// StatsDimensionTotal 总点击次数
+	StatsDimensionTotal = "total"
+	// StatsDimensionReferrer 来源站点
+	StatsDimensionReferrer = "referrer"
+	// StatsDimensionBrowser 浏览器
+	StatsDimensionBrowser = "browser"
+	// StatsDimensionOS 操作系统
+	StatsDimensionOS = "os"
+	// StatsDimensionDevice 设备类型
+	StatsDimensionDevice = "device"
+	// StatsDimensionCountry 国家或地区
+	StatsDimensionCountry = "country"
+
+	// statsIncrBatchSize 单条 SQL 累加的最大行数
+	statsIncrBatchSize = 500
+)
+
+var _ ShortLinkStatsModel = (*customShortLinkStatsModel)(nil)
+
+type (
+	// ShortLinkStatsModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customShortLinkStatsModel.
+	ShortLinkStatsModel interface {
+		shortLinkStatsModel
+		// Incr 按唯一索引累加点击次数，记录不存在时插入
+		Incr(ctx context.Context, stats []*ShortLinkStats) error
+		// FindSeries 查询 [start, end) 范围内指定粒度的总点击次数，按时间升序
+		FindSeries(ctx context.Context, code string, granularity int64, start, end time.Time) ([]*ShortLinkStats, error)
+		// FindTop 基于天粒度统计 [start, end) 范围内某个维度点击次数最多的取值
+		FindTop(ctx context.Context, code, dimension string, start, end time.Time, limit int) ([]*StatsCount, error)
+	}
+
+	customShortLinkStatsModel struct {
+		*defaultShortLinkStatsModel
+	}
+
+	// StatsCount 为维度取值及其点击次数.
+	StatsCount struct {
+		Value  string `db:"value"`  // 维度取值
+		Clicks int64  `db:"clicks"` // 点击次数
+	}
+)
+
+// NewShortLinkStatsModel returns a model for the database table.
+func NewShortLinkStatsModel(conn sqlx.SqlConn) ShortLinkStatsModel {
+	return &customShortLinkStatsModel{
+		defaultShortLinkStatsModel: newShortLinkStatsModel(conn),
+	}
+}
+
+func (m *customShortLinkStatsModel) Incr(ctx context.Context, stats []*ShortLinkStats) error {
+	for start := 0; start < len(stats); start += statsIncrBatchSize {
+		end := min(start+statsIncrBatchSize, len(stats))
+		batch := stats[start:end]
+
+		placeholders := make([]string, 0, len(batch))
+		args := make([]any, 0, len(batch)*6)
+		for _, s := range batch {
+			placeholders = append(placeholders, "(?, ?, ?, ?, ?, ?)")
+			args = append(args, s.Code, s.Granularity, s.BucketAt, s.Dimension, s.Value, s.Clicks)
+		}
+
+		query := fmt.Sprintf("insert into %s (%s) values %s on duplicate key update `clicks` = `clicks` + values(`clicks`)",
+			m.table, shortLinkStatsRowsExpectAutoSet, strings.Join(placeholders, ", "))
+		if _, err := m.conn.ExecCtx(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *customShortLinkStatsModel) FindSeries(ctx context.Context, code string, granularity int64, start, end time.Time) ([]*ShortLinkStats, error) {
+	query := fmt.Sprintf("select %s from %s where `code` = ? and `dimension` = ? and `granularity` = ? and `bucket_at` >= ? and `bucket_at` < ? order by `bucket_at`",
+		shortLinkStatsRows, m.table)
+	var resp []*ShortLinkStats
+	if err := m.conn.QueryRowsCtx(ctx, &resp, query, code, StatsDimensionTotal, granularity, start, end); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (m *customShortLinkStatsModel) FindTop(ctx context.Context, code, dimension string, start, end time.Time, limit int) ([]*StatsCount, error) {
+	query := fmt.Sprintf("select `value`, sum(`clicks`) as `clicks` from %s where `code` = ? and `dimension` = ? and `granularity` = ? and `bucket_at` >= ? and `bucket_at` < ? group by `value` order by `clicks` desc limit ?",
+		m.table)
+	var resp []*StatsCount
+	if err := m.conn.QueryRowsCtx(ctx, &resp, query, code, dimension, StatsGranularityDay, start, end, limit); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
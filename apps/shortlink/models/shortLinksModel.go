@@ -0,0 +1,32 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package models
+
+import (
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+var _ ShortLinksModel = (*customShortLinksModel)(nil)
+
+type (
+	// ShortLinksModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customShortLinksModel.
+	ShortLinksModel interface {
+		shortLinksModel
+	}
+
+	customShortLinksModel struct {
+		*defaultShortLinksModel
+	}
+)
+
+// NewShortLinksModel returns a model for the database table.
+func NewShortLinksModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) ShortLinksModel {
+	return &customShortLinksModel{
+		defaultShortLinksModel: newShortLinksModel(conn, c, opts...),
+	}
+}
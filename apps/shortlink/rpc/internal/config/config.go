@@ -0,0 +1,53 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package config
+
+import (
+	"time"
+
+	"github.com/clin211/miniblog-v3/pkg/id"
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/zrpc"
+)
+
+type Config struct {
+	zrpc.RpcServerConf
+	Cache cache.CacheConf
+
+	// MySQL 数据库
+	Mysql struct {
+		DataSource string
+	}
+
+	// 短链配置
+	ShortLink struct {
+		Domain        string        // 短链域名，例如 https://clin.pro
+		DefaultExpire time.Duration `json:",default=720h"` // 默认有效期，默认一个月
+		CodeLength    int           `json:",default=8"`    // 短码长度
+		// ReservedAliases 额外的保留别名，与内置保留字一起禁止作为自定义别名
+		ReservedAliases []string `json:",optional"`
+	}
+
+	// 访问密码错误次数限制，防止穷举短链密码
+	PasswordAttempts struct {
+		MaxPerIP int           `json:",default=5"`   // 同一IP在窗口内最多输错的次数，不区分短链
+		Window   time.Duration `json:",default=15m"` // 错误次数的统计窗口
+		Lockout  time.Duration `json:",default=15m"` // 达到上限后禁止尝试的时间
+	}
+
+	// Sonyflake 配置，用于生成短码
+	Sonyflake id.SonyflakeConf
+
+	// 内部服务调用配置，RecordClicks 等方法只接受携带该密钥的调用
+	ServiceAuth struct {
+		Secret string // 服务密钥，必须与调用方配置的密钥一致
+	}
+
+	// 服务配置
+	Service struct {
+		Name string
+	}
+}
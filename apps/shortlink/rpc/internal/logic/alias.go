@@ -0,0 +1,84 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/clin211/miniblog-v3/pkg/errorx"
+)
+
+// generatedCodeChars 系统生成短码使用的字符集，与 pkg/id 的默认字符集一致.
+const generatedCodeChars = "23456789ABCDEFGHJKLMNPQRSTVWXY"
+
+// aliasPattern 自定义别名只能包含字母、数字、下划线和中划线，长度 3~32.
+var aliasPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{3,32}$`)
+
+// reservedAliases 内置保留字，避免别名与现有路由或常用入口混淆.
+var reservedAliases = map[string]struct{}{
+	"admin":     {},
+	"api":       {},
+	"app":       {},
+	"assets":    {},
+	"blog":      {},
+	"favicon":   {},
+	"health":    {},
+	"help":      {},
+	"login":     {},
+	"logout":    {},
+	"null":      {},
+	"post":      {},
+	"posts":     {},
+	"qr":        {},
+	"register":  {},
+	"robots":    {},
+	"rpc":       {},
+	"s":         {},
+	"shortlink": {},
+	"sitemap":   {},
+	"static":    {},
+	"undefined": {},
+	"user":      {},
+	"users":     {},
+	"www":       {},
+}
+
+// validateAlias 验证自定义别名：格式合法、不是保留字，且不会与系统生成的短码冲突.
+func validateAlias(alias string, codeLength int, extraReserved []string) error {
+	if !aliasPattern.MatchString(alias) {
+		return errorx.ErrShortLinkAliasInvalid.WithMessage("别名只能包含字母、数字、下划线和中划线，长度为3-32个字符")
+	}
+
+	lower := strings.ToLower(alias)
+	if _, ok := reservedAliases[lower]; ok {
+		return errorx.ErrShortLinkAliasInvalid.WithMessage("别名 %s 为保留字", alias)
+	}
+	for _, word := range extraReserved {
+		if strings.EqualFold(word, alias) {
+			return errorx.ErrShortLinkAliasInvalid.WithMessage("别名 %s 为保留字", alias)
+		}
+	}
+
+	// 与生成短码格式相同的别名可能在将来与生成的短码冲突，忽略大小写判断以免混淆
+	if looksLikeGeneratedCode(alias, codeLength) {
+		return errorx.ErrShortLinkAliasInvalid.WithMessage("别名不能与系统生成的短码格式相同")
+	}
+	return nil
+}
+
+// looksLikeGeneratedCode 判断字符串是否可能是系统生成的短码.
+func looksLikeGeneratedCode(s string, codeLength int) bool {
+	if len(s) != codeLength {
+		return false
+	}
+	for _, r := range strings.ToUpper(s) {
+		if !strings.ContainsRune(generatedCodeChars, r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,343 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/models"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/internal/svc"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/pb/rpc"
+	"github.com/clin211/miniblog-v3/pkg/encrypt"
+	"github.com/clin211/miniblog-v3/pkg/errorx"
+	"github.com/clin211/miniblog-v3/pkg/id"
+	"github.com/clin211/miniblog-v3/pkg/known"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const (
+	// maxURLLength 原始 URL 的最大长度，与数据库字段长度一致
+	maxURLLength = 2048
+	// maxCodeAttempts 短码冲突时的最大重试次数
+	maxCodeAttempts = 3
+	// minPasswordLength 访问密码的最小长度
+	minPasswordLength = 4
+	// maxPasswordLength 访问密码的最大长度
+	maxPasswordLength = 32
+	// maxExternalKeyLength 外部键的最大长度，与数据库字段长度一致
+	maxExternalKeyLength = 64
+)
+
+var (
+	// externalKeyPattern 外部键只允许字母、数字和 : _ - . 字符
+	externalKeyPattern = regexp.MustCompile(`^[A-Za-z0-9:_.-]+$`)
+
+	// errExternalKeyConflict 写入时外部键已被占用，通常是并发创建同一个外部键
+	errExternalKeyConflict = errors.New("external key conflict")
+)
+
+type CreateShortLinkLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewCreateShortLinkLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateShortLinkLogic {
+	return &CreateShortLinkLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// CreateShortLink 创建短链
+//
+// 传入 external_key 时按用户和外部键创建或复用短链，重复调用返回同一条短链，调用方可以安全重试.
+// 复用时只同步原始 URL；别名、密码、最大点击次数或过期时间与已有短链不同时返回
+// ErrShortLinkExternalKeyConflict，不会静默忽略这些参数.
+// 文章发布时自动生成短链尚未接入：博客服务接入时由 blog-rpc 在发布后以 external_key=post:<id>
+// 调用本方法并保存返回的短链，调用失败不影响发布，由博客服务的补偿任务重试.
+func (l *CreateShortLinkLogic) CreateShortLink(in *rpc.CreateShortLinkRequest) (*rpc.CreateShortLinkResponse, error) {
+	// 从context中获取用户ID（由拦截器设置）
+	userID, ok := l.ctx.Value(known.XUserID).(string)
+	if !ok {
+		l.Errorw("从context中获取用户ID失败")
+		return nil, errorx.ToGRPCError(errorx.ErrTokenInvalid)
+	}
+
+	// 1. 参数验证
+	if err := validateOriginalURL(in.OriginalUrl); err != nil {
+		return nil, errorx.ToGRPCError(err)
+	}
+	expireAt, err := l.resolveExpireAt(in.ExpireAt)
+	if err != nil {
+		return nil, errorx.ToGRPCError(err)
+	}
+	if in.MaxClicks < 0 {
+		return nil, errorx.ToGRPCError(errorx.ErrInvalidParameter.WithMessage("最大点击次数不能为负数"))
+	}
+	if err := validateExternalKey(in.ExternalKey); err != nil {
+		return nil, errorx.ToGRPCError(err)
+	}
+
+	link := &models.ShortLinks{
+		UserId:      userID,
+		OriginalUrl: in.OriginalUrl,
+		MaxClicks:   in.MaxClicks,
+		ExternalKey: sql.NullString{String: in.ExternalKey, Valid: in.ExternalKey != ""},
+		Status:      statusActive,
+		ExpireAt:    expireAt,
+	}
+
+	// 2. 外部键已有可用的短链时直接复用，保证调用方重试时拿到同一条短链
+	if link.ExternalKey.Valid {
+		existing, err := l.findByExternalKey(userID, link.ExternalKey)
+		if err != nil {
+			return nil, errorx.ToGRPCError(err)
+		}
+		if existing != nil {
+			return l.reuse(existing, in)
+		}
+	}
+
+	// 3. 访问密码加密存储
+	if in.Password != "" {
+		if len(in.Password) < minPasswordLength || len(in.Password) > maxPasswordLength {
+			return nil, errorx.ToGRPCError(errorx.ErrInvalidParameter.WithMessage("访问密码长度必须在%d-%d个字符之间", minPasswordLength, maxPasswordLength))
+		}
+		hashed, err := encrypt.Encrypt(in.Password)
+		if err != nil {
+			l.Errorw("访问密码加密失败", logx.Field("error", err))
+			return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("访问密码加密失败"))
+		}
+		link.Password = hashed
+	}
+
+	// 4. 使用自定义别名或生成短码写入
+	if in.Alias != "" {
+		err = l.createWithAlias(link, in.Alias)
+	} else {
+		err = l.createWithGeneratedCode(link)
+	}
+	if errors.Is(err, errExternalKeyConflict) {
+		// 并发创建同一个外部键，以先写入的一方为准
+		existing, err := l.findByExternalKey(userID, link.ExternalKey)
+		if err != nil {
+			return nil, errorx.ToGRPCError(err)
+		}
+		if existing == nil {
+			return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("创建短链失败"))
+		}
+		return l.reuse(existing, in)
+	}
+	if err != nil {
+		return nil, errorx.ToGRPCError(err)
+	}
+
+	l.Infow("创建短链成功",
+		logx.Field("userId", userID),
+		logx.Field("code", link.Code),
+		logx.Field("isAlias", link.IsAlias))
+
+	return &rpc.CreateShortLinkResponse{
+		Code:        link.Code,
+		ShortUrl:    shortURL(l.svcCtx.Config.ShortLink.Domain, link.Code),
+		OriginalUrl: link.OriginalUrl,
+		ExpireAt:    formatExpireAt(link),
+	}, nil
+}
+
+// createWithAlias 使用自定义别名写入短链，别名已被占用时返回冲突错误.
+func (l *CreateShortLinkLogic) createWithAlias(link *models.ShortLinks, alias string) error {
+	conf := l.svcCtx.Config.ShortLink
+	if err := validateAlias(alias, conf.CodeLength, conf.ReservedAliases); err != nil {
+		return err
+	}
+
+	link.Code = alias
+	link.IsAlias = 1
+	if _, err := l.svcCtx.ShortLinkModel.Insert(l.ctx, link); err != nil {
+		if isDuplicateExternalKey(err) {
+			return errExternalKeyConflict
+		}
+		if isDuplicateEntry(err) {
+			return errorx.ErrShortLinkAliasExists
+		}
+		l.Errorw("创建短链失败",
+			logx.Field("alias", alias),
+			logx.Field("error", err))
+		return errorx.InternalServerError.WithMessage("创建短链失败")
+	}
+	return nil
+}
+
+// createWithGeneratedCode 生成短码写入短链，短码冲突时重新生成.
+func (l *CreateShortLinkLogic) createWithGeneratedCode(link *models.ShortLinks) error {
+	for attempt := 1; ; attempt++ {
+		code, err := l.newCode()
+		if err != nil {
+			l.Errorw("生成短码失败", logx.Field("error", err))
+			return errorx.InternalServerError.WithMessage("生成短码失败")
+		}
+		link.Code = code
+		_, err = l.svcCtx.ShortLinkModel.Insert(l.ctx, link)
+		if err == nil {
+			return nil
+		}
+		if isDuplicateExternalKey(err) {
+			return errExternalKeyConflict
+		}
+		if !isDuplicateEntry(err) || attempt >= maxCodeAttempts {
+			l.Errorw("创建短链失败",
+				logx.Field("attempt", attempt),
+				logx.Field("error", err))
+			return errorx.InternalServerError.WithMessage("创建短链失败")
+		}
+		l.Infow("短码冲突，重新生成", logx.Field("code", link.Code))
+	}
+}
+
+// findByExternalKey 查询外部键对应的可用短链，不存在时返回 nil.
+// 已过期的短链会释放外部键，以便重新生成一条新的短链.
+func (l *CreateShortLinkLogic) findByExternalKey(userID string, key sql.NullString) (*models.ShortLinks, error) {
+	link, err := l.svcCtx.ShortLinkModel.FindOneByUserIdExternalKey(l.ctx, userID, key)
+	if err == models.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		l.Errorw("查询短链失败",
+			logx.Field("externalKey", key.String),
+			logx.Field("error", err))
+		return nil, errorx.InternalServerError.WithMessage("查询短链失败")
+	}
+	if linkState(link, time.Now()) == rpc.ShortLinkState_SHORT_LINK_STATE_ACTIVE {
+		return link, nil
+	}
+
+	link.ExternalKey = sql.NullString{}
+	if err := l.svcCtx.ShortLinkModel.Update(l.ctx, link); err != nil {
+		l.Errorw("释放短链外部键失败",
+			logx.Field("code", link.Code),
+			logx.Field("error", err))
+		return nil, errorx.InternalServerError.WithMessage("创建短链失败")
+	}
+	return nil, nil
+}
+
+// reuse 复用外部键对应的短链，原始 URL 变化时（例如文章修改了路径）同步更新.
+// 其他参数与已有短链不同时返回冲突错误.
+func (l *CreateShortLinkLogic) reuse(link *models.ShortLinks, in *rpc.CreateShortLinkRequest) (*rpc.CreateShortLinkResponse, error) {
+	if !sameOptions(link, in) {
+		return nil, errorx.ToGRPCError(errorx.ErrShortLinkExternalKeyConflict)
+	}
+
+	if link.OriginalUrl != in.OriginalUrl {
+		link.OriginalUrl = in.OriginalUrl
+		if err := l.svcCtx.ShortLinkModel.Update(l.ctx, link); err != nil {
+			l.Errorw("更新短链失败",
+				logx.Field("code", link.Code),
+				logx.Field("error", err))
+			return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("更新短链失败"))
+		}
+	}
+
+	l.Infow("复用短链",
+		logx.Field("userId", link.UserId),
+		logx.Field("code", link.Code),
+		logx.Field("externalKey", link.ExternalKey.String))
+
+	return &rpc.CreateShortLinkResponse{
+		Code:        link.Code,
+		ShortUrl:    shortURL(l.svcCtx.Config.ShortLink.Domain, link.Code),
+		OriginalUrl: link.OriginalUrl,
+		ExpireAt:    formatExpireAt(link),
+		Reused:      true,
+	}, nil
+}
+
+// sameOptions 返回请求中除原始 URL 以外的参数是否与已有短链一致.
+// 未指定别名时不比较短码；expire_at 为 0 表示使用默认有效期，重试时无法与首次计算的时间比较，视为一致.
+func sameOptions(link *models.ShortLinks, in *rpc.CreateShortLinkRequest) bool {
+	if in.Alias != "" && (link.IsAlias != 1 || link.Code != in.Alias) {
+		return false
+	}
+	if in.MaxClicks != link.MaxClicks {
+		return false
+	}
+	switch {
+	case in.Password == "" && link.Password != "", in.Password != "" && link.Password == "":
+		return false
+	case in.Password != "" && encrypt.Compare(link.Password, in.Password) != nil:
+		return false
+	}
+	switch in.ExpireAt {
+	case 0:
+		return true
+	case -1:
+		return !link.ExpireAt.Valid
+	default:
+		return link.ExpireAt.Valid && link.ExpireAt.Time.Unix() == in.ExpireAt
+	}
+}
+
+// newCode 基于 Sonyflake 生成的数值 ID 编码出短码.
+func (l *CreateShortLinkLogic) newCode() (string, error) {
+	num, err := l.svcCtx.Sonyflake.Id(l.ctx)
+	if err != nil {
+		return "", err
+	}
+	return id.NewCode(num, id.WithCodeL(l.svcCtx.Config.ShortLink.CodeLength)), nil
+}
+
+// resolveExpireAt 计算过期时间：0 使用默认有效期，-1 表示永不过期.
+func (l *CreateShortLinkLogic) resolveExpireAt(expireAt int64) (sql.NullTime, error) {
+	now := time.Now()
+	switch {
+	case expireAt == 0:
+		return sql.NullTime{Time: now.Add(l.svcCtx.Config.ShortLink.DefaultExpire), Valid: true}, nil
+	case expireAt == -1:
+		return sql.NullTime{}, nil
+	case expireAt < 0 || !time.Unix(expireAt, 0).After(now):
+		return sql.NullTime{}, errorx.ErrInvalidParameter.WithMessage("过期时间必须晚于当前时间")
+	default:
+		return sql.NullTime{Time: time.Unix(expireAt, 0), Valid: true}, nil
+	}
+}
+
+// validateOriginalURL 验证原始 URL，仅允许 http 和 https 协议.
+func validateOriginalURL(raw string) error {
+	if raw == "" {
+		return errorx.ErrInvalidParameter.WithMessage("原始URL不能为空")
+	}
+	if len(raw) > maxURLLength {
+		return errorx.ErrInvalidParameter.WithMessage("原始URL长度不能超过%d个字符", maxURLLength)
+	}
+	u, err := url.ParseRequestURI(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return errorx.ErrInvalidParameter.WithMessage("原始URL格式不正确")
+	}
+	return nil
+}
+
+// validateExternalKey 验证外部键，为空表示不使用外部键.
+func validateExternalKey(key string) error {
+	if key == "" {
+		return nil
+	}
+	if len(key) > maxExternalKeyLength {
+		return errorx.ErrInvalidParameter.WithMessage("外部键长度不能超过%d个字符", maxExternalKeyLength)
+	}
+	if !externalKeyPattern.MatchString(key) {
+		return errorx.ErrInvalidParameter.WithMessage("外部键只能包含字母、数字和 : _ - . 字符")
+	}
+	return nil
+}
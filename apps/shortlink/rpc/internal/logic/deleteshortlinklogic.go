@@ -0,0 +1,89 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/models"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/internal/svc"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/pb/rpc"
+	"github.com/clin211/miniblog-v3/pkg/errorx"
+	"github.com/clin211/miniblog-v3/pkg/known"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteShortLinkLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewDeleteShortLinkLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteShortLinkLogic {
+	return &DeleteShortLinkLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// DeleteShortLink 删除短链，仅创建者可删除
+func (l *DeleteShortLinkLogic) DeleteShortLink(in *rpc.DeleteShortLinkRequest) (*rpc.DeleteShortLinkResponse, error) {
+	// 从context中获取用户ID（由拦截器设置）
+	userID, ok := l.ctx.Value(known.XUserID).(string)
+	if !ok {
+		l.Errorw("从context中获取用户ID失败")
+		return nil, errorx.ToGRPCError(errorx.ErrTokenInvalid)
+	}
+
+	if in.Code == "" {
+		return nil, errorx.ToGRPCError(errorx.ErrInvalidParameter.WithMessage("短码不能为空"))
+	}
+
+	link, err := l.svcCtx.ShortLinkModel.FindOneByCode(l.ctx, in.Code)
+	if err != nil {
+		if err == models.ErrNotFound {
+			return nil, errorx.ToGRPCError(errorx.ErrShortLinkNotFound)
+		}
+		l.Errorw("查询短链失败",
+			logx.Field("code", in.Code),
+			logx.Field("error", err))
+		return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("查询短链失败"))
+	}
+	if link.Status == statusDeleted {
+		return nil, errorx.ToGRPCError(errorx.ErrShortLinkNotFound)
+	}
+
+	// 验证用户权限
+	if link.UserId != userID {
+		l.Errorw("用户权限不足",
+			logx.Field("currentUserID", userID),
+			logx.Field("code", in.Code))
+		return nil, errorx.ToGRPCError(errorx.ErrShortLinkForbidden)
+	}
+
+	// 软删除短链，短码不会被复用，重定向时返回 410；外部键随之释放，可以重新创建
+	link.Status = statusDeleted
+	link.DeletedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	link.ExternalKey = sql.NullString{}
+	if err := l.svcCtx.ShortLinkModel.Update(l.ctx, link); err != nil {
+		l.Errorw("删除短链失败",
+			logx.Field("code", in.Code),
+			logx.Field("error", err))
+		return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("删除短链失败"))
+	}
+
+	l.Infow("删除短链成功",
+		logx.Field("userId", userID),
+		logx.Field("code", in.Code))
+
+	return &rpc.DeleteShortLinkResponse{
+		Success: true,
+	}, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"context"
+	"time"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/models"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/internal/svc"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/pb/rpc"
+	"github.com/clin211/miniblog-v3/pkg/errorx"
+	"github.com/clin211/miniblog-v3/pkg/known"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetShortLinkLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetShortLinkLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetShortLinkLogic {
+	return &GetShortLinkLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// GetShortLink 获取短链信息，仅创建者可查询
+func (l *GetShortLinkLogic) GetShortLink(in *rpc.GetShortLinkRequest) (*rpc.GetShortLinkResponse, error) {
+	// 从context中获取用户ID（由拦截器设置）
+	userID, ok := l.ctx.Value(known.XUserID).(string)
+	if !ok {
+		l.Errorw("从context中获取用户ID失败")
+		return nil, errorx.ToGRPCError(errorx.ErrTokenInvalid)
+	}
+
+	if in.Code == "" {
+		return nil, errorx.ToGRPCError(errorx.ErrInvalidParameter.WithMessage("短码不能为空"))
+	}
+
+	// 查询短链，已删除的短链对创建者同样不可见
+	link, err := l.svcCtx.ShortLinkModel.FindOneByCode(l.ctx, in.Code)
+	if err != nil {
+		if err == models.ErrNotFound {
+			return nil, errorx.ToGRPCError(errorx.ErrShortLinkNotFound)
+		}
+		l.Errorw("查询短链失败",
+			logx.Field("code", in.Code),
+			logx.Field("error", err))
+		return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("查询短链失败"))
+	}
+	if link.Status == statusDeleted {
+		return nil, errorx.ToGRPCError(errorx.ErrShortLinkNotFound)
+	}
+
+	// 验证用户权限
+	if link.UserId != userID {
+		l.Errorw("用户权限不足",
+			logx.Field("currentUserID", userID),
+			logx.Field("code", in.Code))
+		return nil, errorx.ToGRPCError(errorx.ErrShortLinkForbidden)
+	}
+
+	resp := &rpc.GetShortLinkResponse{
+		Code:              link.Code,
+		ShortUrl:          shortURL(l.svcCtx.Config.ShortLink.Domain, link.Code),
+		OriginalUrl:       link.OriginalUrl,
+		UserId:            link.UserId,
+		State:             linkState(link, time.Now()),
+		ExpireAt:          formatExpireAt(link),
+		CreatedAt:         link.CreatedAt.Format(timeLayout),
+		UpdatedAt:         link.UpdatedAt.Format(timeLayout),
+		IsAlias:           link.IsAlias == 1,
+		MaxClicks:         link.MaxClicks,
+		PasswordProtected: link.Password != "",
+	}
+
+	// 仅设置了最大点击次数的短链才会计数
+	if link.MaxClicks > 0 {
+		clicks, err := l.svcCtx.ClicksModel.Count(l.ctx, link.Code)
+		if err != nil {
+			l.Errorw("查询短链点击次数失败",
+				logx.Field("code", in.Code),
+				logx.Field("error", err))
+			return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("查询短链失败"))
+		}
+		resp.Clicks = clicks
+		if resp.State == rpc.ShortLinkState_SHORT_LINK_STATE_ACTIVE && clicks >= link.MaxClicks {
+			resp.State = rpc.ShortLinkState_SHORT_LINK_STATE_EXHAUSTED
+		}
+	}
+	return resp, nil
+}
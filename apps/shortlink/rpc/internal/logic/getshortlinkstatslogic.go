@@ -0,0 +1,206 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"context"
+	"time"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/models"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/internal/svc"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/pb/rpc"
+	"github.com/clin211/miniblog-v3/pkg/errorx"
+	"github.com/clin211/miniblog-v3/pkg/known"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/mr"
+)
+
+const (
+	// defaultStatsTop 排行榜的默认条数
+	defaultStatsTop = 10
+	// maxStatsTop 排行榜的最大条数
+	maxStatsTop = 50
+	// maxHourBuckets 按小时查询时的最大时间桶数，约一个月
+	maxHourBuckets = 31 * 24
+	// maxDayBuckets 按天查询时的最大时间桶数，约一年
+	maxDayBuckets = 366
+)
+
+type GetShortLinkStatsLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetShortLinkStatsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetShortLinkStatsLogic {
+	return &GetShortLinkStatsLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// GetShortLinkStats 获取短链访问统计，仅创建者可查询
+//
+// 时间序列按请求的粒度返回，排行榜基于覆盖查询范围的天粒度统计.
+func (l *GetShortLinkStatsLogic) GetShortLinkStats(in *rpc.GetShortLinkStatsRequest) (*rpc.GetShortLinkStatsResponse, error) {
+	// 从context中获取用户ID（由拦截器设置）
+	userID, ok := l.ctx.Value(known.XUserID).(string)
+	if !ok {
+		l.Errorw("从context中获取用户ID失败")
+		return nil, errorx.ToGRPCError(errorx.ErrTokenInvalid)
+	}
+
+	if in.Code == "" {
+		return nil, errorx.ToGRPCError(errorx.ErrInvalidParameter.WithMessage("短码不能为空"))
+	}
+
+	// 1. 参数验证
+	granularity, start, end, err := statsRange(in, time.Now())
+	if err != nil {
+		return nil, errorx.ToGRPCError(err)
+	}
+	top := int(in.Top)
+	switch {
+	case top < 0 || top > maxStatsTop:
+		return nil, errorx.ToGRPCError(errorx.ErrInvalidParameter.WithMessage("排行榜条数必须在0-%d之间", maxStatsTop))
+	case top == 0:
+		top = defaultStatsTop
+	}
+
+	// 2. 验证用户权限，已删除的短链对创建者同样不可见
+	link, err := l.svcCtx.ShortLinkModel.FindOneByCode(l.ctx, in.Code)
+	if err != nil {
+		if err == models.ErrNotFound {
+			return nil, errorx.ToGRPCError(errorx.ErrShortLinkNotFound)
+		}
+		l.Errorw("查询短链失败",
+			logx.Field("code", in.Code),
+			logx.Field("error", err))
+		return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("查询短链失败"))
+	}
+	if link.Status == statusDeleted {
+		return nil, errorx.ToGRPCError(errorx.ErrShortLinkNotFound)
+	}
+	if link.UserId != userID {
+		l.Errorw("用户权限不足",
+			logx.Field("currentUserID", userID),
+			logx.Field("code", in.Code))
+		return nil, errorx.ToGRPCError(errorx.ErrShortLinkForbidden)
+	}
+
+	// 3. 并发查询时间序列和各维度排行
+	resp := &rpc.GetShortLinkStatsResponse{
+		Granularity: rpc.StatsGranularity(granularity),
+		StartTime:   start.Unix(),
+		EndTime:     end.Unix(),
+	}
+	topStart := truncateBucket(start, models.StatsGranularityDay)
+	topEnd := truncateBucket(end.Add(-time.Nanosecond), models.StatsGranularityDay).AddDate(0, 0, 1)
+	tops := []struct {
+		dimension string
+		items     *[]*rpc.StatsItem
+	}{
+		{models.StatsDimensionReferrer, &resp.TopReferrers},
+		{models.StatsDimensionBrowser, &resp.TopBrowsers},
+		{models.StatsDimensionOS, &resp.TopOs},
+		{models.StatsDimensionDevice, &resp.TopDevices},
+		{models.StatsDimensionCountry, &resp.TopCountries},
+	}
+
+	fns := []func() error{
+		func() error {
+			series, err := l.svcCtx.StatsModel.FindSeries(l.ctx, in.Code, granularity, start, end)
+			if err != nil {
+				return err
+			}
+			resp.Series, resp.TotalClicks = fillSeries(series, granularity, start, end)
+			return nil
+		},
+	}
+	for _, t := range tops {
+		fns = append(fns, func() error {
+			counts, err := l.svcCtx.StatsModel.FindTop(l.ctx, in.Code, t.dimension, topStart, topEnd, top)
+			if err != nil {
+				return err
+			}
+			items := make([]*rpc.StatsItem, 0, len(counts))
+			for _, c := range counts {
+				items = append(items, &rpc.StatsItem{Name: c.Value, Clicks: c.Clicks})
+			}
+			*t.items = items
+			return nil
+		})
+	}
+	if err := mr.Finish(fns...); err != nil {
+		l.Errorw("查询短链访问统计失败",
+			logx.Field("code", in.Code),
+			logx.Field("error", err))
+		return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("查询短链访问统计失败"))
+	}
+
+	return resp, nil
+}
+
+// statsRange 计算对齐到时间桶的查询范围 [start, end)，未指定时按小时查询最近 24 小时，按天查询最近 30 天.
+func statsRange(in *rpc.GetShortLinkStatsRequest, now time.Time) (int64, time.Time, time.Time, error) {
+	granularity := int64(models.StatsGranularityHour)
+	maxBuckets := maxHourBuckets
+	defaultRange := 24 * time.Hour
+	switch in.Granularity {
+	case rpc.StatsGranularity_STATS_GRANULARITY_UNSPECIFIED, rpc.StatsGranularity_STATS_GRANULARITY_HOUR:
+	case rpc.StatsGranularity_STATS_GRANULARITY_DAY:
+		granularity = models.StatsGranularityDay
+		maxBuckets = maxDayBuckets
+		defaultRange = 30 * 24 * time.Hour
+	default:
+		return 0, time.Time{}, time.Time{}, errorx.ErrInvalidParameter.WithMessage("不支持的统计粒度")
+	}
+
+	end := now
+	if in.EndTime > 0 {
+		end = time.Unix(in.EndTime, 0)
+	}
+	start := end.Add(-defaultRange)
+	if in.StartTime > 0 {
+		start = time.Unix(in.StartTime, 0)
+	}
+	if in.StartTime < 0 || in.EndTime < 0 || !start.Before(end) {
+		return 0, time.Time{}, time.Time{}, errorx.ErrInvalidParameter.WithMessage("开始时间必须早于结束时间")
+	}
+
+	// 结束时间所在的时间桶包含在查询范围内
+	start = truncateBucket(start, granularity)
+	end = nextBucket(truncateBucket(end.Add(-time.Nanosecond), granularity), granularity)
+
+	buckets := 0
+	for t := start; t.Before(end); t = nextBucket(t, granularity) {
+		if buckets++; buckets > maxBuckets {
+			return 0, time.Time{}, time.Time{}, errorx.ErrInvalidParameter.WithMessage("查询范围不能超过%d个时间桶", maxBuckets)
+		}
+	}
+	return granularity, start, end, nil
+}
+
+// fillSeries 将统计记录补齐为连续的时间序列，没有点击的时间桶点击次数为 0，同时返回总点击次数.
+func fillSeries(stats []*models.ShortLinkStats, granularity int64, start, end time.Time) ([]*rpc.StatsPoint, int64) {
+	clicks := make(map[int64]int64, len(stats))
+	for _, s := range stats {
+		clicks[s.BucketAt.Unix()] += s.Clicks
+	}
+
+	var (
+		series []*rpc.StatsPoint
+		total  int64
+	)
+	for t := start; t.Before(end); t = nextBucket(t, granularity) {
+		n := clicks[t.Unix()]
+		series = append(series, &rpc.StatsPoint{Time: t.Unix(), Clicks: n})
+		total += n
+	}
+	return series, total
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"context"
+	"net/netip"
+	"time"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/internal/config"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+// 访问密码错误次数的 Redis 键前缀，按访问者IP统计
+const (
+	passwordFailedKeyPrefix = "shortlink:password:failed:"
+	passwordLockKeyPrefix   = "shortlink:password:lock:"
+)
+
+// recordFailureScript 累计一次密码错误，达到上限时设置锁定键并清除计数.
+// 计数窗口从第一次输错开始，窗口内的错误次数不会因后续请求而延长.
+//
+// KEYS[1]: 错误计数键；KEYS[2]: 锁定键
+// ARGV[1]: 最大错误次数；ARGV[2]: 统计窗口（秒）；ARGV[3]: 锁定时间（秒）
+var recordFailureScript = redis.NewScript(`
+local n = redis.call('INCR', KEYS[1])
+if n == 1 then
+  redis.call('EXPIRE', KEYS[1], ARGV[2])
+end
+if n >= tonumber(ARGV[1]) then
+  redis.call('SET', KEYS[2], '1', 'EX', ARGV[3])
+  redis.call('DEL', KEYS[1])
+end
+return n
+`)
+
+// passwordSubject 返回统计错误次数的访问者标识. IPv6 按 /64 前缀统计，避免攻击者轮换同一网段内的地址.
+// 不按短链锁定，否则任何人都可以故意输错密码，让所有访问者在锁定期间都无法打开短链.
+func passwordSubject(clientIP string) string {
+	addr, err := netip.ParseAddr(clientIP)
+	if err != nil {
+		return clientIP
+	}
+	addr = addr.Unmap()
+	if addr.Is6() {
+		prefix, _ := addr.Prefix(64)
+		return prefix.String()
+	}
+	return addr.String()
+}
+
+// passwordLocked 返回访问者IP是否因密码错误次数过多被锁定. IP 未知时不锁定.
+func passwordLocked(ctx context.Context, rds *redis.Redis, clientIP string) (bool, error) {
+	if clientIP == "" {
+		return false, nil
+	}
+	return rds.ExistsCtx(ctx, passwordLockKeyPrefix+passwordSubject(clientIP))
+}
+
+// recordPasswordFailure 为访问者IP累计一次密码错误，同一IP输错的次数不区分短链.
+func recordPasswordFailure(ctx context.Context, rds *redis.Redis, c config.Config, clientIP string) error {
+	if clientIP == "" {
+		return nil
+	}
+	subject := passwordSubject(clientIP)
+	keys := []string{passwordFailedKeyPrefix + subject, passwordLockKeyPrefix + subject}
+	_, err := rds.ScriptRunCtx(ctx, recordFailureScript, keys, c.PasswordAttempts.MaxPerIP,
+		int64(c.PasswordAttempts.Window/time.Second), int64(c.PasswordAttempts.Lockout/time.Second))
+	return err
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"context"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/internal/svc"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/pb/rpc"
+	"github.com/clin211/miniblog-v3/pkg/errorx"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// maxRecordClicksEvents 单次上报的最大事件数
+const maxRecordClicksEvents = 5000
+
+type RecordClicksLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewRecordClicksLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RecordClicksLogic {
+	return &RecordClicksLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// RecordClicks 批量上报点击事件，仅供重定向服务携带服务密钥调用
+//
+// 事件在内存中按小时和按天聚合后，通过唯一索引累加写入统计表，原始事件不落库.
+func (l *RecordClicksLogic) RecordClicks(in *rpc.RecordClicksRequest) (*rpc.RecordClicksResponse, error) {
+	if len(in.Events) > maxRecordClicksEvents {
+		return nil, errorx.ToGRPCError(errorx.ErrInvalidParameter.WithMessage("单次上报的事件数不能超过%d", maxRecordClicksEvents))
+	}
+
+	stats, accepted := aggregateClicks(in.Events)
+	if len(stats) == 0 {
+		return &rpc.RecordClicksResponse{}, nil
+	}
+
+	if err := l.svcCtx.StatsModel.Incr(l.ctx, stats); err != nil {
+		l.Errorw("写入短链访问统计失败",
+			logx.Field("events", len(in.Events)),
+			logx.Field("error", err))
+		return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("写入短链访问统计失败"))
+	}
+
+	return &rpc.RecordClicksResponse{
+		Accepted: accepted,
+	}, nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"context"
+	"time"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/models"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/internal/svc"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/pb/rpc"
+	"github.com/clin211/miniblog-v3/pkg/encrypt"
+	"github.com/clin211/miniblog-v3/pkg/errorx"
+	"github.com/clin211/miniblog-v3/pkg/middleware"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ResolveShortLinkLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewResolveShortLinkLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ResolveShortLinkLogic {
+	return &ResolveShortLinkLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// ResolveShortLink 解析短链，供重定向使用，无需认证
+//
+// 短链记录通过模型的 Redis 缓存读取，不存在的短码同样会被缓存，避免穷举短码击穿数据库.
+// 设置了访问密码的短链先校验密码；设置了最大点击次数的短链在 MySQL 中原子地占用一次点击，
+// 只有两项检查都通过时才返回原始 URL. 同一访问者IP输错密码次数过多时，在锁定期间直接返回
+// ErrShortLinkPasswordLocked，不再比较密码. 访问者IP由 middleware.ClientIP 从可信的来源取得.
+func (l *ResolveShortLinkLogic) ResolveShortLink(in *rpc.ResolveShortLinkRequest) (*rpc.ResolveShortLinkResponse, error) {
+	if in.Code == "" {
+		return nil, errorx.ToGRPCError(errorx.ErrInvalidParameter.WithMessage("短码不能为空"))
+	}
+
+	link, err := l.svcCtx.ShortLinkModel.FindOneByCode(l.ctx, in.Code)
+	if err != nil {
+		if err == models.ErrNotFound {
+			return nil, errorx.ToGRPCError(errorx.ErrShortLinkNotFound)
+		}
+		l.Errorw("查询短链失败",
+			logx.Field("code", in.Code),
+			logx.Field("error", err))
+		return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("查询短链失败"))
+	}
+
+	resp := &rpc.ResolveShortLinkResponse{
+		State:      linkState(link, time.Now()),
+		Restricted: link.Password != "" || link.MaxClicks > 0,
+		ShortUrl:   shortURL(l.svcCtx.Config.ShortLink.Domain, link.Code),
+	}
+	if link.ExpireAt.Valid {
+		resp.ExpireAt = link.ExpireAt.Time.Unix()
+	}
+	if resp.State != rpc.ShortLinkState_SHORT_LINK_STATE_ACTIVE {
+		return resp, nil
+	}
+	if in.Peek {
+		return l.peek(link, resp)
+	}
+
+	// 1. 校验访问密码，密码错误时不占用点击次数
+	if link.Password != "" {
+		ok, err := l.verifyPassword(link, in)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			resp.State = rpc.ShortLinkState_SHORT_LINK_STATE_LOCKED
+			return resp, nil
+		}
+	}
+
+	// 2. 占用一次点击
+	if link.MaxClicks > 0 {
+		ok, err := l.svcCtx.ClicksModel.Take(l.ctx, link.Code, link.MaxClicks)
+		if err != nil {
+			l.Errorw("统计短链点击次数失败",
+				logx.Field("code", in.Code),
+				logx.Field("error", err))
+			return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("解析短链失败"))
+		}
+		if !ok {
+			resp.State = rpc.ShortLinkState_SHORT_LINK_STATE_EXHAUSTED
+			return resp, nil
+		}
+	}
+
+	// 仅正常状态的短链返回原始 URL
+	resp.OriginalUrl = link.OriginalUrl
+	return resp, nil
+}
+
+// verifyPassword 校验访问密码，返回密码是否正确. 未提交密码时不计入错误次数.
+func (l *ResolveShortLinkLogic) verifyPassword(link *models.ShortLinks, in *rpc.ResolveShortLinkRequest) (bool, error) {
+	if in.Password == "" {
+		return false, nil
+	}
+
+	clientIP := middleware.ClientIP(l.ctx, l.svcCtx.Config.ServiceAuth.Secret)
+	locked, err := passwordLocked(l.ctx, l.svcCtx.Redis, clientIP)
+	if err != nil {
+		l.Errorw("查询短链密码锁定状态失败",
+			logx.Field("code", link.Code),
+			logx.Field("error", err))
+		return false, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("解析短链失败"))
+	}
+	if locked {
+		return false, errorx.ToGRPCError(errorx.ErrShortLinkPasswordLocked)
+	}
+
+	if encrypt.Compare(link.Password, in.Password) == nil {
+		return true, nil
+	}
+	if err := recordPasswordFailure(l.ctx, l.svcCtx.Redis, l.svcCtx.Config, clientIP); err != nil {
+		l.Errorw("记录短链密码错误次数失败",
+			logx.Field("code", link.Code),
+			logx.Field("error", err))
+	}
+	return false, nil
+}
+
+// peek 仅查询短链状态，点击次数已用完时返回 EXHAUSTED.
+func (l *ResolveShortLinkLogic) peek(link *models.ShortLinks, resp *rpc.ResolveShortLinkResponse) (*rpc.ResolveShortLinkResponse, error) {
+	if link.MaxClicks > 0 {
+		clicks, err := l.svcCtx.ClicksModel.Count(l.ctx, link.Code)
+		if err != nil {
+			l.Errorw("查询短链点击次数失败",
+				logx.Field("code", link.Code),
+				logx.Field("error", err))
+			return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("解析短链失败"))
+		}
+		if clicks >= link.MaxClicks {
+			resp.State = rpc.ShortLinkState_SHORT_LINK_STATE_EXHAUSTED
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/models"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/pb/rpc"
+	"github.com/go-sql-driver/mysql"
+)
+
+const (
+	// statusActive 短链正常
+	statusActive = 1
+	// statusDeleted 短链已删除
+	statusDeleted = 0
+
+	// timeLayout 对外返回的时间格式
+	timeLayout = "2006-01-02 15:04:05"
+
+	// mysqlErrDuplicateEntry 唯一索引冲突的错误码
+	mysqlErrDuplicateEntry = 1062
+	// ukExternalKey 外部键唯一索引名称，用于区分短码冲突和外部键冲突
+	ukExternalKey = "uk_user_id_external_key"
+)
+
+// linkState 计算短链在 now 时刻的状态.
+func linkState(link *models.ShortLinks, now time.Time) rpc.ShortLinkState {
+	if link.Status == statusDeleted {
+		return rpc.ShortLinkState_SHORT_LINK_STATE_DELETED
+	}
+	if link.ExpireAt.Valid && !link.ExpireAt.Time.After(now) {
+		return rpc.ShortLinkState_SHORT_LINK_STATE_EXPIRED
+	}
+	return rpc.ShortLinkState_SHORT_LINK_STATE_ACTIVE
+}
+
+// shortURL 拼接短链访问地址.
+func shortURL(domain, code string) string {
+	return strings.TrimRight(domain, "/") + "/s/" + code
+}
+
+// formatExpireAt 格式化过期时间，永不过期时返回空字符串.
+func formatExpireAt(link *models.ShortLinks) string {
+	if !link.ExpireAt.Valid {
+		return ""
+	}
+	return link.ExpireAt.Time.Format(timeLayout)
+}
+
+// isDuplicateEntry 判断是否为唯一索引冲突错误.
+func isDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry
+}
+
+// isDuplicateExternalKey 判断是否为外部键唯一索引冲突错误.
+func isDuplicateExternalKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry &&
+		strings.Contains(mysqlErr.Message, ukExternalKey)
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/models"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/pb/rpc"
+)
+
+const (
+	// statsDirect 没有来源站点时的来源名称
+	statsDirect = "(direct)"
+	// statsUnknown 无法识别的维度取值
+	statsUnknown = "unknown"
+	// maxStatsValueLength 维度取值的最大长度，与数据库字段长度一致
+	maxStatsValueLength = 255
+)
+
+// statsKey 为统计表唯一索引对应的聚合键.
+type statsKey struct {
+	code        string
+	granularity int64
+	bucketAt    time.Time
+	dimension   string
+	value       string
+}
+
+// aggregateClicks 将点击事件聚合为统计记录：小时粒度只统计总点击次数，天粒度同时统计各维度的分布.
+// 返回聚合后的记录和有效的事件数.
+func aggregateClicks(events []*rpc.ClickEvent) ([]*models.ShortLinkStats, int64) {
+	counts := make(map[statsKey]int64)
+	var accepted int64
+	for _, e := range events {
+		if e.Code == "" || e.Timestamp <= 0 {
+			continue
+		}
+		accepted++
+
+		t := time.Unix(e.Timestamp, 0)
+		hour := truncateBucket(t, models.StatsGranularityHour)
+		day := truncateBucket(t, models.StatsGranularityDay)
+
+		counts[statsKey{e.Code, models.StatsGranularityHour, hour, models.StatsDimensionTotal, ""}]++
+		counts[statsKey{e.Code, models.StatsGranularityDay, day, models.StatsDimensionTotal, ""}]++
+		dimensions := [...]struct{ name, value string }{
+			{models.StatsDimensionReferrer, statsValue(e.Referrer, statsDirect)},
+			{models.StatsDimensionBrowser, statsValue(e.Browser, statsUnknown)},
+			{models.StatsDimensionOS, statsValue(e.Os, statsUnknown)},
+			{models.StatsDimensionDevice, statsValue(e.Device, statsUnknown)},
+			{models.StatsDimensionCountry, statsValue(e.Country, statsUnknown)},
+		}
+		for _, d := range dimensions {
+			counts[statsKey{e.Code, models.StatsGranularityDay, day, d.name, d.value}]++
+		}
+	}
+
+	stats := make([]*models.ShortLinkStats, 0, len(counts))
+	for k, clicks := range counts {
+		stats = append(stats, &models.ShortLinkStats{
+			Code:        k.code,
+			Granularity: k.granularity,
+			BucketAt:    k.bucketAt,
+			Dimension:   k.dimension,
+			Value:       k.value,
+			Clicks:      clicks,
+		})
+	}
+	return stats, accepted
+}
+
+// statsValue 规范化维度取值，为空时使用默认值，超长时截断.
+func statsValue(v, def string) string {
+	if v == "" {
+		return def
+	}
+	if utf8.RuneCountInString(v) > maxStatsValueLength {
+		v = string([]rune(v)[:maxStatsValueLength])
+	}
+	return v
+}
+
+// truncateBucket 返回 t 所在时间桶的起始时间，按服务所在时区划分.
+func truncateBucket(t time.Time, granularity int64) time.Time {
+	t = t.Local()
+	if granularity == models.StatsGranularityDay {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, time.Local)
+}
+
+// nextBucket 返回 t 之后的下一个时间桶起始时间，t 必须已对齐.
+func nextBucket(t time.Time, granularity int64) time.Time {
+	if granularity == models.StatsGranularityDay {
+		return t.AddDate(0, 0, 1)
+	}
+	return t.Add(time.Hour)
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package svc
+
+import (
+	"github.com/clin211/miniblog-v3/apps/shortlink/models"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/internal/config"
+	"github.com/clin211/miniblog-v3/pkg/id"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+type ServiceContext struct {
+	Config         config.Config
+	ShortLinkModel models.ShortLinksModel
+	// StatsModel 短链访问统计
+	StatsModel models.ShortLinkStatsModel
+	// ClicksModel 设置了最大点击次数的短链已占用的点击次数
+	ClicksModel models.ShortLinkClicksModel
+	// 原始数据库连接
+	DB sqlx.SqlConn
+	// Redis 客户端
+	Redis *redis.Redis
+	// Sonyflake 用于生成短码的数值 ID
+	Sonyflake *id.Sonyflake
+}
+
+func NewServiceContext(c config.Config) *ServiceContext {
+	// 连接 MySQL 数据库
+	conn := sqlx.NewMysql(c.Mysql.DataSource)
+
+	// 初始化 Sonyflake，多实例部署时从 etcd 租用唯一的机器 ID
+	sf := id.MustNewSonyflake(c.Sonyflake)
+	id.SetDefault(sf)
+
+	return &ServiceContext{
+		Config:         c,
+		ShortLinkModel: models.NewShortLinksModel(conn, c.Cache),
+		StatsModel:     models.NewShortLinkStatsModel(conn),
+		ClicksModel:    models.NewShortLinkClicksModel(conn),
+		DB:             conn,
+		Redis:          redis.MustNewRedis(c.Cache[0].RedisConf),
+		Sonyflake:      sf,
+	}
+}
@@ -0,0 +1,1394 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.30.0
+// source: shortlink.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ShortLinkState 短链状态
+type ShortLinkState int32
+
+const (
+	ShortLinkState_SHORT_LINK_STATE_UNSPECIFIED ShortLinkState = 0 // 未知
+	ShortLinkState_SHORT_LINK_STATE_ACTIVE      ShortLinkState = 1 // 正常
+	ShortLinkState_SHORT_LINK_STATE_EXPIRED     ShortLinkState = 2 // 已过期
+	ShortLinkState_SHORT_LINK_STATE_DELETED     ShortLinkState = 3 // 已删除
+	ShortLinkState_SHORT_LINK_STATE_EXHAUSTED   ShortLinkState = 4 // 点击次数已用完
+	ShortLinkState_SHORT_LINK_STATE_LOCKED      ShortLinkState = 5 // 需要密码或密码错误
+)
+
+// Enum value maps for ShortLinkState.
+var (
+	ShortLinkState_name = map[int32]string{
+		0: "SHORT_LINK_STATE_UNSPECIFIED",
+		1: "SHORT_LINK_STATE_ACTIVE",
+		2: "SHORT_LINK_STATE_EXPIRED",
+		3: "SHORT_LINK_STATE_DELETED",
+		4: "SHORT_LINK_STATE_EXHAUSTED",
+		5: "SHORT_LINK_STATE_LOCKED",
+	}
+	ShortLinkState_value = map[string]int32{
+		"SHORT_LINK_STATE_UNSPECIFIED": 0,
+		"SHORT_LINK_STATE_ACTIVE":      1,
+		"SHORT_LINK_STATE_EXPIRED":     2,
+		"SHORT_LINK_STATE_DELETED":     3,
+		"SHORT_LINK_STATE_EXHAUSTED":   4,
+		"SHORT_LINK_STATE_LOCKED":      5,
+	}
+)
+
+func (x ShortLinkState) Enum() *ShortLinkState {
+	p := new(ShortLinkState)
+	*p = x
+	return p
+}
+
+func (x ShortLinkState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ShortLinkState) Descriptor() protoreflect.EnumDescriptor {
+	return file_shortlink_proto_enumTypes[0].Descriptor()
+}
+
+func (ShortLinkState) Type() protoreflect.EnumType {
+	return &file_shortlink_proto_enumTypes[0]
+}
+
+func (x ShortLinkState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ShortLinkState.Descriptor instead.
+func (ShortLinkState) EnumDescriptor() ([]byte, []int) {
+	return file_shortlink_proto_rawDescGZIP(), []int{0}
+}
+
+// StatsGranularity 统计聚合粒度
+type StatsGranularity int32
+
+const (
+	StatsGranularity_STATS_GRANULARITY_UNSPECIFIED StatsGranularity = 0 // 未指定，按小时聚合
+	StatsGranularity_STATS_GRANULARITY_HOUR        StatsGranularity = 1 // 按小时聚合
+	StatsGranularity_STATS_GRANULARITY_DAY         StatsGranularity = 2 // 按天聚合
+)
+
+// Enum value maps for StatsGranularity.
+var (
+	StatsGranularity_name = map[int32]string{
+		0: "STATS_GRANULARITY_UNSPECIFIED",
+		1: "STATS_GRANULARITY_HOUR",
+		2: "STATS_GRANULARITY_DAY",
+	}
+	StatsGranularity_value = map[string]int32{
+		"STATS_GRANULARITY_UNSPECIFIED": 0,
+		"STATS_GRANULARITY_HOUR":        1,
+		"STATS_GRANULARITY_DAY":         2,
+	}
+)
+
+func (x StatsGranularity) Enum() *StatsGranularity {
+	p := new(StatsGranularity)
+	*p = x
+	return p
+}
+
+func (x StatsGranularity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StatsGranularity) Descriptor() protoreflect.EnumDescriptor {
+	return file_shortlink_proto_enumTypes[1].Descriptor()
+}
+
+func (StatsGranularity) Type() protoreflect.EnumType {
+	return &file_shortlink_proto_enumTypes[1]
+}
+
+func (x StatsGranularity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StatsGranularity.Descriptor instead.
+func (StatsGranularity) EnumDescriptor() ([]byte, []int) {
+	return file_shortlink_proto_rawDescGZIP(), []int{1}
+}
+
+// CreateShortLinkRequest 创建短链请求
+type CreateShortLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OriginalUrl   string                 `protobuf:"bytes,1,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"` // 原始URL
+	ExpireAt      int64                  `protobuf:"varint,2,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`         // 过期时间，Unix 时间戳（秒），0 表示使用默认有效期，-1 表示永不过期
+	Alias         string                 `protobuf:"bytes,3,opt,name=alias,proto3" json:"alias,omitempty"`                                // 自定义别名，为空时自动生成短码
+	MaxClicks     int64                  `protobuf:"varint,4,opt,name=max_clicks,json=maxClicks,proto3" json:"max_clicks,omitempty"`      // 最大点击次数，0 表示不限制
+	Password      string                 `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`                          // 访问密码，为空表示无需密码
+	ExternalKey   string                 `protobuf:"bytes,6,opt,name=external_key,json=externalKey,proto3" json:"external_key,omitempty"` // 调用方的幂等键，例如 post:123；同一用户下相同的键返回同一条短链
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShortLinkRequest) Reset() {
+	*x = CreateShortLinkRequest{}
+	mi := &file_shortlink_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShortLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShortLinkRequest) ProtoMessage() {}
+
+func (x *CreateShortLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortlink_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShortLinkRequest.ProtoReflect.Descriptor instead.
+func (*CreateShortLinkRequest) Descriptor() ([]byte, []int) {
+	return file_shortlink_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateShortLinkRequest) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *CreateShortLinkRequest) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+func (x *CreateShortLinkRequest) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *CreateShortLinkRequest) GetMaxClicks() int64 {
+	if x != nil {
+		return x.MaxClicks
+	}
+	return 0
+}
+
+func (x *CreateShortLinkRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *CreateShortLinkRequest) GetExternalKey() string {
+	if x != nil {
+		return x.ExternalKey
+	}
+	return ""
+}
+
+// CreateShortLinkResponse 创建短链响应
+type CreateShortLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`                                  // 短码
+	ShortUrl      string                 `protobuf:"bytes,2,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`          // 短链URL
+	OriginalUrl   string                 `protobuf:"bytes,3,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"` // 原始URL
+	ExpireAt      string                 `protobuf:"bytes,4,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`          // 过期时间，为空表示永不过期
+	Reused        bool                   `protobuf:"varint,5,opt,name=reused,proto3" json:"reused,omitempty"`                             // 是否复用了外部键对应的已有短链
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShortLinkResponse) Reset() {
+	*x = CreateShortLinkResponse{}
+	mi := &file_shortlink_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShortLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShortLinkResponse) ProtoMessage() {}
+
+func (x *CreateShortLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortlink_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShortLinkResponse.ProtoReflect.Descriptor instead.
+func (*CreateShortLinkResponse) Descriptor() ([]byte, []int) {
+	return file_shortlink_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateShortLinkResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreateShortLinkResponse) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *CreateShortLinkResponse) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *CreateShortLinkResponse) GetExpireAt() string {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return ""
+}
+
+func (x *CreateShortLinkResponse) GetReused() bool {
+	if x != nil {
+		return x.Reused
+	}
+	return false
+}
+
+// GetShortLinkRequest 获取短链信息请求
+type GetShortLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"` // 短码
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShortLinkRequest) Reset() {
+	*x = GetShortLinkRequest{}
+	mi := &file_shortlink_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShortLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShortLinkRequest) ProtoMessage() {}
+
+func (x *GetShortLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortlink_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShortLinkRequest.ProtoReflect.Descriptor instead.
+func (*GetShortLinkRequest) Descriptor() ([]byte, []int) {
+	return file_shortlink_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetShortLinkRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// GetShortLinkResponse 获取短链信息响应
+type GetShortLinkResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Code              string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`                                                      // 短码
+	ShortUrl          string                 `protobuf:"bytes,2,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`                              // 短链URL
+	OriginalUrl       string                 `protobuf:"bytes,3,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`                     // 原始URL
+	UserId            string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                    // 创建者用户ID
+	State             ShortLinkState         `protobuf:"varint,5,opt,name=state,proto3,enum=rpc.ShortLinkState" json:"state,omitempty"`                           // 状态
+	ExpireAt          string                 `protobuf:"bytes,6,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`                              // 过期时间，为空表示永不过期
+	CreatedAt         string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                           // 创建时间
+	UpdatedAt         string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`                           // 更新时间
+	IsAlias           bool                   `protobuf:"varint,9,opt,name=is_alias,json=isAlias,proto3" json:"is_alias,omitempty"`                                // 是否为自定义别名
+	MaxClicks         int64                  `protobuf:"varint,10,opt,name=max_clicks,json=maxClicks,proto3" json:"max_clicks,omitempty"`                         // 最大点击次数，0 表示不限制
+	Clicks            int64                  `protobuf:"varint,11,opt,name=clicks,proto3" json:"clicks,omitempty"`                                                // 已计数的点击次数，仅设置了最大点击次数时统计
+	PasswordProtected bool                   `protobuf:"varint,12,opt,name=password_protected,json=passwordProtected,proto3" json:"password_protected,omitempty"` // 是否设置了访问密码
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetShortLinkResponse) Reset() {
+	*x = GetShortLinkResponse{}
+	mi := &file_shortlink_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShortLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShortLinkResponse) ProtoMessage() {}
+
+func (x *GetShortLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortlink_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShortLinkResponse.ProtoReflect.Descriptor instead.
+func (*GetShortLinkResponse) Descriptor() ([]byte, []int) {
+	return file_shortlink_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetShortLinkResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *GetShortLinkResponse) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *GetShortLinkResponse) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *GetShortLinkResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetShortLinkResponse) GetState() ShortLinkState {
+	if x != nil {
+		return x.State
+	}
+	return ShortLinkState_SHORT_LINK_STATE_UNSPECIFIED
+}
+
+func (x *GetShortLinkResponse) GetExpireAt() string {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return ""
+}
+
+func (x *GetShortLinkResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *GetShortLinkResponse) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *GetShortLinkResponse) GetIsAlias() bool {
+	if x != nil {
+		return x.IsAlias
+	}
+	return false
+}
+
+func (x *GetShortLinkResponse) GetMaxClicks() int64 {
+	if x != nil {
+		return x.MaxClicks
+	}
+	return 0
+}
+
+func (x *GetShortLinkResponse) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+func (x *GetShortLinkResponse) GetPasswordProtected() bool {
+	if x != nil {
+		return x.PasswordProtected
+	}
+	return false
+}
+
+// DeleteShortLinkRequest 删除短链请求
+type DeleteShortLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"` // 短码
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteShortLinkRequest) Reset() {
+	*x = DeleteShortLinkRequest{}
+	mi := &file_shortlink_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteShortLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteShortLinkRequest) ProtoMessage() {}
+
+func (x *DeleteShortLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortlink_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteShortLinkRequest.ProtoReflect.Descriptor instead.
+func (*DeleteShortLinkRequest) Descriptor() ([]byte, []int) {
+	return file_shortlink_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteShortLinkRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// DeleteShortLinkResponse 删除短链响应
+type DeleteShortLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // 是否成功
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteShortLinkResponse) Reset() {
+	*x = DeleteShortLinkResponse{}
+	mi := &file_shortlink_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteShortLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteShortLinkResponse) ProtoMessage() {}
+
+func (x *DeleteShortLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortlink_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteShortLinkResponse.ProtoReflect.Descriptor instead.
+func (*DeleteShortLinkResponse) Descriptor() ([]byte, []int) {
+	return file_shortlink_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteShortLinkResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// ResolveShortLinkRequest 解析短链请求
+type ResolveShortLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`         // 短码
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"` // 访问密码，短链设置了密码时必填
+	Peek          bool                   `protobuf:"varint,3,opt,name=peek,proto3" json:"peek,omitempty"`        // 仅查询状态，不校验密码也不占用点击次数，不返回原始URL
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveShortLinkRequest) Reset() {
+	*x = ResolveShortLinkRequest{}
+	mi := &file_shortlink_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveShortLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveShortLinkRequest) ProtoMessage() {}
+
+func (x *ResolveShortLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortlink_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveShortLinkRequest.ProtoReflect.Descriptor instead.
+func (*ResolveShortLinkRequest) Descriptor() ([]byte, []int) {
+	return file_shortlink_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ResolveShortLinkRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ResolveShortLinkRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *ResolveShortLinkRequest) GetPeek() bool {
+	if x != nil {
+		return x.Peek
+	}
+	return false
+}
+
+// ResolveShortLinkResponse 解析短链响应
+type ResolveShortLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OriginalUrl   string                 `protobuf:"bytes,1,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"` // 原始URL，仅状态正常时返回
+	State         ShortLinkState         `protobuf:"varint,2,opt,name=state,proto3,enum=rpc.ShortLinkState" json:"state,omitempty"`       // 状态
+	ExpireAt      int64                  `protobuf:"varint,3,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`         // 过期时间，Unix 时间戳（秒），0 表示永不过期
+	Restricted    bool                   `protobuf:"varint,4,opt,name=restricted,proto3" json:"restricted,omitempty"`                     // 是否设置了密码或最大点击次数，受限短链的每次访问都必须经过服务端校验，不能缓存
+	ShortUrl      string                 `protobuf:"bytes,5,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`          // 短链URL
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveShortLinkResponse) Reset() {
+	*x = ResolveShortLinkResponse{}
+	mi := &file_shortlink_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveShortLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveShortLinkResponse) ProtoMessage() {}
+
+func (x *ResolveShortLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortlink_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveShortLinkResponse.ProtoReflect.Descriptor instead.
+func (*ResolveShortLinkResponse) Descriptor() ([]byte, []int) {
+	return file_shortlink_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ResolveShortLinkResponse) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *ResolveShortLinkResponse) GetState() ShortLinkState {
+	if x != nil {
+		return x.State
+	}
+	return ShortLinkState_SHORT_LINK_STATE_UNSPECIFIED
+}
+
+func (x *ResolveShortLinkResponse) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+func (x *ResolveShortLinkResponse) GetRestricted() bool {
+	if x != nil {
+		return x.Restricted
+	}
+	return false
+}
+
+func (x *ResolveShortLinkResponse) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+// ClickEvent 短链点击事件
+type ClickEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`            // 短码
+	Timestamp     int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // 点击时间，Unix 时间戳（秒）
+	Referrer      string                 `protobuf:"bytes,3,opt,name=referrer,proto3" json:"referrer,omitempty"`    // 来源站点域名，直接访问为空
+	Browser       string                 `protobuf:"bytes,4,opt,name=browser,proto3" json:"browser,omitempty"`      // 浏览器
+	Os            string                 `protobuf:"bytes,5,opt,name=os,proto3" json:"os,omitempty"`                // 操作系统
+	Device        string                 `protobuf:"bytes,6,opt,name=device,proto3" json:"device,omitempty"`        // 设备类型：desktop、mobile、tablet、bot
+	Ip            string                 `protobuf:"bytes,7,opt,name=ip,proto3" json:"ip,omitempty"`                // 截断后的访问 IP，IPv4 保留前 24 位，IPv6 保留前 48 位
+	Country       string                 `protobuf:"bytes,8,opt,name=country,proto3" json:"country,omitempty"`      // 国家或地区代码，未知时为空
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClickEvent) Reset() {
+	*x = ClickEvent{}
+	mi := &file_shortlink_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClickEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClickEvent) ProtoMessage() {}
+
+func (x *ClickEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_shortlink_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClickEvent.ProtoReflect.Descriptor instead.
+func (*ClickEvent) Descriptor() ([]byte, []int) {
+	return file_shortlink_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ClickEvent) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ClickEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ClickEvent) GetReferrer() string {
+	if x != nil {
+		return x.Referrer
+	}
+	return ""
+}
+
+func (x *ClickEvent) GetBrowser() string {
+	if x != nil {
+		return x.Browser
+	}
+	return ""
+}
+
+func (x *ClickEvent) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *ClickEvent) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *ClickEvent) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *ClickEvent) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+// RecordClicksRequest 批量上报点击事件请求
+type RecordClicksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*ClickEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"` // 点击事件
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordClicksRequest) Reset() {
+	*x = RecordClicksRequest{}
+	mi := &file_shortlink_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordClicksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordClicksRequest) ProtoMessage() {}
+
+func (x *RecordClicksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortlink_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordClicksRequest.ProtoReflect.Descriptor instead.
+func (*RecordClicksRequest) Descriptor() ([]byte, []int) {
+	return file_shortlink_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RecordClicksRequest) GetEvents() []*ClickEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// RecordClicksResponse 批量上报点击事件响应
+type RecordClicksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accepted      int64                  `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"` // 成功聚合的事件数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordClicksResponse) Reset() {
+	*x = RecordClicksResponse{}
+	mi := &file_shortlink_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordClicksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordClicksResponse) ProtoMessage() {}
+
+func (x *RecordClicksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortlink_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordClicksResponse.ProtoReflect.Descriptor instead.
+func (*RecordClicksResponse) Descriptor() ([]byte, []int) {
+	return file_shortlink_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RecordClicksResponse) GetAccepted() int64 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+// GetShortLinkStatsRequest 获取短链访问统计请求
+type GetShortLinkStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`                                          // 短码
+	Granularity   StatsGranularity       `protobuf:"varint,2,opt,name=granularity,proto3,enum=rpc.StatsGranularity" json:"granularity,omitempty"` // 时间序列的聚合粒度
+	StartTime     int64                  `protobuf:"varint,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`              // 开始时间，Unix 时间戳（秒），0 表示按粒度取默认范围
+	EndTime       int64                  `protobuf:"varint,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`                    // 结束时间，Unix 时间戳（秒），0 表示当前时间
+	Top           int32                  `protobuf:"varint,5,opt,name=top,proto3" json:"top,omitempty"`                                           // 排行榜条数，0 表示默认 10 条
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShortLinkStatsRequest) Reset() {
+	*x = GetShortLinkStatsRequest{}
+	mi := &file_shortlink_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShortLinkStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShortLinkStatsRequest) ProtoMessage() {}
+
+func (x *GetShortLinkStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortlink_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShortLinkStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetShortLinkStatsRequest) Descriptor() ([]byte, []int) {
+	return file_shortlink_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetShortLinkStatsRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *GetShortLinkStatsRequest) GetGranularity() StatsGranularity {
+	if x != nil {
+		return x.Granularity
+	}
+	return StatsGranularity_STATS_GRANULARITY_UNSPECIFIED
+}
+
+func (x *GetShortLinkStatsRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *GetShortLinkStatsRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *GetShortLinkStatsRequest) GetTop() int32 {
+	if x != nil {
+		return x.Top
+	}
+	return 0
+}
+
+// StatsPoint 时间序列中的一个点
+type StatsPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          int64                  `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`     // 时间桶起始时间，Unix 时间戳（秒）
+	Clicks        int64                  `protobuf:"varint,2,opt,name=clicks,proto3" json:"clicks,omitempty"` // 点击次数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsPoint) Reset() {
+	*x = StatsPoint{}
+	mi := &file_shortlink_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsPoint) ProtoMessage() {}
+
+func (x *StatsPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_shortlink_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsPoint.ProtoReflect.Descriptor instead.
+func (*StatsPoint) Descriptor() ([]byte, []int) {
+	return file_shortlink_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StatsPoint) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *StatsPoint) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+// StatsItem 排行榜中的一项
+type StatsItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`      // 名称
+	Clicks        int64                  `protobuf:"varint,2,opt,name=clicks,proto3" json:"clicks,omitempty"` // 点击次数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsItem) Reset() {
+	*x = StatsItem{}
+	mi := &file_shortlink_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsItem) ProtoMessage() {}
+
+func (x *StatsItem) ProtoReflect() protoreflect.Message {
+	mi := &file_shortlink_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsItem.ProtoReflect.Descriptor instead.
+func (*StatsItem) Descriptor() ([]byte, []int) {
+	return file_shortlink_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *StatsItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StatsItem) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+// GetShortLinkStatsResponse 获取短链访问统计响应
+type GetShortLinkStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Granularity   StatsGranularity       `protobuf:"varint,1,opt,name=granularity,proto3,enum=rpc.StatsGranularity" json:"granularity,omitempty"` // 时间序列的聚合粒度
+	StartTime     int64                  `protobuf:"varint,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`              // 对齐后的开始时间，Unix 时间戳（秒）
+	EndTime       int64                  `protobuf:"varint,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`                    // 对齐后的结束时间（不含），Unix 时间戳（秒）
+	TotalClicks   int64                  `protobuf:"varint,4,opt,name=total_clicks,json=totalClicks,proto3" json:"total_clicks,omitempty"`        // 范围内的总点击次数
+	Series        []*StatsPoint          `protobuf:"bytes,5,rep,name=series,proto3" json:"series,omitempty"`                                      // 时间序列，没有点击的时间桶点击次数为 0
+	TopReferrers  []*StatsItem           `protobuf:"bytes,6,rep,name=top_referrers,json=topReferrers,proto3" json:"top_referrers,omitempty"`      // 来源排行，按天统计
+	TopBrowsers   []*StatsItem           `protobuf:"bytes,7,rep,name=top_browsers,json=topBrowsers,proto3" json:"top_browsers,omitempty"`         // 浏览器排行，按天统计
+	TopOs         []*StatsItem           `protobuf:"bytes,8,rep,name=top_os,json=topOs,proto3" json:"top_os,omitempty"`                           // 操作系统排行，按天统计
+	TopDevices    []*StatsItem           `protobuf:"bytes,9,rep,name=top_devices,json=topDevices,proto3" json:"top_devices,omitempty"`            // 设备类型排行，按天统计
+	TopCountries  []*StatsItem           `protobuf:"bytes,10,rep,name=top_countries,json=topCountries,proto3" json:"top_countries,omitempty"`     // 国家或地区排行，按天统计
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShortLinkStatsResponse) Reset() {
+	*x = GetShortLinkStatsResponse{}
+	mi := &file_shortlink_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShortLinkStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShortLinkStatsResponse) ProtoMessage() {}
+
+func (x *GetShortLinkStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortlink_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShortLinkStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetShortLinkStatsResponse) Descriptor() ([]byte, []int) {
+	return file_shortlink_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetShortLinkStatsResponse) GetGranularity() StatsGranularity {
+	if x != nil {
+		return x.Granularity
+	}
+	return StatsGranularity_STATS_GRANULARITY_UNSPECIFIED
+}
+
+func (x *GetShortLinkStatsResponse) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *GetShortLinkStatsResponse) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *GetShortLinkStatsResponse) GetTotalClicks() int64 {
+	if x != nil {
+		return x.TotalClicks
+	}
+	return 0
+}
+
+func (x *GetShortLinkStatsResponse) GetSeries() []*StatsPoint {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+func (x *GetShortLinkStatsResponse) GetTopReferrers() []*StatsItem {
+	if x != nil {
+		return x.TopReferrers
+	}
+	return nil
+}
+
+func (x *GetShortLinkStatsResponse) GetTopBrowsers() []*StatsItem {
+	if x != nil {
+		return x.TopBrowsers
+	}
+	return nil
+}
+
+func (x *GetShortLinkStatsResponse) GetTopOs() []*StatsItem {
+	if x != nil {
+		return x.TopOs
+	}
+	return nil
+}
+
+func (x *GetShortLinkStatsResponse) GetTopDevices() []*StatsItem {
+	if x != nil {
+		return x.TopDevices
+	}
+	return nil
+}
+
+func (x *GetShortLinkStatsResponse) GetTopCountries() []*StatsItem {
+	if x != nil {
+		return x.TopCountries
+	}
+	return nil
+}
+
+var File_shortlink_proto protoreflect.FileDescriptor
+
+const file_shortlink_proto_rawDesc = "" +
+	"\n" +
+	"\x0fshortlink.proto\x12\x03rpc\"\xcc\x01\n" +
+	"\x16CreateShortLinkRequest\x12!\n" +
+	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\x12\x1b\n" +
+	"\texpire_at\x18\x02 \x01(\x03R\bexpireAt\x12\x14\n" +
+	"\x05alias\x18\x03 \x01(\tR\x05alias\x12\x1d\n" +
+	"\n" +
+	"max_clicks\x18\x04 \x01(\x03R\tmaxClicks\x12\x1a\n" +
+	"\bpassword\x18\x05 \x01(\tR\bpassword\x12!\n" +
+	"\fexternal_key\x18\x06 \x01(\tR\vexternalKey\"\xa2\x01\n" +
+	"\x17CreateShortLinkResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1b\n" +
+	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\x12!\n" +
+	"\foriginal_url\x18\x03 \x01(\tR\voriginalUrl\x12\x1b\n" +
+	"\texpire_at\x18\x04 \x01(\tR\bexpireAt\x12\x16\n" +
+	"\x06reused\x18\x05 \x01(\bR\x06reused\")\n" +
+	"\x13GetShortLinkRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"\x8a\x03\n" +
+	"\x14GetShortLinkResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1b\n" +
+	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\x12!\n" +
+	"\foriginal_url\x18\x03 \x01(\tR\voriginalUrl\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12)\n" +
+	"\x05state\x18\x05 \x01(\x0e2\x13.rpc.ShortLinkStateR\x05state\x12\x1b\n" +
+	"\texpire_at\x18\x06 \x01(\tR\bexpireAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\tR\tupdatedAt\x12\x19\n" +
+	"\bis_alias\x18\t \x01(\bR\aisAlias\x12\x1d\n" +
+	"\n" +
+	"max_clicks\x18\n" +
+	" \x01(\x03R\tmaxClicks\x12\x16\n" +
+	"\x06clicks\x18\v \x01(\x03R\x06clicks\x12-\n" +
+	"\x12password_protected\x18\f \x01(\bR\x11passwordProtected\",\n" +
+	"\x16DeleteShortLinkRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"3\n" +
+	"\x17DeleteShortLinkResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"]\n" +
+	"\x17ResolveShortLinkRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x12\n" +
+	"\x04peek\x18\x03 \x01(\bR\x04peek\"\xc2\x01\n" +
+	"\x18ResolveShortLinkResponse\x12!\n" +
+	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\x12)\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x13.rpc.ShortLinkStateR\x05state\x12\x1b\n" +
+	"\texpire_at\x18\x03 \x01(\x03R\bexpireAt\x12\x1e\n" +
+	"\n" +
+	"restricted\x18\x04 \x01(\bR\n" +
+	"restricted\x12\x1b\n" +
+	"\tshort_url\x18\x05 \x01(\tR\bshortUrl\"\xc6\x01\n" +
+	"\n" +
+	"ClickEvent\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12\x1a\n" +
+	"\breferrer\x18\x03 \x01(\tR\breferrer\x12\x18\n" +
+	"\abrowser\x18\x04 \x01(\tR\abrowser\x12\x0e\n" +
+	"\x02os\x18\x05 \x01(\tR\x02os\x12\x16\n" +
+	"\x06device\x18\x06 \x01(\tR\x06device\x12\x0e\n" +
+	"\x02ip\x18\a \x01(\tR\x02ip\x12\x18\n" +
+	"\acountry\x18\b \x01(\tR\acountry\">\n" +
+	"\x13RecordClicksRequest\x12'\n" +
+	"\x06events\x18\x01 \x03(\v2\x0f.rpc.ClickEventR\x06events\"2\n" +
+	"\x14RecordClicksResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\x03R\baccepted\"\xb3\x01\n" +
+	"\x18GetShortLinkStatsRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x127\n" +
+	"\vgranularity\x18\x02 \x01(\x0e2\x15.rpc.StatsGranularityR\vgranularity\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x04 \x01(\x03R\aendTime\x12\x10\n" +
+	"\x03top\x18\x05 \x01(\x05R\x03top\"8\n" +
+	"\n" +
+	"StatsPoint\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\x03R\x04time\x12\x16\n" +
+	"\x06clicks\x18\x02 \x01(\x03R\x06clicks\"7\n" +
+	"\tStatsItem\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06clicks\x18\x02 \x01(\x03R\x06clicks\"\xcf\x03\n" +
+	"\x19GetShortLinkStatsResponse\x127\n" +
+	"\vgranularity\x18\x01 \x01(\x0e2\x15.rpc.StatsGranularityR\vgranularity\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x03 \x01(\x03R\aendTime\x12!\n" +
+	"\ftotal_clicks\x18\x04 \x01(\x03R\vtotalClicks\x12'\n" +
+	"\x06series\x18\x05 \x03(\v2\x0f.rpc.StatsPointR\x06series\x123\n" +
+	"\rtop_referrers\x18\x06 \x03(\v2\x0e.rpc.StatsItemR\ftopReferrers\x121\n" +
+	"\ftop_browsers\x18\a \x03(\v2\x0e.rpc.StatsItemR\vtopBrowsers\x12%\n" +
+	"\x06top_os\x18\b \x03(\v2\x0e.rpc.StatsItemR\x05topOs\x12/\n" +
+	"\vtop_devices\x18\t \x03(\v2\x0e.rpc.StatsItemR\n" +
+	"topDevices\x123\n" +
+	"\rtop_countries\x18\n" +
+	" \x03(\v2\x0e.rpc.StatsItemR\ftopCountries*\xc8\x01\n" +
+	"\x0eShortLinkState\x12 \n" +
+	"\x1cSHORT_LINK_STATE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17SHORT_LINK_STATE_ACTIVE\x10\x01\x12\x1c\n" +
+	"\x18SHORT_LINK_STATE_EXPIRED\x10\x02\x12\x1c\n" +
+	"\x18SHORT_LINK_STATE_DELETED\x10\x03\x12\x1e\n" +
+	"\x1aSHORT_LINK_STATE_EXHAUSTED\x10\x04\x12\x1b\n" +
+	"\x17SHORT_LINK_STATE_LOCKED\x10\x05*l\n" +
+	"\x10StatsGranularity\x12!\n" +
+	"\x1dSTATS_GRANULARITY_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16STATS_GRANULARITY_HOUR\x10\x01\x12\x19\n" +
+	"\x15STATS_GRANULARITY_DAY\x10\x022\xd6\x03\n" +
+	"\tShortLink\x12L\n" +
+	"\x0fCreateShortLink\x12\x1b.rpc.CreateShortLinkRequest\x1a\x1c.rpc.CreateShortLinkResponse\x12C\n" +
+	"\fGetShortLink\x12\x18.rpc.GetShortLinkRequest\x1a\x19.rpc.GetShortLinkResponse\x12L\n" +
+	"\x0fDeleteShortLink\x12\x1b.rpc.DeleteShortLinkRequest\x1a\x1c.rpc.DeleteShortLinkResponse\x12O\n" +
+	"\x10ResolveShortLink\x12\x1c.rpc.ResolveShortLinkRequest\x1a\x1d.rpc.ResolveShortLinkResponse\x12C\n" +
+	"\fRecordClicks\x12\x18.rpc.RecordClicksRequest\x1a\x19.rpc.RecordClicksResponse\x12R\n" +
+	"\x11GetShortLinkStats\x12\x1d.rpc.GetShortLinkStatsRequest\x1a\x1e.rpc.GetShortLinkStatsResponseB\aZ\x05./rpcb\x06proto3"
+
+var (
+	file_shortlink_proto_rawDescOnce sync.Once
+	file_shortlink_proto_rawDescData []byte
+)
+
+func file_shortlink_proto_rawDescGZIP() []byte {
+	file_shortlink_proto_rawDescOnce.Do(func() {
+		file_shortlink_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_shortlink_proto_rawDesc), len(file_shortlink_proto_rawDesc)))
+	})
+	return file_shortlink_proto_rawDescData
+}
+
+var file_shortlink_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_shortlink_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_shortlink_proto_goTypes = []any{
+	(ShortLinkState)(0),               // 0: rpc.ShortLinkState
+	(StatsGranularity)(0),             // 1: rpc.StatsGranularity
+	(*CreateShortLinkRequest)(nil),    // 2: rpc.CreateShortLinkRequest
+	(*CreateShortLinkResponse)(nil),   // 3: rpc.CreateShortLinkResponse
+	(*GetShortLinkRequest)(nil),       // 4: rpc.GetShortLinkRequest
+	(*GetShortLinkResponse)(nil),      // 5: rpc.GetShortLinkResponse
+	(*DeleteShortLinkRequest)(nil),    // 6: rpc.DeleteShortLinkRequest
+	(*DeleteShortLinkResponse)(nil),   // 7: rpc.DeleteShortLinkResponse
+	(*ResolveShortLinkRequest)(nil),   // 8: rpc.ResolveShortLinkRequest
+	(*ResolveShortLinkResponse)(nil),  // 9: rpc.ResolveShortLinkResponse
+	(*ClickEvent)(nil),                // 10: rpc.ClickEvent
+	(*RecordClicksRequest)(nil),       // 11: rpc.RecordClicksRequest
+	(*RecordClicksResponse)(nil),      // 12: rpc.RecordClicksResponse
+	(*GetShortLinkStatsRequest)(nil),  // 13: rpc.GetShortLinkStatsRequest
+	(*StatsPoint)(nil),                // 14: rpc.StatsPoint
+	(*StatsItem)(nil),                 // 15: rpc.StatsItem
+	(*GetShortLinkStatsResponse)(nil), // 16: rpc.GetShortLinkStatsResponse
+}
+var file_shortlink_proto_depIdxs = []int32{
+	0,  // 0: rpc.GetShortLinkResponse.state:type_name -> rpc.ShortLinkState
+	0,  // 1: rpc.ResolveShortLinkResponse.state:type_name -> rpc.ShortLinkState
+	10, // 2: rpc.RecordClicksRequest.events:type_name -> rpc.ClickEvent
+	1,  // 3: rpc.GetShortLinkStatsRequest.granularity:type_name -> rpc.StatsGranularity
+	1,  // 4: rpc.GetShortLinkStatsResponse.granularity:type_name -> rpc.StatsGranularity
+	14, // 5: rpc.GetShortLinkStatsResponse.series:type_name -> rpc.StatsPoint
+	15, // 6: rpc.GetShortLinkStatsResponse.top_referrers:type_name -> rpc.StatsItem
+	15, // 7: rpc.GetShortLinkStatsResponse.top_browsers:type_name -> rpc.StatsItem
+	15, // 8: rpc.GetShortLinkStatsResponse.top_os:type_name -> rpc.StatsItem
+	15, // 9: rpc.GetShortLinkStatsResponse.top_devices:type_name -> rpc.StatsItem
+	15, // 10: rpc.GetShortLinkStatsResponse.top_countries:type_name -> rpc.StatsItem
+	2,  // 11: rpc.ShortLink.CreateShortLink:input_type -> rpc.CreateShortLinkRequest
+	4,  // 12: rpc.ShortLink.GetShortLink:input_type -> rpc.GetShortLinkRequest
+	6,  // 13: rpc.ShortLink.DeleteShortLink:input_type -> rpc.DeleteShortLinkRequest
+	8,  // 14: rpc.ShortLink.ResolveShortLink:input_type -> rpc.ResolveShortLinkRequest
+	11, // 15: rpc.ShortLink.RecordClicks:input_type -> rpc.RecordClicksRequest
+	13, // 16: rpc.ShortLink.GetShortLinkStats:input_type -> rpc.GetShortLinkStatsRequest
+	3,  // 17: rpc.ShortLink.CreateShortLink:output_type -> rpc.CreateShortLinkResponse
+	5,  // 18: rpc.ShortLink.GetShortLink:output_type -> rpc.GetShortLinkResponse
+	7,  // 19: rpc.ShortLink.DeleteShortLink:output_type -> rpc.DeleteShortLinkResponse
+	9,  // 20: rpc.ShortLink.ResolveShortLink:output_type -> rpc.ResolveShortLinkResponse
+	12, // 21: rpc.ShortLink.RecordClicks:output_type -> rpc.RecordClicksResponse
+	16, // 22: rpc.ShortLink.GetShortLinkStats:output_type -> rpc.GetShortLinkStatsResponse
+	17, // [17:23] is the sub-list for method output_type
+	11, // [11:17] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_shortlink_proto_init() }
+func file_shortlink_proto_init() {
+	if File_shortlink_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shortlink_proto_rawDesc), len(file_shortlink_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_shortlink_proto_goTypes,
+		DependencyIndexes: file_shortlink_proto_depIdxs,
+		EnumInfos:         file_shortlink_proto_enumTypes,
+		MessageInfos:      file_shortlink_proto_msgTypes,
+	}.Build()
+	File_shortlink_proto = out.File
+	file_shortlink_proto_goTypes = nil
+	file_shortlink_proto_depIdxs = nil
+}
@@ -0,0 +1,323 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.30.0
+// source: shortlink.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ShortLink_CreateShortLink_FullMethodName   = "/rpc.ShortLink/CreateShortLink"
+	ShortLink_GetShortLink_FullMethodName      = "/rpc.ShortLink/GetShortLink"
+	ShortLink_DeleteShortLink_FullMethodName   = "/rpc.ShortLink/DeleteShortLink"
+	ShortLink_ResolveShortLink_FullMethodName  = "/rpc.ShortLink/ResolveShortLink"
+	ShortLink_RecordClicks_FullMethodName      = "/rpc.ShortLink/RecordClicks"
+	ShortLink_GetShortLinkStats_FullMethodName = "/rpc.ShortLink/GetShortLinkStats"
+)
+
+// ShortLinkClient is the client API for ShortLink service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ShortLinkClient interface {
+	// CreateShortLink 创建短链
+	CreateShortLink(ctx context.Context, in *CreateShortLinkRequest, opts ...grpc.CallOption) (*CreateShortLinkResponse, error)
+	// GetShortLink 获取短链信息，仅创建者可查询
+	GetShortLink(ctx context.Context, in *GetShortLinkRequest, opts ...grpc.CallOption) (*GetShortLinkResponse, error)
+	// DeleteShortLink 删除短链，仅创建者可删除
+	DeleteShortLink(ctx context.Context, in *DeleteShortLinkRequest, opts ...grpc.CallOption) (*DeleteShortLinkResponse, error)
+	// ResolveShortLink 解析短链，供重定向使用，无需认证
+	ResolveShortLink(ctx context.Context, in *ResolveShortLinkRequest, opts ...grpc.CallOption) (*ResolveShortLinkResponse, error)
+	// RecordClicks 批量上报点击事件，仅供重定向服务携带服务密钥调用
+	RecordClicks(ctx context.Context, in *RecordClicksRequest, opts ...grpc.CallOption) (*RecordClicksResponse, error)
+	// GetShortLinkStats 获取短链访问统计，仅创建者可查询
+	GetShortLinkStats(ctx context.Context, in *GetShortLinkStatsRequest, opts ...grpc.CallOption) (*GetShortLinkStatsResponse, error)
+}
+
+type shortLinkClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewShortLinkClient(cc grpc.ClientConnInterface) ShortLinkClient {
+	return &shortLinkClient{cc}
+}
+
+func (c *shortLinkClient) CreateShortLink(ctx context.Context, in *CreateShortLinkRequest, opts ...grpc.CallOption) (*CreateShortLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateShortLinkResponse)
+	err := c.cc.Invoke(ctx, ShortLink_CreateShortLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortLinkClient) GetShortLink(ctx context.Context, in *GetShortLinkRequest, opts ...grpc.CallOption) (*GetShortLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetShortLinkResponse)
+	err := c.cc.Invoke(ctx, ShortLink_GetShortLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortLinkClient) DeleteShortLink(ctx context.Context, in *DeleteShortLinkRequest, opts ...grpc.CallOption) (*DeleteShortLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteShortLinkResponse)
+	err := c.cc.Invoke(ctx, ShortLink_DeleteShortLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortLinkClient) ResolveShortLink(ctx context.Context, in *ResolveShortLinkRequest, opts ...grpc.CallOption) (*ResolveShortLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveShortLinkResponse)
+	err := c.cc.Invoke(ctx, ShortLink_ResolveShortLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortLinkClient) RecordClicks(ctx context.Context, in *RecordClicksRequest, opts ...grpc.CallOption) (*RecordClicksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordClicksResponse)
+	err := c.cc.Invoke(ctx, ShortLink_RecordClicks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortLinkClient) GetShortLinkStats(ctx context.Context, in *GetShortLinkStatsRequest, opts ...grpc.CallOption) (*GetShortLinkStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetShortLinkStatsResponse)
+	err := c.cc.Invoke(ctx, ShortLink_GetShortLinkStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ShortLinkServer is the server API for ShortLink service.
+// All implementations must embed UnimplementedShortLinkServer
+// for forward compatibility.
+type ShortLinkServer interface {
+	// CreateShortLink 创建短链
+	CreateShortLink(context.Context, *CreateShortLinkRequest) (*CreateShortLinkResponse, error)
+	// GetShortLink 获取短链信息，仅创建者可查询
+	GetShortLink(context.Context, *GetShortLinkRequest) (*GetShortLinkResponse, error)
+	// DeleteShortLink 删除短链，仅创建者可删除
+	DeleteShortLink(context.Context, *DeleteShortLinkRequest) (*DeleteShortLinkResponse, error)
+	// ResolveShortLink 解析短链，供重定向使用，无需认证
+	ResolveShortLink(context.Context, *ResolveShortLinkRequest) (*ResolveShortLinkResponse, error)
+	// RecordClicks 批量上报点击事件，仅供重定向服务携带服务密钥调用
+	RecordClicks(context.Context, *RecordClicksRequest) (*RecordClicksResponse, error)
+	// GetShortLinkStats 获取短链访问统计，仅创建者可查询
+	GetShortLinkStats(context.Context, *GetShortLinkStatsRequest) (*GetShortLinkStatsResponse, error)
+	mustEmbedUnimplementedShortLinkServer()
+}
+
+// UnimplementedShortLinkServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedShortLinkServer struct{}
+
+func (UnimplementedShortLinkServer) CreateShortLink(context.Context, *CreateShortLinkRequest) (*CreateShortLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateShortLink not implemented")
+}
+func (UnimplementedShortLinkServer) GetShortLink(context.Context, *GetShortLinkRequest) (*GetShortLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShortLink not implemented")
+}
+func (UnimplementedShortLinkServer) DeleteShortLink(context.Context, *DeleteShortLinkRequest) (*DeleteShortLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteShortLink not implemented")
+}
+func (UnimplementedShortLinkServer) ResolveShortLink(context.Context, *ResolveShortLinkRequest) (*ResolveShortLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveShortLink not implemented")
+}
+func (UnimplementedShortLinkServer) RecordClicks(context.Context, *RecordClicksRequest) (*RecordClicksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordClicks not implemented")
+}
+func (UnimplementedShortLinkServer) GetShortLinkStats(context.Context, *GetShortLinkStatsRequest) (*GetShortLinkStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShortLinkStats not implemented")
+}
+func (UnimplementedShortLinkServer) mustEmbedUnimplementedShortLinkServer() {}
+func (UnimplementedShortLinkServer) testEmbeddedByValue()                   {}
+
+// UnsafeShortLinkServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ShortLinkServer will
+// result in compilation errors.
+type UnsafeShortLinkServer interface {
+	mustEmbedUnimplementedShortLinkServer()
+}
+
+func RegisterShortLinkServer(s grpc.ServiceRegistrar, srv ShortLinkServer) {
+	// If the following call pancis, it indicates UnimplementedShortLinkServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ShortLink_ServiceDesc, srv)
+}
+
+func _ShortLink_CreateShortLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateShortLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortLinkServer).CreateShortLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortLink_CreateShortLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortLinkServer).CreateShortLink(ctx, req.(*CreateShortLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortLink_GetShortLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShortLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortLinkServer).GetShortLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortLink_GetShortLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortLinkServer).GetShortLink(ctx, req.(*GetShortLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortLink_DeleteShortLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteShortLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortLinkServer).DeleteShortLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortLink_DeleteShortLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortLinkServer).DeleteShortLink(ctx, req.(*DeleteShortLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortLink_ResolveShortLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveShortLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortLinkServer).ResolveShortLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortLink_ResolveShortLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortLinkServer).ResolveShortLink(ctx, req.(*ResolveShortLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortLink_RecordClicks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordClicksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortLinkServer).RecordClicks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortLink_RecordClicks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortLinkServer).RecordClicks(ctx, req.(*RecordClicksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortLink_GetShortLinkStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShortLinkStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortLinkServer).GetShortLinkStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortLink_GetShortLinkStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortLinkServer).GetShortLinkStats(ctx, req.(*GetShortLinkStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ShortLink_ServiceDesc is the grpc.ServiceDesc for ShortLink service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ShortLink_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rpc.ShortLink",
+	HandlerType: (*ShortLinkServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateShortLink",
+			Handler:    _ShortLink_CreateShortLink_Handler,
+		},
+		{
+			MethodName: "GetShortLink",
+			Handler:    _ShortLink_GetShortLink_Handler,
+		},
+		{
+			MethodName: "DeleteShortLink",
+			Handler:    _ShortLink_DeleteShortLink_Handler,
+		},
+		{
+			MethodName: "ResolveShortLink",
+			Handler:    _ShortLink_ResolveShortLink_Handler,
+		},
+		{
+			MethodName: "RecordClicks",
+			Handler:    _ShortLink_RecordClicks_Handler,
+		},
+		{
+			MethodName: "GetShortLinkStats",
+			Handler:    _ShortLink_GetShortLinkStats_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "shortlink.proto",
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/internal/config"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/internal/server"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/internal/svc"
+	"github.com/clin211/miniblog-v3/apps/shortlink/rpc/pb/rpc"
+	"github.com/clin211/miniblog-v3/pkg/middleware"
+
+	"github.com/zeromicro/go-zero/core/conf"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/service"
+	"github.com/zeromicro/go-zero/zrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+var configFile = flag.String("f", "etc/shortlink.yaml", "the config file")
+
+func main() {
+	flag.Parse()
+
+	var c config.Config
+	conf.MustLoad(*configFile, &c)
+	ctx := svc.NewServiceContext(c)
+
+	logx.MustSetup(logx.LogConf{
+		ServiceName:      "shortlink-rpc", // 服务名称
+		Mode:             "file",          // 日志模式
+		Path:             "./logs",        // 日志文件存储路径
+		Level:            "info",          // 日志级别
+		MaxSize:          100,             // 每个日志文件的最大大小，单位MB
+		MaxContentLength: 200,             // 日志长度限制
+		MaxBackups:       10,              // 文件输出模式，按照大小分割时，最多文件保留个数
+		Compress:         true,            // 是否启用日志压缩
+	})
+
+	s := zrpc.MustNewServer(c.RpcServerConf, func(grpcServer *grpc.Server) {
+		rpc.RegisterShortLinkServer(grpcServer, server.NewShortLinkServer(ctx))
+		fmt.Println("RegisterShortLinkServer", c.Mode)
+		if c.Mode == service.DevMode || c.Mode == service.TestMode {
+			reflection.Register(grpcServer)
+		}
+	})
+	defer s.Stop()
+
+	// 添加gRPC拦截器，请求实现 validate.Validator 时在认证之后校验参数
+	// 点击事件只接受重定向服务携带服务密钥上报，避免伪造点击
+	s.AddUnaryInterceptors(middleware.LocaleInterceptor(),
+		middleware.AuthnInterceptor(middleware.WithServiceMethods(c.ServiceAuth.Secret, rpc.ShortLink_RecordClicks_FullMethodName)),
+		middleware.ValidateInterceptor())
+
+	fmt.Printf("Starting rpc server at %s...\n", c.ListenOn)
+	s.Start()
+}
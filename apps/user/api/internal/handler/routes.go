@@ -34,6 +34,11 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 				Path:    "/user",
 				Handler: DeleteUserHandler(serverCtx),
 			},
+			{
+				Method:  http.MethodPut,
+				Path:    "/user/password",
+				Handler: ChangePasswordHandler(serverCtx),
+			},
 			{
 				Method:  http.MethodPost,
 				Path:    "/user/login",
@@ -0,0 +1,73 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"context"
+
+	"github.com/clin211/miniblog-v3/apps/user/api/internal/svc"
+	"github.com/clin211/miniblog-v3/apps/user/api/internal/types"
+	"github.com/clin211/miniblog-v3/apps/user/rpc/pb/rpc"
+	"github.com/clin211/miniblog-v3/pkg/errorx"
+	"github.com/clin211/miniblog-v3/pkg/known"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/metadata"
+)
+
+type ChangePasswordLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewChangePasswordLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ChangePasswordLogic {
+	return &ChangePasswordLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ChangePasswordLogic) ChangePassword(req *types.ChangePasswordRequest) (resp *types.ChangePasswordResponse, err error) {
+	// 从context中获取用户ID（由中间件设置）
+	userID, ok := l.ctx.Value(known.XUserID).(string)
+	if !ok {
+		logx.Errorw("从context中获取用户ID失败")
+		return nil, errorx.ErrTokenInvalid
+	}
+
+	// 从context中获取原始token
+	token, ok := l.ctx.Value("auth_token").(string)
+	if !ok {
+		logx.Errorw("从context中获取token失败")
+		return nil, errorx.ErrTokenInvalid
+	}
+
+	// 创建带token的gRPC上下文
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + token,
+	})
+	rpcCtx := metadata.NewOutgoingContext(l.ctx, md)
+
+	// 调用RPC服务修改密码
+	_, err = l.svcCtx.UserRpc.ChangePassword(rpcCtx, &rpc.ChangePasswordRequest{
+		UserId:      userID,
+		OldPassword: req.OldPassword,
+		NewPassword: req.NewPassword,
+	})
+	if err != nil {
+		logx.Errorw("调用RPC服务失败",
+			logx.Field("userId", userID),
+			logx.Field("error", err))
+		// 将 gRPC 错误转换为 API 错误，参数校验失败时返回具体的字段错误
+		return nil, fromRPCError(err)
+	}
+
+	logx.Infow("修改密码成功", logx.Field("userId", userID))
+
+	return &types.ChangePasswordResponse{}, nil
+}
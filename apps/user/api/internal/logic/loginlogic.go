@@ -11,7 +11,6 @@ import (
 	"github.com/clin211/miniblog-v3/apps/user/api/internal/svc"
 	"github.com/clin211/miniblog-v3/apps/user/api/internal/types"
 	"github.com/clin211/miniblog-v3/apps/user/rpc/pb/rpc"
-	"github.com/clin211/miniblog-v3/pkg/errorx"
 
 	"github.com/zeromicro/go-zero/core/logx"
 )
@@ -38,8 +37,8 @@ func (l *LoginLogic) Login(req *types.LoginRequest) (resp *types.LoginResponse,
 	})
 
 	if err != nil {
-		// 将 gRPC 错误转换为 errorx 错误
-		return nil, errorx.FromGRPCError(err)
+		// 将 gRPC 错误转换为 API 错误，参数校验失败时返回具体的字段错误
+		return nil, fromRPCError(err)
 	}
 
 	// 2. 构造响应
@@ -11,7 +11,6 @@ import (
 	"github.com/clin211/miniblog-v3/apps/user/api/internal/svc"
 	"github.com/clin211/miniblog-v3/apps/user/api/internal/types"
 	"github.com/clin211/miniblog-v3/apps/user/rpc/pb/rpc"
-	"github.com/clin211/miniblog-v3/pkg/errorx"
 
 	"github.com/zeromicro/go-zero/core/logx"
 )
@@ -41,10 +40,11 @@ func (l *RegisterLogic) Register(req *types.RegisterRequest) (resp *types.Regist
 		Gender:         int32(req.Gender),
 		Avatar:         req.Avatar,
 		RegisterSource: int32(req.RegisterSource),
+		WechatOpenid:   req.WechatOpenid,
 	})
 	if err != nil {
-		// 将 gRPC 错误转换为 errorx 错误
-		return nil, errorx.FromGRPCError(err)
+		// 将 gRPC 错误转换为 API 错误，参数校验失败时返回具体的字段错误
+		return nil, fromRPCError(err)
 	}
 
 	return &types.RegisterResponse{
@@ -65,8 +65,8 @@ func (l *UpdateUserLogic) UpdateUser(req *types.UpdateUserRequest) (resp *types.
 		logx.Errorw("调用RPC服务失败",
 			logx.Field("userId", userID),
 			logx.Field("error", err))
-		// 将 gRPC 错误转换为 errorx 错误
-		return nil, errorx.FromGRPCError(err)
+		// 将 gRPC 错误转换为 API 错误，参数校验失败时返回具体的字段错误
+		return nil, fromRPCError(err)
 	}
 
 	// 构建响应
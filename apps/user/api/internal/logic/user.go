@@ -0,0 +1,20 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"github.com/clin211/miniblog-v3/pkg/errorx"
+	"github.com/clin211/miniblog-v3/pkg/validate"
+)
+
+// fromRPCError 将用户 RPC 返回的 gRPC 错误转换为 API 错误.
+// 参数校验失败或违反密码策略时还原为字段级的验证错误，响应中列出每个字段的错误；其他错误转换为 errorx 错误
+func fromRPCError(err error) error {
+	if es, ok := validate.FromGRPCError(err); ok {
+		return es
+	}
+	return errorx.FromGRPCError(err)
+}
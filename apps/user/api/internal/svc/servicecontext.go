@@ -20,9 +20,12 @@ type ServiceContext struct {
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
+	// 将协商出的语言传递给RPC服务，RPC返回的错误信息按该语言输出
+	userRpc := zrpc.MustNewClient(c.UserRpc, zrpc.WithUnaryClientInterceptor(middleware.LocaleClientInterceptor()))
+
 	return &ServiceContext{
 		Config:          c,
-		UserRpc:         rpc.NewUserClient(zrpc.MustNewClient(c.UserRpc).Conn()),
+		UserRpc:         rpc.NewUserClient(userRpc.Conn()),
 		AuthnMiddleware: middleware.NewAuthnMiddleware().Handle,
 	}
 }
@@ -3,6 +3,14 @@
 
 package types
 
+type ChangePasswordRequest struct {
+	OldPassword string `json:"oldPassword" valid:"required"` // 原密码
+	NewPassword string `json:"newPassword" valid:"required"` // 新密码，复杂度由用户服务的密码策略检查
+}
+
+type ChangePasswordResponse struct {
+}
+
 type DeleteUserRequest struct {
 	UserId string `json:"userId" valid:"required"` // 用户ID
 }
@@ -43,15 +51,28 @@ type LoginResponse struct {
 	ExpireAt string `json:"expireAt"` // 过期时间
 }
 
+type PageRequest struct {
+	Cursor string `form:"cursor,optional"` // 上一页返回的游标，为空时从第一页开始
+	Offset int    `form:"offset,optional"` // 偏移量，仅 cursor 为空时生效，最大 10000
+	Limit  int    `form:"limit,optional"`  // 每页条数，0 表示默认 20 条，最多 100 条
+}
+
+type PageResponse struct {
+	NextCursor string `json:"nextCursor,omitempty"` // 下一页的游标，没有下一页时为空
+	HasMore    bool   `json:"hasMore"`              // 是否有下一页
+	Total      *int64 `json:"total,omitempty"`      // 总数，没有统计时为空
+}
+
 type RegisterRequest struct {
-	Username       string `json:"username" valid:"required,length(3|100)"`    // 用户名
-	Password       string `json:"password" valid:"required,length(6|32)"`     // 密码
-	Email          string `json:"email" valid:"required,email"`               // 邮箱
-	Phone          string `json:"phone" valid:"required,length(11|20)"`       // 手机号
-	Age            int    `json:"age,optional" valid:"range(1|120)"`          // 年龄
-	Gender         int    `json:"gender,optional" valid:"range(0|3)"`         // 性别：0-未设置，1-男，2-女，3-其他
-	Avatar         string `json:"avatar,optional"`                            // 头像URL
-	RegisterSource int    `json:"registerSource,optional" valid:"range(1|6)"` // 注册来源：1-web，2-app，3-wechat，4-qq，5-github，6-google
+	Username       string `json:"username" valid:"required,username"`                          // 用户名
+	Password       string `json:"password" valid:"required"`                                   // 密码，复杂度由用户服务的密码策略检查
+	Email          string `json:"email" valid:"required,email"`                                // 邮箱
+	Phone          string `json:"phone" valid:"required,cn_mobile"`                            // 手机号
+	Age            int    `json:"age,optional" valid:"user_age"`                               // 年龄
+	Gender         int    `json:"gender,optional" valid:"user_gender"`                         // 性别：0-未设置，1-男，2-女，3-其他
+	Avatar         string `json:"avatar,optional"`                                             // 头像URL
+	RegisterSource int    `json:"registerSource,optional" valid:"user_register_source"`        // 注册来源：1-web，2-app，3-wechat，4-qq，5-github，6-google
+	WechatOpenid   string `json:"wechatOpenid,optional" valid:"required_if(RegisterSource|3)"` // 微信OpenID，微信注册时必填
 }
 
 type RegisterResponse struct {
@@ -59,11 +80,11 @@ type RegisterResponse struct {
 }
 
 type UpdateUserRequest struct {
-	UserId   string `json:"userId" valid:"required"`                 // 用户ID
-	Username string `json:"username,optional" valid:"length(3|100)"` // 用户名
-	Age      int    `json:"age,optional" valid:"range(1|120)"`       // 年龄
-	Gender   int    `json:"gender,optional" valid:"range(0|3)"`      // 性别
-	Avatar   string `json:"avatar,optional"`                         // 头像URL
+	UserId   string `json:"userId" valid:"required"`             // 用户ID
+	Username string `json:"username,optional" valid:"username"`  // 用户名
+	Age      int    `json:"age,optional" valid:"user_age"`       // 年龄
+	Gender   int    `json:"gender,optional" valid:"user_gender"` // 性别
+	Avatar   string `json:"avatar,optional"`                     // 头像URL
 }
 
 type UpdateUserResponse struct {
@@ -12,9 +12,14 @@ import (
 	"github.com/clin211/miniblog-v3/apps/user/api/internal/config"
 	"github.com/clin211/miniblog-v3/apps/user/api/internal/handler"
 	"github.com/clin211/miniblog-v3/apps/user/api/internal/svc"
+	// 注册 valid 标签中使用的规则别名
+	_ "github.com/clin211/miniblog-v3/apps/user/rules"
+	"github.com/clin211/miniblog-v3/pkg/middleware"
+	"github.com/clin211/miniblog-v3/pkg/validate"
 
 	"github.com/zeromicro/go-zero/core/conf"
 	"github.com/zeromicro/go-zero/rest"
+	"github.com/zeromicro/go-zero/rest/httpx"
 )
 
 var configFile = flag.String("f", "etc/user.yaml", "the config file")
@@ -25,9 +30,19 @@ func main() {
 	var c config.Config
 	conf.MustLoad(*configFile, &c)
 
+	// httpx.Parse 按 valid 标签校验请求参数
+	httpx.SetValidator(validate.HttpxValidatorAdapter{})
+
 	server := rest.MustNewServer(c.RestConf)
 	defer server.Stop()
 
+	// 为每个请求分配请求 ID，错误响应通过它关联服务端日志
+	server.Use(middleware.RequestIDMiddleware)
+	// 根据 Accept-Language 协商响应语言
+	server.Use(middleware.LocaleMiddleware)
+	// Accept 为 application/problem+json 时按 RFC 7807 输出错误
+	server.Use(middleware.ProblemMiddleware)
+
 	ctx := svc.NewServiceContext(c)
 	handler.RegisterHandlers(server, ctx)
 
@@ -0,0 +1,22 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package models
+
+import "strings"
+
+// placeholders 返回 n 个以逗号分隔的占位符，用于 in 查询.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+// toArgs 将 in 查询的取值列表转换为 SQL 参数.
+func toArgs[T any](vals []T) []any {
+	args := make([]any, len(vals))
+	for i, v := range vals {
+		args[i] = v
+	}
+	return args
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+const (
+	// OutboxStatusPending 待发布
+	OutboxStatusPending = 0
+	// OutboxStatusPublished 已发布
+	OutboxStatusPublished = 1
+	// OutboxStatusPublishing 已被 relay 领取，正在发布
+	OutboxStatusPublishing = 2
+
+	// outboxLastErrorMaxLen last_error 字段的最大长度
+	outboxLastErrorMaxLen = 255
+)
+
+// ErrOutboxBusy 表示排在最前面的事件正被其他 relay 发布，租约到期前不能领取后续事件
+var ErrOutboxBusy = errors.New("outbox events are being published by another relay")
+
+var _ UserOutboxEventsModel = (*customUserOutboxEventsModel)(nil)
+
+type (
+	// UserOutboxEventsModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customUserOutboxEventsModel.
+	UserOutboxEventsModel interface {
+		userOutboxEventsModel
+		// TransactCtx 在事务中执行 fn
+		TransactCtx(ctx context.Context, fn func(ctx context.Context, session sqlx.Session) error) error
+		// InsertWithSession 在事务中写入事件
+		InsertWithSession(ctx context.Context, session sqlx.Session, data *UserOutboxEvents) error
+		// ClaimPending 在一个短事务中按 id 顺序领取最多 limit 条待发布或租约已到期的事件，
+		// 标记为发布中并设置 lease 的发布租约. 这些事件之前有其他 relay 的租约未到期时返回 ErrOutboxBusy
+		ClaimPending(ctx context.Context, owner string, limit int, lease time.Duration) ([]*UserOutboxEvents, error)
+		// MarkPublished 将 owner 领取的事件标记为已发布，返回更新的条数. 租约已被其他 relay 接管的事件不会更新
+		MarkPublished(ctx context.Context, owner string, ids []int64) (int64, error)
+		// ReleaseClaim 发布失败时释放 owner 领取的事件，记录失败次数和原因，事件恢复为待发布
+		ReleaseClaim(ctx context.Context, owner string, ids []int64, reason string) error
+		// DeletePublishedBefore 删除 before 之前发布的事件，每次最多删除 limit 条，返回删除的条数
+		DeletePublishedBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+	}
+
+	customUserOutboxEventsModel struct {
+		*defaultUserOutboxEventsModel
+	}
+)
+
+// NewUserOutboxEventsModel returns a model for the database table.
+func NewUserOutboxEventsModel(conn sqlx.SqlConn) UserOutboxEventsModel {
+	return &customUserOutboxEventsModel{
+		defaultUserOutboxEventsModel: newUserOutboxEventsModel(conn),
+	}
+}
+
+func (m *customUserOutboxEventsModel) TransactCtx(ctx context.Context, fn func(ctx context.Context, session sqlx.Session) error) error {
+	return m.conn.TransactCtx(ctx, fn)
+}
+
+func (m *customUserOutboxEventsModel) InsertWithSession(ctx context.Context, session sqlx.Session, data *UserOutboxEvents) error {
+	query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", m.table, userOutboxEventsRowsExpectAutoSet)
+	_, err := session.ExecCtx(ctx, query, data.EventId, data.EventType, data.AggregateId, data.Payload, data.Status, data.Attempts, data.LastError, data.ClaimedBy, data.ClaimUntil, data.PublishedAt)
+	return err
+}
+
+func (m *customUserOutboxEventsModel) ClaimPending(ctx context.Context, owner string, limit int, lease time.Duration) ([]*UserOutboxEvents, error) {
+	var resp []*UserOutboxEvents
+	err := m.conn.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		// 不使用 SKIP LOCKED：多个 relay 依次领取，后领取的 relay 能看到前面未到期的租约
+		query := fmt.Sprintf("select %s from %s where `status` in (?, ?) order by `id` limit ? for update", userOutboxEventsRows, m.table)
+		if err := session.QueryRowsCtx(ctx, &resp, query, OutboxStatusPending, OutboxStatusPublishing, limit); err != nil {
+			return err
+		}
+		if len(resp) == 0 {
+			return nil
+		}
+
+		// 租约时间使用数据库时钟，避免各实例的时钟偏差
+		ids := make([]int64, len(resp))
+		for i, e := range resp {
+			ids[i] = e.Id
+		}
+		var busy int64
+		query = fmt.Sprintf("select count(*) from %s where `id` in (%s) and `status` = ? and `claimed_by` <> ? and `claim_until` > now()", m.table, placeholders(len(ids)))
+		args := append(toArgs(ids), OutboxStatusPublishing, owner)
+		if err := session.QueryRowCtx(ctx, &busy, query, args...); err != nil {
+			return err
+		}
+		if busy > 0 {
+			return ErrOutboxBusy
+		}
+
+		query = fmt.Sprintf("update %s set `status` = ?, `claimed_by` = ?, `claim_until` = now() + interval ? second where `id` in (%s)", m.table, placeholders(len(ids)))
+		args = append([]any{OutboxStatusPublishing, owner, int64(lease / time.Second)}, toArgs(ids)...)
+		_, err := session.ExecCtx(ctx, query, args...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (m *customUserOutboxEventsModel) MarkPublished(ctx context.Context, owner string, ids []int64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	query := fmt.Sprintf("update %s set `status` = ?, `published_at` = ?, `claim_until` = null where `id` in (%s) and `status` = ? and `claimed_by` = ?", m.table, placeholders(len(ids)))
+	args := append([]any{OutboxStatusPublished, time.Now()}, toArgs(ids)...)
+	ret, err := m.conn.ExecCtx(ctx, query, append(args, OutboxStatusPublishing, owner)...)
+	if err != nil {
+		return 0, err
+	}
+	return ret.RowsAffected()
+}
+
+func (m *customUserOutboxEventsModel) ReleaseClaim(ctx context.Context, owner string, ids []int64, reason string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if len(reason) > outboxLastErrorMaxLen {
+		reason = reason[:outboxLastErrorMaxLen]
+	}
+	query := fmt.Sprintf("update %s set `status` = ?, `attempts` = `attempts` + 1, `last_error` = ?, `claim_until` = null where `id` in (%s) and `status` = ? and `claimed_by` = ?", m.table, placeholders(len(ids)))
+	args := append([]any{OutboxStatusPending, reason}, toArgs(ids)...)
+	_, err := m.conn.ExecCtx(ctx, query, append(args, OutboxStatusPublishing, owner)...)
+	return err
+}
+
+func (m *customUserOutboxEventsModel) DeletePublishedBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	query := fmt.Sprintf("delete from %s where `status` = ? and `published_at` < ? limit ?", m.table)
+	ret, err := m.conn.ExecCtx(ctx, query, OutboxStatusPublished, before, limit)
+	if err != nil {
+		return 0, err
+	}
+	return ret.RowsAffected()
+}
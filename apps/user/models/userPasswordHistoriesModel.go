@@ -0,0 +1,62 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package models
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+var _ UserPasswordHistoriesModel = (*customUserPasswordHistoriesModel)(nil)
+
+type (
+	// UserPasswordHistoriesModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customUserPasswordHistoriesModel.
+	UserPasswordHistoriesModel interface {
+		userPasswordHistoriesModel
+		// FindRecent 查询用户最近使用过的 limit 个密码哈希，按时间倒序
+		FindRecent(ctx context.Context, userId string, limit int) ([]string, error)
+		// Prune 只保留用户最近的 keep 条记录
+		Prune(ctx context.Context, userId string, keep int) error
+	}
+
+	customUserPasswordHistoriesModel struct {
+		*defaultUserPasswordHistoriesModel
+	}
+)
+
+// NewUserPasswordHistoriesModel returns a model for the database table.
+func NewUserPasswordHistoriesModel(conn sqlx.SqlConn) UserPasswordHistoriesModel {
+	return &customUserPasswordHistoriesModel{
+		defaultUserPasswordHistoriesModel: newUserPasswordHistoriesModel(conn),
+	}
+}
+
+func (m *customUserPasswordHistoriesModel) FindRecent(ctx context.Context, userId string, limit int) ([]string, error) {
+	query := fmt.Sprintf("select `password` from %s where `user_id` = ? order by `id` desc limit ?", m.table)
+	var resp []string
+	if err := m.conn.QueryRowsCtx(ctx, &resp, query, userId, limit); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (m *customUserPasswordHistoriesModel) Prune(ctx context.Context, userId string, keep int) error {
+	// MySQL 不支持在 IN 子查询中使用 LIMIT，先查出第 keep 条记录的 ID 再删除更早的记录
+	var ids []int64
+	query := fmt.Sprintf("select `id` from %s where `user_id` = ? order by `id` desc limit ?, 1", m.table)
+	if err := m.conn.QueryRowsCtx(ctx, &ids, query, userId, keep); err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	query = fmt.Sprintf("delete from %s where `user_id` = ? and `id` <= ?", m.table)
+	_, err := m.conn.ExecCtx(ctx, query, userId, ids[0])
+	return err
+}
@@ -6,6 +6,9 @@
 package models
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/zeromicro/go-zero/core/stores/cache"
 	"github.com/zeromicro/go-zero/core/stores/sqlx"
 )
@@ -17,6 +20,17 @@ type (
 	// and implement the added methods in customUsersModel.
 	UsersModel interface {
 		usersModel
+		// TransactCtx 在事务中执行 fn，用于与 outbox 事件一起提交，返回 fn 涉及的缓存键.
+		// 事务提交前删除缓存会让并发读取把旧数据写回缓存，调用方须在提交后调用 DelCacheCtx
+		TransactCtx(ctx context.Context, fn func(ctx context.Context, session sqlx.Session) ([]string, error)) ([]string, error)
+		// InsertWithSession 在事务中插入用户，返回需要在提交后删除的缓存键
+		InsertWithSession(ctx context.Context, session sqlx.Session, data *Users) ([]string, error)
+		// UpdateWithSession 在事务中更新用户，返回需要在提交后删除的缓存键
+		UpdateWithSession(ctx context.Context, session sqlx.Session, newData *Users) ([]string, error)
+		// DelCacheCtx 删除缓存键
+		DelCacheCtx(ctx context.Context, keys ...string) error
+		// FindActiveByUserIds 查询多个未禁用的用户，不存在的用户ID被忽略，不使用缓存
+		FindActiveByUserIds(ctx context.Context, userIds []string) ([]*Users, error)
 	}
 
 	customUsersModel struct {
@@ -30,3 +44,65 @@ func NewUsersModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) U
 		defaultUsersModel: newUsersModel(conn, c, opts...),
 	}
 }
+
+func (m *customUsersModel) TransactCtx(ctx context.Context, fn func(ctx context.Context, session sqlx.Session) ([]string, error)) ([]string, error) {
+	var keys []string
+	err := m.CachedConn.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		var err error
+		keys, err = fn(ctx, session)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (m *customUsersModel) InsertWithSession(ctx context.Context, session sqlx.Session, data *Users) ([]string, error) {
+	query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", m.table, usersRowsExpectAutoSet)
+	_, err := session.ExecCtx(ctx, query, data.UserId, data.Age, data.Avatar, data.Username, data.Password, data.PasswordUpdatedAt, data.Email, data.EmailVerified, data.Phone, data.PhoneVerified, data.Gender, data.Status, data.FailedLoginAttempts, data.LastLoginAt, data.LastLoginIp, data.IsRisk, data.RegisterSource, data.RegisterIp, data.WechatOpenid, data.DeletedAt)
+	if err != nil {
+		return nil, err
+	}
+	// 唯一键可能缓存了未找到的占位符，插入后同样需要删除
+	return m.cacheKeys(data), nil
+}
+
+func (m *customUsersModel) UpdateWithSession(ctx context.Context, session sqlx.Session, newData *Users) ([]string, error) {
+	data, err := m.FindOne(ctx, newData.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("update %s set %s where `id` = ?", m.table, usersRowsWithPlaceHolder)
+	_, err = session.ExecCtx(ctx, query, newData.UserId, newData.Age, newData.Avatar, newData.Username, newData.Password, newData.PasswordUpdatedAt, newData.Email, newData.EmailVerified, newData.Phone, newData.PhoneVerified, newData.Gender, newData.Status, newData.FailedLoginAttempts, newData.LastLoginAt, newData.LastLoginIp, newData.IsRisk, newData.RegisterSource, newData.RegisterIp, newData.WechatOpenid, newData.DeletedAt, newData.Id)
+	if err != nil {
+		return nil, err
+	}
+	// 唯一键变化时旧键和新键都需要删除
+	return append(m.cacheKeys(data), m.cacheKeys(newData)...), nil
+}
+
+func (m *customUsersModel) FindActiveByUserIds(ctx context.Context, userIds []string) ([]*Users, error) {
+	if len(userIds) == 0 {
+		return nil, nil
+	}
+	query := fmt.Sprintf("select %s from %s where `user_id` in (%s) and `status` = 1", usersRows, m.table, placeholders(len(userIds)))
+	var resp []*Users
+	if err := m.QueryRowsNoCacheCtx(ctx, &resp, query, toArgs(userIds)...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// cacheKeys 返回用户的所有缓存键.
+func (m *customUsersModel) cacheKeys(data *Users) []string {
+	return []string{
+		fmt.Sprintf("%s%v", cacheUsersEmailPrefix, data.Email),
+		fmt.Sprintf("%s%v", cacheUsersIdPrefix, data.Id),
+		fmt.Sprintf("%s%v", cacheUsersPhonePrefix, data.Phone),
+		fmt.Sprintf("%s%v", cacheUsersUserIdPrefix, data.UserId),
+		fmt.Sprintf("%s%v", cacheUsersUsernamePrefix, data.Username),
+		fmt.Sprintf("%s%v", cacheUsersWechatOpenidPrefix, data.WechatOpenid),
+	}
+}
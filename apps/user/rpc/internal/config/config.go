@@ -6,6 +6,11 @@
 package config
 
 import (
+	"time"
+
+	"github.com/clin211/miniblog-v3/pkg/encrypt"
+	"github.com/clin211/miniblog-v3/pkg/id"
+	"github.com/clin211/miniblog-v3/pkg/password"
 	"github.com/zeromicro/go-zero/core/stores/cache"
 	"github.com/zeromicro/go-zero/zrpc"
 )
@@ -25,6 +30,31 @@ type Config struct {
 		ExpireHours int
 	}
 
+	// 密码哈希配置
+	Password encrypt.Config
+
+	// 密码策略，注册和修改密码时检查
+	PasswordPolicy password.Config
+
+	// Sonyflake 配置，用于生成用户ID
+	Sonyflake id.SonyflakeConf
+
+	// Kafka 配置，用户事件经 outbox 发布到 Kafka
+	Kafka struct {
+		Brokers     []string      // Kafka broker 地址
+		Timeout     time.Duration `json:",default=10s"` // 单批事件的发布超时，必须小于 Outbox.Lease
+		MaxAttempts int           `json:",default=3"`   // leader 切换等可重试错误的最大尝试次数
+	}
+
+	// Outbox 配置
+	Outbox struct {
+		Topic     string        `json:",default=user.events"` // 用户事件主题
+		Interval  time.Duration `json:",default=1s"`          // 轮询待发布事件的间隔
+		BatchSize int           `json:",default=100"`         // 每批发布的最大事件数
+		Lease     time.Duration `json:",default=30s"`         // 领取事件和 relay 主节点锁的租约，到期后由其他实例接管
+		Retention time.Duration `json:",default=168h"`        // 已发布事件的保留时间
+	}
+
 	// 服务配置
 	Service struct {
 		Name string
@@ -0,0 +1,59 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"context"
+	"slices"
+
+	"github.com/clin211/miniblog-v3/apps/user/rpc/internal/svc"
+	"github.com/clin211/miniblog-v3/apps/user/rpc/pb/rpc"
+	"github.com/clin211/miniblog-v3/pkg/errorx"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type BatchGetUsersLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewBatchGetUsersLogic(ctx context.Context, svcCtx *svc.ServiceContext) *BatchGetUsersLogic {
+	return &BatchGetUsersLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// BatchGetUsers 批量获取用户公开信息
+// 只返回用户名和头像等公开字段，不检查调用方与用户的关系；不存在或已禁用的用户不返回
+func (l *BatchGetUsersLogic) BatchGetUsers(in *rpc.BatchGetUsersRequest) (*rpc.BatchGetUsersResponse, error) {
+	// 参数已由 ValidateInterceptor 按 rules.Requests 校验，规则见 apps/user/rules/requests.go
+	userIds := slices.Compact(slices.Sorted(slices.Values(in.UserIds)))
+
+	users, err := l.svcCtx.UserModel.FindActiveByUserIds(l.ctx, userIds)
+	if err != nil {
+		l.Errorw("批量查询用户信息失败",
+			logx.Field("count", len(userIds)),
+			logx.Field("error", err))
+		return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("查询用户信息失败"))
+	}
+
+	resp := &rpc.BatchGetUsersResponse{
+		Users: make(map[string]*rpc.UserProfile, len(users)),
+	}
+	for _, user := range users {
+		resp.Users[user.UserId] = &rpc.UserProfile{
+			UserId:   user.UserId,
+			Username: user.Username,
+			Avatar:   user.Avatar,
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"time"
+
+	"github.com/clin211/miniblog-v3/apps/user/models"
+	"github.com/clin211/miniblog-v3/apps/user/rpc/internal/svc"
+	"github.com/clin211/miniblog-v3/apps/user/rpc/pb/rpc"
+	"github.com/clin211/miniblog-v3/pkg/errorx"
+	"github.com/clin211/miniblog-v3/pkg/event"
+	"github.com/clin211/miniblog-v3/pkg/known"
+	"github.com/clin211/miniblog-v3/pkg/password"
+	"github.com/clin211/miniblog-v3/pkg/rid"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+type ChangePasswordLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewChangePasswordLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ChangePasswordLogic {
+	return &ChangePasswordLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// ChangePassword 修改密码
+func (l *ChangePasswordLogic) ChangePassword(in *rpc.ChangePasswordRequest) (*rpc.ChangePasswordResponse, error) {
+	// 从context中获取用户ID（由拦截器设置）
+	userID, ok := l.ctx.Value(known.XUserID).(string)
+	if !ok {
+		l.Errorw("从context中获取用户ID失败")
+		return nil, errorx.ToGRPCError(errorx.ErrTokenInvalid)
+	}
+
+	if err := rid.UserID.Validate(in.UserId); err != nil {
+		return nil, errorx.ToGRPCError(errorx.ErrInvalidParameter.WithMessage("用户ID格式不正确"))
+	}
+
+	// 只能修改自己的密码
+	if userID != in.UserId {
+		l.Errorw("用户权限不足",
+			logx.Field("currentUserID", userID),
+			logx.Field("requestUserID", in.UserId))
+		return nil, errorx.ToGRPCError(errorx.ErrUnauthorized.WithMessage("只能修改自己的密码"))
+	}
+
+	user, err := l.svcCtx.UserModel.FindOneByUserId(l.ctx, in.UserId)
+	if err != nil {
+		if err == models.ErrNotFound {
+			return nil, errorx.ToGRPCError(errorx.ErrUserNotFound)
+		}
+		l.Errorw("查询用户信息失败",
+			logx.Field("userId", in.UserId),
+			logx.Field("error", err))
+		return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("查询用户信息失败"))
+	}
+	if user.Status == 0 {
+		return nil, errorx.ToGRPCError(errorx.ErrUserDisabled)
+	}
+
+	// 校验原密码
+	if err := l.svcCtx.PasswordHasher.Compare(user.Password, in.OldPassword); err != nil {
+		return nil, errorx.ToGRPCError(errorx.ErrPasswordIncorrect.WithMessage("原密码错误"))
+	}
+
+	// 按密码策略检查新密码
+	history, err := l.passwordHistory(user)
+	if err != nil {
+		return nil, errorx.ToGRPCError(err)
+	}
+	if err := checkPasswordPolicy(l.ctx, l.svcCtx, password.Input{
+		Field:    "newPassword",
+		Password: in.NewPassword,
+		Username: user.Username,
+		Email:    user.Email,
+		History:  history,
+	}); err != nil {
+		return nil, errorx.ToGRPCError(err)
+	}
+
+	hashed, err := l.svcCtx.PasswordHasher.Hash(in.NewPassword)
+	if err != nil {
+		l.Errorw("密码加密失败", logx.Field("error", err))
+		return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("密码加密失败"))
+	}
+
+	user.Password = hashed
+	user.PasswordUpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	err = withUserEvent(l.ctx, l.svcCtx, event.UserPasswordChanged, user.UserId, &event.UserPasswordChangedData{
+		UserId: user.UserId,
+	}, func(ctx context.Context, session sqlx.Session) ([]string, error) {
+		return l.svcCtx.UserModel.UpdateWithSession(ctx, session, user)
+	})
+	if err != nil {
+		l.Errorw("更新密码失败",
+			logx.Field("userId", in.UserId),
+			logx.Field("error", err))
+		return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("更新密码失败"))
+	}
+	recordPasswordHistory(l.ctx, l.svcCtx, user.UserId, hashed)
+
+	l.Infow("修改密码成功", logx.Field("userId", in.UserId))
+	return &rpc.ChangePasswordResponse{Success: true}, nil
+}
+
+// passwordHistory 返回用户最近使用过的密码哈希. 密码历史表上线前注册的用户没有历史记录，
+// 当前密码总是作为最近的一次密码参与检查
+func (l *ChangePasswordLogic) passwordHistory(user *models.Users) ([]string, error) {
+	size := l.svcCtx.PasswordPolicy.HistorySize()
+	if size == 0 {
+		return nil, nil
+	}
+
+	history, err := l.svcCtx.PasswordHistoryModel.FindRecent(l.ctx, user.UserId, size)
+	if err != nil {
+		l.Errorw("查询密码历史失败",
+			logx.Field("userId", user.UserId),
+			logx.Field("error", err))
+		return nil, errorx.InternalServerError.WithMessage("查询密码历史失败")
+	}
+	if !slices.Contains(history, user.Password) {
+		history = append([]string{user.Password}, history...)
+	}
+	return history, nil
+}
@@ -12,9 +12,12 @@ import (
 	"github.com/clin211/miniblog-v3/apps/user/rpc/internal/svc"
 	"github.com/clin211/miniblog-v3/apps/user/rpc/pb/rpc"
 	"github.com/clin211/miniblog-v3/pkg/errorx"
+	"github.com/clin211/miniblog-v3/pkg/event"
 	"github.com/clin211/miniblog-v3/pkg/known"
+	"github.com/clin211/miniblog-v3/pkg/rid"
 
 	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
 )
 
 type DeleteUserLogic struct {
@@ -40,12 +43,17 @@ func (l *DeleteUserLogic) DeleteUser(in *rpc.DeleteUserRequest) (*rpc.DeleteUser
 		return nil, errorx.ToGRPCError(errorx.ErrTokenInvalid)
 	}
 
+	// 格式不正确的用户ID直接拒绝，避免无效查询
+	if err := rid.UserID.Validate(in.UserId); err != nil {
+		return nil, errorx.ToGRPCError(errorx.ErrInvalidParameter.WithMessage("用户ID格式不正确"))
+	}
+
 	// 验证用户权限
 	if userID != in.UserId {
 		l.Errorw("用户权限不足",
 			logx.Field("currentUserID", userID),
 			logx.Field("requestUserID", in.UserId))
-		return nil, errorx.ToGRPCError(errorx.ErrUnauthorized.SetMessage("只能删除自己的账户"))
+		return nil, errorx.ToGRPCError(errorx.ErrUnauthorized.WithMessage("只能删除自己的账户"))
 	}
 
 	// 从数据库查询用户信息
@@ -58,7 +66,7 @@ func (l *DeleteUserLogic) DeleteUser(in *rpc.DeleteUserRequest) (*rpc.DeleteUser
 		l.Errorw("查询用户信息失败",
 			logx.Field("userId", in.UserId),
 			logx.Field("error", err))
-		return nil, errorx.ToGRPCError(errorx.InternalServerError.SetMessage("查询用户信息失败"))
+		return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("查询用户信息失败"))
 	}
 
 	// 检查用户状态
@@ -73,12 +81,16 @@ func (l *DeleteUserLogic) DeleteUser(in *rpc.DeleteUserRequest) (*rpc.DeleteUser
 
 	// 更新用户状态为禁用
 	user.Status = 0
-	err = l.svcCtx.UserModel.Update(l.ctx, user)
+	err = withUserEvent(l.ctx, l.svcCtx, event.UserDeleted, user.UserId, &event.UserDeletedData{
+		UserId: user.UserId,
+	}, func(ctx context.Context, session sqlx.Session) ([]string, error) {
+		return l.svcCtx.UserModel.UpdateWithSession(ctx, session, user)
+	})
 	if err != nil {
 		l.Errorw("删除用户失败",
 			logx.Field("userId", in.UserId),
 			logx.Field("error", err))
-		return nil, errorx.ToGRPCError(errorx.InternalServerError.SetMessage("删除用户失败"))
+		return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("删除用户失败"))
 	}
 
 	// 构建响应
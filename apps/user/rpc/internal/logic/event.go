@@ -0,0 +1,49 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/clin211/miniblog-v3/apps/user/models"
+	"github.com/clin211/miniblog-v3/apps/user/rpc/internal/svc"
+	"github.com/clin211/miniblog-v3/pkg/event"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+// withUserEvent 在同一个事务中执行 fn 并将用户事件写入 outbox，用户数据和事件同时提交或回滚.
+// 事件由 relay 异步发布到 Kafka. fn 返回的用户缓存键在事务提交后删除.
+func withUserEvent(ctx context.Context, svcCtx *svc.ServiceContext, typ, userId string, data any,
+	fn func(ctx context.Context, session sqlx.Session) ([]string, error)) error {
+	env, err := event.New(typ, event.UserEventVersion, svcCtx.Config.Service.Name, userId, data)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	keys, err := svcCtx.UserModel.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) ([]string, error) {
+		keys, err := fn(ctx, session)
+		if err != nil {
+			return nil, err
+		}
+		return keys, svcCtx.OutboxModel.InsertWithSession(ctx, session, &models.UserOutboxEvents{
+			EventId:     env.ID,
+			EventType:   typ,
+			AggregateId: userId,
+			Payload:     string(payload),
+			Status:      models.OutboxStatusPending,
+		})
+	})
+	if err != nil {
+		return err
+	}
+	return svcCtx.UserModel.DelCacheCtx(ctx, keys...)
+}
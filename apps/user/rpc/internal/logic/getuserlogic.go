@@ -45,7 +45,7 @@ func (l *GetUserLogic) GetUser(in *rpc.GetUserRequest) (*rpc.GetUserResponse, er
 		l.Errorw("用户权限不足",
 			logx.Field("currentUserID", userID),
 			logx.Field("requestUserID", in.UserId))
-		return nil, errorx.ToGRPCError(errorx.ErrUnauthorized.SetMessage("只能查看自己的用户信息"))
+		return nil, errorx.ToGRPCError(errorx.ErrUnauthorized.WithMessage("只能查看自己的用户信息"))
 	}
 
 	// 从数据库查询用户信息
@@ -58,7 +58,7 @@ func (l *GetUserLogic) GetUser(in *rpc.GetUserRequest) (*rpc.GetUserResponse, er
 		l.Errorw("查询用户信息失败",
 			logx.Field("userId", in.UserId),
 			logx.Field("error", err))
-		return nil, errorx.ToGRPCError(errorx.InternalServerError.SetMessage("查询用户信息失败"))
+		return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("查询用户信息失败"))
 	}
 
 	// 检查用户状态
@@ -14,8 +14,8 @@ import (
 	"github.com/clin211/miniblog-v3/apps/user/models"
 	"github.com/clin211/miniblog-v3/apps/user/rpc/internal/svc"
 	"github.com/clin211/miniblog-v3/apps/user/rpc/pb/rpc"
-	"github.com/clin211/miniblog-v3/pkg/encrypt"
 	"github.com/clin211/miniblog-v3/pkg/errorx"
+	"github.com/clin211/miniblog-v3/pkg/event"
 	"github.com/clin211/miniblog-v3/pkg/token"
 
 	"github.com/zeromicro/go-zero/core/logx"
@@ -39,41 +39,40 @@ func NewLoginLogic(ctx context.Context, svcCtx *svc.ServiceContext) *LoginLogic
 
 // Login 用户登录
 func (l *LoginLogic) Login(in *rpc.LoginRequest) (*rpc.LoginResponse, error) {
-	// 1. 参数验证
-	if err := l.validateLoginRequest(in); err != nil {
-		return nil, errorx.ToGRPCError(err)
-	}
-
-	// 2. 检查账户锁定状态
+	// 参数已由 ValidateInterceptor 按 rules.Requests 校验，规则见 apps/user/rules/requests.go
+	// 1. 检查账户锁定状态
 	if l.isAccountLocked(in.Username) {
-		return nil, errorx.ToGRPCError(errorx.ErrUnauthorized.SetMessage("账户已被锁定，请30分钟后重试"))
+		return nil, errorx.ToGRPCError(errorx.ErrUnauthorized.WithMessage("账户已被锁定，请30分钟后重试"))
 	}
 
-	// 3. 查询用户信息
+	// 2. 查询用户信息
 	user, err := l.getUserByUsername(in.Username)
 	if err != nil {
 		l.recordFailedLogin(in.Username)
-		return nil, errorx.ToGRPCError(errorx.ErrPasswordIncorrect.SetMessage("用户名或密码错误"))
+		return nil, errorx.ToGRPCError(errorx.ErrPasswordIncorrect.WithMessage("用户名或密码错误"))
 	}
 
-	// 4. 验证密码
-	if err := encrypt.Compare(user.Password, in.Password); err != nil {
+	// 3. 验证密码
+	if err := l.svcCtx.PasswordHasher.Compare(user.Password, in.Password); err != nil {
 		l.recordFailedLogin(in.Username)
-		return nil, errorx.ToGRPCError(errorx.ErrPasswordIncorrect.SetMessage("用户名或密码错误"))
+		return nil, errorx.ToGRPCError(errorx.ErrPasswordIncorrect.WithMessage("用户名或密码错误"))
 	}
 
-	// 5. 检查用户状态
+	// 4. 检查用户状态
 	if user.Status != 1 {
-		return nil, errorx.ToGRPCError(errorx.ErrUserDisabled.SetMessage("账户已被禁用"))
+		return nil, errorx.ToGRPCError(errorx.ErrUserDisabled.WithMessage("账户已被禁用"))
 	}
 
-	// 6. 生成 JWT Token
+	// 5. 生成 JWT Token
 	tokenStr, expireAt, err := token.Sign(user.UserId)
 	if err != nil {
 		logx.Errorf("生成Token失败: %v", err)
-		return nil, errorx.ToGRPCError(errorx.ErrSignToken.SetMessage("生成Token失败"))
+		return nil, errorx.ToGRPCError(errorx.ErrSignToken.WithMessage("生成Token失败"))
 	}
 
+	// 6. 密码哈希使用了旧的算法或参数时重新计算，随登录信息一并更新
+	l.rehashPassword(user, in.Password)
+
 	// 7. 更新登录信息
 	if err := l.updateLoginInfo(user, ""); err != nil {
 		logx.Errorf("更新登录信息失败: %v", err)
@@ -95,21 +94,6 @@ func (l *LoginLogic) Login(in *rpc.LoginRequest) (*rpc.LoginResponse, error) {
 	}, nil
 }
 
-// validateLoginRequest 验证登录请求参数
-func (l *LoginLogic) validateLoginRequest(in *rpc.LoginRequest) error {
-	// 验证用户名
-	if in.Username == "" {
-		return errorx.ErrInvalidParameter.SetMessage("用户名不能为空")
-	}
-
-	// 验证密码
-	if in.Password == "" {
-		return errorx.ErrInvalidParameter.SetMessage("密码不能为空")
-	}
-
-	return nil
-}
-
 // isAccountLocked 检查账户是否被锁定
 func (l *LoginLogic) isAccountLocked(username string) bool {
 	key := fmt.Sprintf("user:lock:%s", username)
@@ -143,10 +127,10 @@ func (l *LoginLogic) getUserByUsername(username string) (*models.Users, error) {
 
 	// 如果都找不到，返回用户不存在错误
 	if err == sqlx.ErrNotFound {
-		return nil, errorx.ErrUserNotFound.SetMessage("用户不存在")
+		return nil, errorx.ErrUserNotFound.WithMessage("用户不存在")
 	}
 
-	return nil, errorx.InternalServerError.SetMessage("查询用户信息失败")
+	return nil, errorx.InternalServerError.WithMessage("查询用户信息失败")
 }
 
 // recordFailedLogin 记录失败登录
@@ -179,7 +163,28 @@ func (l *LoginLogic) updateLoginInfo(user *models.Users, loginIP string) error {
 	user.LastLoginIp = loginIP
 	user.FailedLoginAttempts = 0
 
-	return l.svcCtx.UserModel.Update(l.ctx, user)
+	return withUserEvent(l.ctx, l.svcCtx, event.UserLoggedIn, user.UserId, &event.UserLoggedInData{
+		UserId: user.UserId,
+		IP:     loginIP,
+	}, func(ctx context.Context, session sqlx.Session) ([]string, error) {
+		return l.svcCtx.UserModel.UpdateWithSession(ctx, session, user)
+	})
+}
+
+// rehashPassword 密码校验成功后，将旧算法或旧参数的哈希升级为当前配置，失败时保留原哈希
+func (l *LoginLogic) rehashPassword(user *models.Users, password string) {
+	if !l.svcCtx.PasswordHasher.NeedsRehash(user.Password) {
+		return
+	}
+	hashed, err := l.svcCtx.PasswordHasher.Hash(password)
+	if err != nil {
+		l.Errorw("重新计算密码哈希失败",
+			logx.Field("userId", user.UserId),
+			logx.Field("error", err))
+		return
+	}
+	user.Password = hashed
+	l.Infow("密码哈希已升级", logx.Field("userId", user.UserId))
 }
 
 // resetFailedLoginCount 重置失败登录次数
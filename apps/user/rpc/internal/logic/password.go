@@ -0,0 +1,54 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package logic
+
+import (
+	"context"
+
+	"github.com/clin211/miniblog-v3/apps/user/models"
+	"github.com/clin211/miniblog-v3/apps/user/rpc/internal/svc"
+	"github.com/clin211/miniblog-v3/pkg/errorx"
+	"github.com/clin211/miniblog-v3/pkg/password"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// checkPasswordPolicy 按密码策略检查密码，违反策略时返回 validate.ValidationErrorsWithCode.
+func checkPasswordPolicy(ctx context.Context, svcCtx *svc.ServiceContext, in password.Input) error {
+	es, err := svcCtx.PasswordPolicy.Check(ctx, in)
+	if err != nil {
+		logx.WithContext(ctx).Errorw("密码策略检查失败", logx.Field("error", err))
+		return errorx.InternalServerError.WithMessage("密码策略检查失败")
+	}
+	if es.HasErrors() {
+		return es
+	}
+	return nil
+}
+
+// recordPasswordHistory 记录新的密码哈希并清理超出策略的历史，失败时只记录日志.
+func recordPasswordHistory(ctx context.Context, svcCtx *svc.ServiceContext, userId, hashed string) {
+	size := svcCtx.PasswordPolicy.HistorySize()
+	if size == 0 {
+		return
+	}
+
+	logger := logx.WithContext(ctx)
+	if _, err := svcCtx.PasswordHistoryModel.Insert(ctx, &models.UserPasswordHistories{
+		UserId:   userId,
+		Password: hashed,
+	}); err != nil {
+		logger.Errorw("记录密码历史失败",
+			logx.Field("userId", userId),
+			logx.Field("error", err))
+		return
+	}
+	if err := svcCtx.PasswordHistoryModel.Prune(ctx, userId, size); err != nil {
+		logger.Errorw("清理密码历史失败",
+			logx.Field("userId", userId),
+			logx.Field("error", err))
+	}
+}
@@ -8,13 +8,13 @@ package logic
 import (
 	"context"
 	"database/sql"
-	"regexp"
 
 	"github.com/clin211/miniblog-v3/apps/user/models"
 	"github.com/clin211/miniblog-v3/apps/user/rpc/internal/svc"
 	"github.com/clin211/miniblog-v3/apps/user/rpc/pb/rpc"
-	"github.com/clin211/miniblog-v3/pkg/encrypt"
 	"github.com/clin211/miniblog-v3/pkg/errorx"
+	"github.com/clin211/miniblog-v3/pkg/event"
+	"github.com/clin211/miniblog-v3/pkg/password"
 	"github.com/clin211/miniblog-v3/pkg/rid"
 
 	"github.com/zeromicro/go-zero/core/logx"
@@ -37,8 +37,13 @@ func NewRegisterLogic(ctx context.Context, svcCtx *svc.ServiceContext) *Register
 
 // Register 用户注册
 func (l *RegisterLogic) Register(in *rpc.RegisterRequest) (*rpc.RegisterResponse, error) {
-	// 1. 参数验证
-	if err := l.validateRegisterRequest(in); err != nil {
+	// 参数已由 ValidateInterceptor 按 rules.Requests 校验，规则见 apps/user/rules/requests.go
+	// 1. 密码策略检查
+	if err := checkPasswordPolicy(l.ctx, l.svcCtx, password.Input{
+		Password: in.Password,
+		Username: in.Username,
+		Email:    in.Email,
+	}); err != nil {
 		return nil, errorx.ToGRPCError(err)
 	}
 
@@ -48,14 +53,18 @@ func (l *RegisterLogic) Register(in *rpc.RegisterRequest) (*rpc.RegisterResponse
 	}
 
 	// 3. 密码加密
-	hashedPassword, err := encrypt.Encrypt(in.Password)
+	hashedPassword, err := l.svcCtx.PasswordHasher.Hash(in.Password)
 	if err != nil {
 		logx.Errorf("密码加密失败: %v", err)
-		return nil, errorx.ToGRPCError(errorx.InternalServerError.SetMessage("密码加密失败"))
+		return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("密码加密失败"))
 	}
 
 	// 4. 生成用户ID
-	userId := rid.UserID.New()
+	userId, err := rid.UserID.New(l.ctx)
+	if err != nil {
+		logx.Errorf("生成用户ID失败: %v", err)
+		return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("生成用户ID失败"))
+	}
 
 	// 5. 使用模型构造用户实体并插入
 	user := &models.Users{
@@ -76,11 +85,21 @@ func (l *RegisterLogic) Register(in *rpc.RegisterRequest) (*rpc.RegisterResponse
 		FailedLoginAttempts: 0, // 0-失败登录次数
 	}
 
-	if _, err := l.svcCtx.UserModel.Insert(l.ctx, user); err != nil {
-		return nil, errorx.ToGRPCError(errorx.InternalServerError.SetMessage("用户创建失败"))
+	err = withUserEvent(l.ctx, l.svcCtx, event.UserRegistered, userId, &event.UserRegisteredData{
+		UserId:         userId,
+		Username:       user.Username,
+		Avatar:         user.Avatar,
+		RegisterSource: user.RegisterSource,
+	}, func(ctx context.Context, session sqlx.Session) ([]string, error) {
+		return l.svcCtx.UserModel.InsertWithSession(ctx, session, user)
+	})
+	if err != nil {
+		logx.Errorf("用户创建失败: %v", err)
+		return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("用户创建失败"))
 	}
 
-	// 6. 记录注册日志
+	// 6. 记录密码历史和注册日志
+	recordPasswordHistory(l.ctx, l.svcCtx, userId, hashedPassword)
 	logx.Infof("用户注册成功: %s, %s, %s", userId, in.Username, in.Email)
 
 	return &rpc.RegisterResponse{
@@ -88,74 +107,6 @@ func (l *RegisterLogic) Register(in *rpc.RegisterRequest) (*rpc.RegisterResponse
 	}, nil
 }
 
-// validateRegisterRequest 验证注册请求参数
-func (l *RegisterLogic) validateRegisterRequest(in *rpc.RegisterRequest) error {
-	// 验证用户名
-	if in.Username == "" {
-		return errorx.ErrInvalidParameter.SetMessage("用户名不能为空")
-	}
-	if len(in.Username) < 3 || len(in.Username) > 20 {
-		return errorx.ErrInvalidParameter.SetMessage("用户名长度必须在3-20个字符之间")
-	}
-	// 用户名只能包含字母、数字、下划线
-	usernameRegex := regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
-	if !usernameRegex.MatchString(in.Username) {
-		return errorx.ErrInvalidParameter.SetMessage("用户名只能包含字母、数字、下划线")
-	}
-
-	// 验证密码
-	if in.Password == "" {
-		return errorx.ErrInvalidParameter.SetMessage("密码不能为空")
-	}
-	if len(in.Password) < 6 || len(in.Password) > 32 {
-		return errorx.ErrInvalidParameter.SetMessage("密码长度必须在6-32个字符之间")
-	}
-	// 密码必须包含字母和数字
-	hasLetter := regexp.MustCompile(`[a-zA-Z]`).MatchString(in.Password)
-	hasDigit := regexp.MustCompile(`[0-9]`).MatchString(in.Password)
-	if !hasLetter || !hasDigit {
-		return errorx.ErrInvalidParameter.SetMessage("密码必须包含字母和数字")
-	}
-
-	// 验证邮箱
-	if in.Email == "" {
-		return errorx.ErrInvalidParameter.SetMessage("邮箱不能为空")
-	}
-	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
-	if !emailRegex.MatchString(in.Email) {
-		return errorx.ErrInvalidParameter.SetMessage("邮箱格式不正确")
-	}
-
-	// 验证手机号
-	if in.Phone == "" {
-		return errorx.ErrInvalidParameter.SetMessage("手机号不能为空")
-	}
-	if len(in.Phone) != 11 {
-		return errorx.ErrInvalidParameter.SetMessage("手机号必须是11位数字")
-	}
-	phoneRegex := regexp.MustCompile(`^1[3-9]\d{9}$`)
-	if !phoneRegex.MatchString(in.Phone) {
-		return errorx.ErrInvalidParameter.SetMessage("手机号格式不正确")
-	}
-
-	// 验证年龄
-	if in.Age < 1 || in.Age > 120 {
-		return errorx.ErrInvalidParameter.SetMessage("年龄必须在1-120岁之间")
-	}
-
-	// 验证性别
-	if in.Gender < 0 || in.Gender > 3 {
-		return errorx.ErrInvalidParameter.SetMessage("性别值必须在0-3之间")
-	}
-
-	// 验证注册来源
-	if in.RegisterSource < 1 || in.RegisterSource > 6 {
-		return errorx.ErrInvalidParameter.SetMessage("注册来源值必须在1-6之间")
-	}
-
-	return nil
-}
-
 // checkUserUniqueness 检查用户唯一性
 func (l *RegisterLogic) checkUserUniqueness(in *rpc.RegisterRequest) error {
 	type uniqueCheck struct {
@@ -171,7 +122,7 @@ func (l *RegisterLogic) checkUserUniqueness(in *rpc.RegisterRequest) error {
 				return nil
 			}
 			logx.Errorf("检查%s唯一性失败: %v", name, err)
-			return errorx.InternalServerError.SetMessage("%s", "检查"+name+"唯一性失败")
+			return errorx.InternalServerError.WithMessage("%s", "检查"+name+"唯一性失败")
 		}
 		return onExist
 	}
@@ -183,7 +134,7 @@ func (l *RegisterLogic) checkUserUniqueness(in *rpc.RegisterRequest) error {
 				_, err := l.svcCtx.UserModel.FindOneByUsername(l.ctx, in.Username)
 				return err
 			},
-			onExist: errorx.ErrUserAlreadyExists.SetMessage("用户名已存在"),
+			onExist: errorx.ErrUserAlreadyExists.WithMessage("用户名已存在"),
 		},
 		{
 			name: "邮箱",
@@ -191,7 +142,7 @@ func (l *RegisterLogic) checkUserUniqueness(in *rpc.RegisterRequest) error {
 				_, err := l.svcCtx.UserModel.FindOneByEmail(l.ctx, in.Email)
 				return err
 			},
-			onExist: errorx.ErrUserAlreadyExists.SetMessage("邮箱已存在"),
+			onExist: errorx.ErrUserAlreadyExists.WithMessage("邮箱已存在"),
 		},
 		{
 			name: "手机号",
@@ -199,7 +150,7 @@ func (l *RegisterLogic) checkUserUniqueness(in *rpc.RegisterRequest) error {
 				_, err := l.svcCtx.UserModel.FindOneByPhone(l.ctx, in.Phone)
 				return err
 			},
-			onExist: errorx.ErrUserAlreadyExists.SetMessage("手机号已存在"),
+			onExist: errorx.ErrUserAlreadyExists.WithMessage("手机号已存在"),
 		},
 	}
 
@@ -212,7 +163,7 @@ func (l *RegisterLogic) checkUserUniqueness(in *rpc.RegisterRequest) error {
 				_, err := l.svcCtx.UserModel.FindOneByWechatOpenid(l.ctx, wechatOpenid)
 				return err
 			},
-			onExist: errorx.ErrUserAlreadyExists.SetMessage("微信账号已存在"),
+			onExist: errorx.ErrUserAlreadyExists.WithMessage("微信账号已存在"),
 		})
 	}
 
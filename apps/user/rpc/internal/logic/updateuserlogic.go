@@ -12,9 +12,12 @@ import (
 	"github.com/clin211/miniblog-v3/apps/user/rpc/internal/svc"
 	"github.com/clin211/miniblog-v3/apps/user/rpc/pb/rpc"
 	"github.com/clin211/miniblog-v3/pkg/errorx"
+	"github.com/clin211/miniblog-v3/pkg/event"
 	"github.com/clin211/miniblog-v3/pkg/known"
+	"github.com/clin211/miniblog-v3/pkg/rid"
 
 	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
 )
 
 type UpdateUserLogic struct {
@@ -40,12 +43,17 @@ func (l *UpdateUserLogic) UpdateUser(in *rpc.UpdateUserRequest) (*rpc.UpdateUser
 		return nil, errorx.ToGRPCError(errorx.ErrTokenInvalid)
 	}
 
+	// 格式不正确的用户ID直接拒绝，避免无效查询
+	if err := rid.UserID.Validate(in.UserId); err != nil {
+		return nil, errorx.ToGRPCError(errorx.ErrInvalidParameter.WithMessage("用户ID格式不正确"))
+	}
+
 	// 验证用户权限
 	if userID != in.UserId {
 		l.Errorw("用户权限不足",
 			logx.Field("currentUserID", userID),
 			logx.Field("requestUserID", in.UserId))
-		return nil, errorx.ToGRPCError(errorx.ErrUnauthorized.SetMessage("只能更新自己的用户信息"))
+		return nil, errorx.ToGRPCError(errorx.ErrUnauthorized.WithMessage("只能更新自己的用户信息"))
 	}
 
 	// 从数据库查询用户信息
@@ -58,7 +66,7 @@ func (l *UpdateUserLogic) UpdateUser(in *rpc.UpdateUserRequest) (*rpc.UpdateUser
 		l.Errorw("查询用户信息失败",
 			logx.Field("userId", in.UserId),
 			logx.Field("error", err))
-		return nil, errorx.ToGRPCError(errorx.InternalServerError.SetMessage("查询用户信息失败"))
+		return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("查询用户信息失败"))
 	}
 
 	// 检查用户状态
@@ -82,12 +90,20 @@ func (l *UpdateUserLogic) UpdateUser(in *rpc.UpdateUserRequest) (*rpc.UpdateUser
 	}
 
 	// 保存到数据库
-	err = l.svcCtx.UserModel.Update(l.ctx, user)
+	err = withUserEvent(l.ctx, l.svcCtx, event.UserUpdated, user.UserId, &event.UserUpdatedData{
+		UserId:   user.UserId,
+		Username: user.Username,
+		Age:      user.Age,
+		Gender:   user.Gender,
+		Avatar:   user.Avatar,
+	}, func(ctx context.Context, session sqlx.Session) ([]string, error) {
+		return l.svcCtx.UserModel.UpdateWithSession(ctx, session, user)
+	})
 	if err != nil {
 		l.Errorw("更新用户信息失败",
 			logx.Field("userId", in.UserId),
 			logx.Field("error", err))
-		return nil, errorx.ToGRPCError(errorx.InternalServerError.SetMessage("更新用户信息失败"))
+		return nil, errorx.ToGRPCError(errorx.InternalServerError.WithMessage("更新用户信息失败"))
 	}
 
 	// 构建响应
@@ -0,0 +1,249 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package relay
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/clin211/miniblog-v3/apps/user/models"
+	"github.com/clin211/miniblog-v3/apps/user/rpc/internal/svc"
+	"github.com/clin211/miniblog-v3/pkg/event"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/core/stringx"
+)
+
+const (
+	// leaderLockKey relay 主节点锁，同一时间只有持有锁的实例发布事件
+	leaderLockKey = "user:outbox:relay"
+	// maxBackoff 连续发布失败时轮询间隔的上限
+	maxBackoff = time.Minute
+	// batchTimeout 未凑满一批时等待的时间，事件已经按批读出，不需要等待更多消息
+	batchTimeout = 10 * time.Millisecond
+	// cleanupInterval 清理已发布事件的间隔
+	cleanupInterval = time.Hour
+	// cleanupBatchSize 每次清理的最大事件数
+	cleanupBatchSize = 1000
+)
+
+// Relay 将 outbox 中待发布的用户事件按写入顺序发布到 Kafka.
+//
+// 多个实例通过 Redis 锁选出一个主节点发布事件，其他实例待命，主节点退出后由待命实例接管.
+// 每批事件分三步发布：短事务中领取事件并设置发布租约，在事务外发布到 Kafka，再用短事务标记为已发布.
+// 锁和租约的时长都是 Outbox.Lease，发布超时小于租约，锁意外丢失时旧主节点也无法覆盖新主节点领取的事件.
+// 事件在 Kafka 确认后才标记为已发布，进程崩溃或标记失败时会重复发布，投递语义为至少一次.
+type Relay struct {
+	svcCtx *svc.ServiceContext
+	owner  string
+	lock   *redis.RedisLock
+	writer *kafka.Writer
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// NewRelay 创建 Relay，实现 service.Service，可以和 rpc 服务放在同一个 ServiceGroup 中.
+func NewRelay(svcCtx *svc.ServiceContext) *Relay {
+	c := svcCtx.Config
+	logx.Must(checkConfig(c.Kafka.Timeout, c.Outbox.Interval, c.Outbox.Lease))
+
+	lock := redis.NewRedisLock(svcCtx.Redis, leaderLockKey)
+	lock.SetExpire(int(c.Outbox.Lease / time.Second))
+
+	return &Relay{
+		svcCtx: svcCtx,
+		owner:  newOwner(),
+		lock:   lock,
+		writer: &kafka.Writer{
+			Addr:  kafka.TCP(c.Kafka.Brokers...),
+			Topic: c.Outbox.Topic,
+			// 与 Java 客户端相同的分区算法，同一用户的事件进入同一分区
+			Balancer:     kafka.Murmur2Balancer{},
+			RequiredAcks: kafka.RequireAll,
+			MaxAttempts:  c.Kafka.MaxAttempts,
+			BatchSize:    c.Outbox.BatchSize,
+			BatchTimeout: batchTimeout,
+			WriteTimeout: c.Kafka.Timeout,
+		},
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+// Start 开始轮询 outbox，阻塞直到 Stop 被调用.
+func (r *Relay) Start() {
+	defer close(r.done)
+
+	c := r.svcCtx.Config.Outbox
+	delay := c.Interval
+	lastCleanup := time.Now()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-timer.C:
+		}
+
+		if !r.lead() {
+			timer.Reset(c.Interval)
+			continue
+		}
+
+		if err := r.drain(); err != nil {
+			// 连续发布失败时指数退避，避免 broker 不可用时频繁重试，发布成功后恢复轮询间隔
+			delay = min(delay*2, maxBackoff)
+		} else {
+			delay = c.Interval
+		}
+		if time.Since(lastCleanup) >= cleanupInterval {
+			r.cleanup()
+			lastCleanup = time.Now()
+		}
+		timer.Reset(delay)
+	}
+}
+
+// Stop 停止轮询，正在发布的批次会先完成，然后释放主节点锁并关闭 Writer.
+func (r *Relay) Stop() {
+	close(r.stop)
+	<-r.done
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.svcCtx.Config.Kafka.Timeout)
+	defer cancel()
+	if _, err := r.lock.ReleaseCtx(ctx); err != nil {
+		logx.Errorw("释放 outbox relay 主节点锁失败", logx.Field("error", err))
+	}
+	if err := r.writer.Close(); err != nil {
+		logx.Errorw("关闭 Kafka Writer 失败", logx.Field("error", err))
+	}
+}
+
+// lead 获取或续期主节点锁，返回当前实例是否为主节点.
+func (r *Relay) lead() bool {
+	ok, err := r.lock.AcquireCtx(context.Background())
+	if err != nil {
+		logx.Errorw("获取 outbox relay 主节点锁失败", logx.Field("error", err))
+		return false
+	}
+	return ok
+}
+
+// drain 连续发布直到没有积压的事件，每批之前续期主节点锁.
+func (r *Relay) drain() error {
+	for {
+		select {
+		case <-r.stop:
+			return nil
+		default:
+		}
+
+		n, err := r.relayOnce(context.Background())
+		if err != nil {
+			logx.Errorw("发布用户事件失败", logx.Field("error", err))
+			return err
+		}
+		if n < r.svcCtx.Config.Outbox.BatchSize || !r.lead() {
+			return nil
+		}
+	}
+}
+
+// relayOnce 领取一批待发布事件并发布到 Kafka，返回本批事件数.
+// 发布失败时释放领取的事件并记录失败原因，下一轮按原顺序重试.
+func (r *Relay) relayOnce(ctx context.Context) (int, error) {
+	c := r.svcCtx.Config
+	events, err := r.svcCtx.OutboxModel.ClaimPending(ctx, r.owner, c.Outbox.BatchSize, c.Outbox.Lease)
+	if errors.Is(err, models.ErrOutboxBusy) {
+		// 之前的主节点领取的事件租约未到期，等租约到期后接管，避免乱序
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	n := len(events)
+	if n == 0 {
+		return 0, nil
+	}
+
+	ids := make([]int64, n)
+	msgs := make([]kafka.Message, n)
+	for i, e := range events {
+		ids[i] = e.Id
+		msgs[i] = kafka.Message{
+			Key:   []byte(e.AggregateId),
+			Value: []byte(e.Payload),
+			Headers: []kafka.Header{
+				{Key: event.HeaderID, Value: []byte(e.EventId)},
+				{Key: event.HeaderType, Value: []byte(e.EventType)},
+			},
+			Time: e.CreatedAt,
+		}
+	}
+
+	pubCtx, cancel := context.WithTimeout(ctx, c.Kafka.Timeout)
+	publishErr := r.writer.WriteMessages(pubCtx, msgs...)
+	cancel()
+	if publishErr != nil {
+		return n, errors.Join(publishErr, r.svcCtx.OutboxModel.ReleaseClaim(ctx, r.owner, ids, publishErr.Error()))
+	}
+
+	marked, err := r.svcCtx.OutboxModel.MarkPublished(ctx, r.owner, ids)
+	if err != nil {
+		// 租约到期后事件会被重新领取并重复发布
+		return n, err
+	}
+	if marked < int64(n) {
+		logx.Sloww("部分用户事件的发布租约已被接管，这些事件会被重复发布",
+			logx.Field("events", n),
+			logx.Field("marked", marked))
+	}
+	return n, nil
+}
+
+// cleanup 删除超过保留时间的已发布事件.
+func (r *Relay) cleanup() {
+	before := time.Now().Add(-r.svcCtx.Config.Outbox.Retention)
+	for {
+		n, err := r.svcCtx.OutboxModel.DeletePublishedBefore(context.Background(), before, cleanupBatchSize)
+		if err != nil {
+			logx.Errorw("清理已发布用户事件失败", logx.Field("error", err))
+			return
+		}
+		if n < cleanupBatchSize {
+			return
+		}
+	}
+}
+
+// checkConfig 检查发布超时和轮询间隔小于租约，否则主节点在发布或待命期间锁就会过期.
+func checkConfig(timeout, interval, lease time.Duration) error {
+	if lease < time.Second {
+		return errors.New("outbox: Lease must be at least 1s")
+	}
+	if timeout >= lease {
+		return errors.New("outbox: Kafka.Timeout must be less than Outbox.Lease")
+	}
+	if interval >= lease {
+		return errors.New("outbox: Outbox.Interval must be less than Outbox.Lease")
+	}
+	return nil
+}
+
+// newOwner 返回当前实例的标识，用于领取事件. 同一主机上的多个进程通过随机后缀区分.
+func newOwner() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return host + "-" + stringx.Randn(8)
+}
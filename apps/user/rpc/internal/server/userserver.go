@@ -57,3 +57,15 @@ func (s *UserServer) Login(ctx context.Context, in *rpc.LoginRequest) (*rpc.Logi
 	l := logic.NewLoginLogic(ctx, s.svcCtx)
 	return l.Login(in)
 }
+
+// ChangePassword 修改密码
+func (s *UserServer) ChangePassword(ctx context.Context, in *rpc.ChangePasswordRequest) (*rpc.ChangePasswordResponse, error) {
+	l := logic.NewChangePasswordLogic(ctx, s.svcCtx)
+	return l.ChangePassword(in)
+}
+
+// BatchGetUsers 批量获取用户公开信息，调用方用一次请求代替逐个调用 GetUser
+func (s *UserServer) BatchGetUsers(ctx context.Context, in *rpc.BatchGetUsersRequest) (*rpc.BatchGetUsersResponse, error) {
+	l := logic.NewBatchGetUsersLogic(ctx, s.svcCtx)
+	return l.BatchGetUsers(in)
+}
@@ -8,6 +8,9 @@ package svc
 import (
 	"github.com/clin211/miniblog-v3/apps/user/models"
 	"github.com/clin211/miniblog-v3/apps/user/rpc/internal/config"
+	"github.com/clin211/miniblog-v3/pkg/encrypt"
+	"github.com/clin211/miniblog-v3/pkg/id"
+	"github.com/clin211/miniblog-v3/pkg/password"
 	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/core/stores/sqlx"
 )
@@ -15,10 +18,18 @@ import (
 type ServiceContext struct {
 	Config    config.Config
 	UserModel models.UsersModel
+	// PasswordHistoryModel 密码历史，用于禁止重复使用最近的密码
+	PasswordHistoryModel models.UserPasswordHistoriesModel
+	// OutboxModel 用户事件 outbox
+	OutboxModel models.UserOutboxEventsModel
 	// 添加原始数据库连接用于事务处理
 	DB sqlx.SqlConn
 	// Redis 客户端
 	Redis *redis.Redis
+	// PasswordHasher 用户密码哈希
+	PasswordHasher encrypt.Hasher
+	// PasswordPolicy 密码策略
+	PasswordPolicy *password.Policy
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
@@ -31,10 +42,23 @@ func NewServiceContext(c config.Config) *ServiceContext {
 	// 初始化 Redis 客户端
 	redisClient := redis.MustNewRedis(c.Cache[0].RedisConf)
 
+	// 初始化进程级的 Sonyflake，多实例部署时从 etcd 租用唯一的机器 ID
+	id.SetDefault(id.MustNewSonyflake(c.Sonyflake))
+
+	// 初始化密码哈希，PepperID 没有对应的 pepper 时启动失败
+	passwordHasher := encrypt.MustNewHasher(c.Password)
+
+	// 初始化密码策略，密码历史使用同一个 Hasher 比对
+	passwordPolicy := password.MustNewPolicy(c.PasswordPolicy, passwordHasher)
+
 	return &ServiceContext{
-		Config:    c,
-		UserModel: userModel,
-		DB:        conn, // 保存原始连接
-		Redis:     redisClient,
+		Config:               c,
+		UserModel:            userModel,
+		PasswordHistoryModel: models.NewUserPasswordHistoriesModel(conn),
+		OutboxModel:          models.NewUserOutboxEventsModel(conn),
+		DB:                   conn, // 保存原始连接
+		Redis:                redisClient,
+		PasswordHasher:       passwordHasher,
+		PasswordPolicy:       passwordPolicy,
 	}
 }
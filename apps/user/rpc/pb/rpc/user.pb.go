@@ -655,6 +655,263 @@ func (x *LoginResponse) GetExpireAt() string {
 	return ""
 }
 
+// ChangePasswordRequest 修改密码请求
+type ChangePasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                // 用户ID
+	OldPassword   string                 `protobuf:"bytes,2,opt,name=old_password,json=oldPassword,proto3" json:"old_password,omitempty"` // 原密码
+	NewPassword   string                 `protobuf:"bytes,3,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"` // 新密码
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_user_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ChangePasswordRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetOldPassword() string {
+	if x != nil {
+		return x.OldPassword
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+// ChangePasswordResponse 修改密码响应
+type ChangePasswordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // 是否成功
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordResponse) Reset() {
+	*x = ChangePasswordResponse{}
+	mi := &file_user_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordResponse) ProtoMessage() {}
+
+func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordResponse.ProtoReflect.Descriptor instead.
+func (*ChangePasswordResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ChangePasswordResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// UserProfile 用户公开信息，用于展示评论作者等
+type UserProfile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 用户ID
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`           // 用户名
+	Avatar        string                 `protobuf:"bytes,3,opt,name=avatar,proto3" json:"avatar,omitempty"`               // 头像URL
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserProfile) Reset() {
+	*x = UserProfile{}
+	mi := &file_user_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserProfile) ProtoMessage() {}
+
+func (x *UserProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserProfile.ProtoReflect.Descriptor instead.
+func (*UserProfile) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UserProfile) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserProfile) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserProfile) GetAvatar() string {
+	if x != nil {
+		return x.Avatar
+	}
+	return ""
+}
+
+// BatchGetUsersRequest 批量获取用户公开信息请求
+type BatchGetUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"` // 用户ID，最多 100 个
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetUsersRequest) Reset() {
+	*x = BatchGetUsersRequest{}
+	mi := &file_user_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetUsersRequest) ProtoMessage() {}
+
+func (x *BatchGetUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetUsersRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetUsersRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BatchGetUsersRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+// BatchGetUsersResponse 批量获取用户公开信息响应
+type BatchGetUsersResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Users         map[string]*UserProfile `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 用户ID -> 公开信息，不存在或已禁用的用户不返回
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetUsersResponse) Reset() {
+	*x = BatchGetUsersResponse{}
+	mi := &file_user_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetUsersResponse) ProtoMessage() {}
+
+func (x *BatchGetUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetUsersResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetUsersResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BatchGetUsersResponse) GetUsers() map[string]*UserProfile {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
 var File_user_proto protoreflect.FileDescriptor
 
 const file_user_proto_rawDesc = "" +
@@ -706,7 +963,25 @@ const file_user_proto_rawDesc = "" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\"B\n" +
 	"\rLoginResponse\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1b\n" +
-	"\texpire_at\x18\x02 \x01(\tR\bexpireAt2\xa3\x02\n" +
+	"\texpire_at\x18\x02 \x01(\tR\bexpireAt\"v\n" +
+	"\x15ChangePasswordRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\fold_password\x18\x02 \x01(\tR\voldPassword\x12!\n" +
+	"\fnew_password\x18\x03 \x01(\tR\vnewPassword\"2\n" +
+	"\x16ChangePasswordResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"Z\n" +
+	"\vUserProfile\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x16\n" +
+	"\x06avatar\x18\x03 \x01(\tR\x06avatar\"1\n" +
+	"\x14BatchGetUsersRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\"\xa0\x01\n" +
+	"\x15BatchGetUsersResponse\x12;\n" +
+	"\x05users\x18\x01 \x03(\v2%.rpc.BatchGetUsersResponse.UsersEntryR\x05users\x1aJ\n" +
+	"\n" +
+	"UsersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12&\n" +
+	"\x05value\x18\x02 \x01(\v2\x10.rpc.UserProfileR\x05value:\x028\x012\xb6\x03\n" +
 	"\x04User\x127\n" +
 	"\bRegister\x12\x14.rpc.RegisterRequest\x1a\x15.rpc.RegisterResponse\x124\n" +
 	"\aGetUser\x12\x13.rpc.GetUserRequest\x1a\x14.rpc.GetUserResponse\x12=\n" +
@@ -714,7 +989,9 @@ const file_user_proto_rawDesc = "" +
 	"UpdateUser\x12\x16.rpc.UpdateUserRequest\x1a\x17.rpc.UpdateUserResponse\x12=\n" +
 	"\n" +
 	"DeleteUser\x12\x16.rpc.DeleteUserRequest\x1a\x17.rpc.DeleteUserResponse\x12.\n" +
-	"\x05Login\x12\x11.rpc.LoginRequest\x1a\x12.rpc.LoginResponseB\aZ\x05./rpcb\x06proto3"
+	"\x05Login\x12\x11.rpc.LoginRequest\x1a\x12.rpc.LoginResponse\x12I\n" +
+	"\x0eChangePassword\x12\x1a.rpc.ChangePasswordRequest\x1a\x1b.rpc.ChangePasswordResponse\x12F\n" +
+	"\rBatchGetUsers\x12\x19.rpc.BatchGetUsersRequest\x1a\x1a.rpc.BatchGetUsersResponseB\aZ\x05./rpcb\x06proto3"
 
 var (
 	file_user_proto_rawDescOnce sync.Once
@@ -728,35 +1005,47 @@ func file_user_proto_rawDescGZIP() []byte {
 	return file_user_proto_rawDescData
 }
 
-var file_user_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_user_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_user_proto_goTypes = []any{
-	(*RegisterRequest)(nil),    // 0: rpc.RegisterRequest
-	(*RegisterResponse)(nil),   // 1: rpc.RegisterResponse
-	(*GetUserRequest)(nil),     // 2: rpc.GetUserRequest
-	(*GetUserResponse)(nil),    // 3: rpc.GetUserResponse
-	(*UpdateUserRequest)(nil),  // 4: rpc.UpdateUserRequest
-	(*UpdateUserResponse)(nil), // 5: rpc.UpdateUserResponse
-	(*DeleteUserRequest)(nil),  // 6: rpc.DeleteUserRequest
-	(*DeleteUserResponse)(nil), // 7: rpc.DeleteUserResponse
-	(*LoginRequest)(nil),       // 8: rpc.LoginRequest
-	(*LoginResponse)(nil),      // 9: rpc.LoginResponse
+	(*RegisterRequest)(nil),        // 0: rpc.RegisterRequest
+	(*RegisterResponse)(nil),       // 1: rpc.RegisterResponse
+	(*GetUserRequest)(nil),         // 2: rpc.GetUserRequest
+	(*GetUserResponse)(nil),        // 3: rpc.GetUserResponse
+	(*UpdateUserRequest)(nil),      // 4: rpc.UpdateUserRequest
+	(*UpdateUserResponse)(nil),     // 5: rpc.UpdateUserResponse
+	(*DeleteUserRequest)(nil),      // 6: rpc.DeleteUserRequest
+	(*DeleteUserResponse)(nil),     // 7: rpc.DeleteUserResponse
+	(*LoginRequest)(nil),           // 8: rpc.LoginRequest
+	(*LoginResponse)(nil),          // 9: rpc.LoginResponse
+	(*ChangePasswordRequest)(nil),  // 10: rpc.ChangePasswordRequest
+	(*ChangePasswordResponse)(nil), // 11: rpc.ChangePasswordResponse
+	(*UserProfile)(nil),            // 12: rpc.UserProfile
+	(*BatchGetUsersRequest)(nil),   // 13: rpc.BatchGetUsersRequest
+	(*BatchGetUsersResponse)(nil),  // 14: rpc.BatchGetUsersResponse
+	nil,                            // 15: rpc.BatchGetUsersResponse.UsersEntry
 }
 var file_user_proto_depIdxs = []int32{
-	0, // 0: rpc.User.Register:input_type -> rpc.RegisterRequest
-	2, // 1: rpc.User.GetUser:input_type -> rpc.GetUserRequest
-	4, // 2: rpc.User.UpdateUser:input_type -> rpc.UpdateUserRequest
-	6, // 3: rpc.User.DeleteUser:input_type -> rpc.DeleteUserRequest
-	8, // 4: rpc.User.Login:input_type -> rpc.LoginRequest
-	1, // 5: rpc.User.Register:output_type -> rpc.RegisterResponse
-	3, // 6: rpc.User.GetUser:output_type -> rpc.GetUserResponse
-	5, // 7: rpc.User.UpdateUser:output_type -> rpc.UpdateUserResponse
-	7, // 8: rpc.User.DeleteUser:output_type -> rpc.DeleteUserResponse
-	9, // 9: rpc.User.Login:output_type -> rpc.LoginResponse
-	5, // [5:10] is the sub-list for method output_type
-	0, // [0:5] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	15, // 0: rpc.BatchGetUsersResponse.users:type_name -> rpc.BatchGetUsersResponse.UsersEntry
+	12, // 1: rpc.BatchGetUsersResponse.UsersEntry.value:type_name -> rpc.UserProfile
+	0,  // 2: rpc.User.Register:input_type -> rpc.RegisterRequest
+	2,  // 3: rpc.User.GetUser:input_type -> rpc.GetUserRequest
+	4,  // 4: rpc.User.UpdateUser:input_type -> rpc.UpdateUserRequest
+	6,  // 5: rpc.User.DeleteUser:input_type -> rpc.DeleteUserRequest
+	8,  // 6: rpc.User.Login:input_type -> rpc.LoginRequest
+	10, // 7: rpc.User.ChangePassword:input_type -> rpc.ChangePasswordRequest
+	13, // 8: rpc.User.BatchGetUsers:input_type -> rpc.BatchGetUsersRequest
+	1,  // 9: rpc.User.Register:output_type -> rpc.RegisterResponse
+	3,  // 10: rpc.User.GetUser:output_type -> rpc.GetUserResponse
+	5,  // 11: rpc.User.UpdateUser:output_type -> rpc.UpdateUserResponse
+	7,  // 12: rpc.User.DeleteUser:output_type -> rpc.DeleteUserResponse
+	9,  // 13: rpc.User.Login:output_type -> rpc.LoginResponse
+	11, // 14: rpc.User.ChangePassword:output_type -> rpc.ChangePasswordResponse
+	14, // 15: rpc.User.BatchGetUsers:output_type -> rpc.BatchGetUsersResponse
+	9,  // [9:16] is the sub-list for method output_type
+	2,  // [2:9] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_user_proto_init() }
@@ -770,7 +1059,7 @@ func file_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_proto_rawDesc), len(file_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
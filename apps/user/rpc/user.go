@@ -10,9 +10,11 @@ import (
 	"fmt"
 
 	"github.com/clin211/miniblog-v3/apps/user/rpc/internal/config"
+	"github.com/clin211/miniblog-v3/apps/user/rpc/internal/relay"
 	"github.com/clin211/miniblog-v3/apps/user/rpc/internal/server"
 	"github.com/clin211/miniblog-v3/apps/user/rpc/internal/svc"
 	"github.com/clin211/miniblog-v3/apps/user/rpc/pb/rpc"
+	"github.com/clin211/miniblog-v3/apps/user/rules"
 	"github.com/clin211/miniblog-v3/pkg/middleware"
 
 	"github.com/zeromicro/go-zero/core/conf"
@@ -50,11 +52,16 @@ func main() {
 			reflection.Register(grpcServer)
 		}
 	})
-	defer s.Stop()
 
-	// 添加gRPC拦截器
-	s.AddUnaryInterceptors(middleware.AuthnInterceptor())
+	// 添加gRPC拦截器，在认证之后按 rules.Requests 校验参数
+	s.AddUnaryInterceptors(middleware.LocaleInterceptor(), middleware.AuthnInterceptor(), middleware.ValidateInterceptor(rules.Requests))
+
+	// rpc 服务和 outbox relay 一起启动和停止
+	group := service.NewServiceGroup()
+	defer group.Stop()
+	group.Add(s)
+	group.Add(relay.NewRelay(ctx))
 
 	fmt.Printf("Starting rpc server at %s...\n", c.ListenOn)
-	s.Start()
+	group.Start()
 }
@@ -19,16 +19,21 @@ import (
 )
 
 type (
-	DeleteUserRequest  = rpc.DeleteUserRequest
-	DeleteUserResponse = rpc.DeleteUserResponse
-	GetUserRequest     = rpc.GetUserRequest
-	GetUserResponse    = rpc.GetUserResponse
-	LoginRequest       = rpc.LoginRequest
-	LoginResponse      = rpc.LoginResponse
-	RegisterRequest    = rpc.RegisterRequest
-	RegisterResponse   = rpc.RegisterResponse
-	UpdateUserRequest  = rpc.UpdateUserRequest
-	UpdateUserResponse = rpc.UpdateUserResponse
+	BatchGetUsersRequest   = rpc.BatchGetUsersRequest
+	BatchGetUsersResponse  = rpc.BatchGetUsersResponse
+	ChangePasswordRequest  = rpc.ChangePasswordRequest
+	ChangePasswordResponse = rpc.ChangePasswordResponse
+	DeleteUserRequest      = rpc.DeleteUserRequest
+	DeleteUserResponse     = rpc.DeleteUserResponse
+	GetUserRequest         = rpc.GetUserRequest
+	GetUserResponse        = rpc.GetUserResponse
+	LoginRequest           = rpc.LoginRequest
+	LoginResponse          = rpc.LoginResponse
+	RegisterRequest        = rpc.RegisterRequest
+	RegisterResponse       = rpc.RegisterResponse
+	UpdateUserRequest      = rpc.UpdateUserRequest
+	UpdateUserResponse     = rpc.UpdateUserResponse
+	UserProfile            = rpc.UserProfile
 
 	User interface {
 		// Register 用户注册
@@ -41,6 +46,10 @@ type (
 		DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error)
 		// Login 用户登录
 		Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+		// ChangePassword 修改密码
+		ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error)
+		// BatchGetUsers 批量获取用户公开信息，调用方用一次请求代替逐个调用 GetUser
+		BatchGetUsers(ctx context.Context, in *BatchGetUsersRequest, opts ...grpc.CallOption) (*BatchGetUsersResponse, error)
 	}
 
 	defaultUser struct {
@@ -83,3 +92,15 @@ func (m *defaultUser) Login(ctx context.Context, in *LoginRequest, opts ...grpc.
 	client := rpc.NewUserClient(m.cli.Conn())
 	return client.Login(ctx, in, opts...)
 }
+
+// ChangePassword 修改密码
+func (m *defaultUser) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error) {
+	client := rpc.NewUserClient(m.cli.Conn())
+	return client.ChangePassword(ctx, in, opts...)
+}
+
+// BatchGetUsers 批量获取用户公开信息，调用方用一次请求代替逐个调用 GetUser
+func (m *defaultUser) BatchGetUsers(ctx context.Context, in *BatchGetUsersRequest, opts ...grpc.CallOption) (*BatchGetUsersResponse, error) {
+	client := rpc.NewUserClient(m.cli.Conn())
+	return client.BatchGetUsers(ctx, in, opts...)
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package rules
+
+import (
+	"github.com/clin211/miniblog-v3/apps/user/rpc/pb/rpc"
+	"github.com/clin211/miniblog-v3/pkg/validate"
+)
+
+// Requests 用户 RPC 请求的校验规则，由 middleware.ValidateInterceptor 在调用 logic 前执行.
+// 规则与 user.api 中对应请求的 valid 标签保持一致（注册请求的 age、registerSource 除外），字段名使用 API 的 JSON 名称
+var Requests = validate.RequestRules{
+	rpc.User_Register_FullMethodName:       validate.ForRequest(registerRequest),
+	rpc.User_GetUser_FullMethodName:        validate.ForRequest(getUserRequest),
+	rpc.User_UpdateUser_FullMethodName:     validate.ForRequest(updateUserRequest),
+	rpc.User_DeleteUser_FullMethodName:     validate.ForRequest(deleteUserRequest),
+	rpc.User_Login_FullMethodName:          validate.ForRequest(loginRequest),
+	rpc.User_ChangePassword_FullMethodName: validate.ForRequest(changePasswordRequest),
+	rpc.User_BatchGetUsers_FullMethodName:  validate.ForRequest(batchGetUsersRequest),
+}
+
+// registerRequest 验证注册请求，密码的长度和复杂度由密码策略检查.
+// age 和 registerSource 在 RPC 中必填；API 为兼容已有客户端保持可选，缺省时由 RPC 返回参数错误
+func registerRequest(x *rpc.RegisterRequest) error {
+	return validate.ValidateStructWithCustomRules(&struct {
+		Username       string `json:"username" valid:"required,username"`
+		Password       string `json:"password" valid:"required"`
+		Email          string `json:"email" valid:"required,email"`
+		Phone          string `json:"phone" valid:"required,cn_mobile"`
+		Age            int32  `json:"age" valid:"required,user_age"`
+		Gender         int32  `json:"gender" valid:"user_gender"`
+		RegisterSource int32  `json:"registerSource" valid:"required,user_register_source"`
+		WechatOpenid   string `json:"wechatOpenid" valid:"required_if(RegisterSource|3)"`
+	}{
+		Username:       x.GetUsername(),
+		Password:       x.GetPassword(),
+		Email:          x.GetEmail(),
+		Phone:          x.GetPhone(),
+		Age:            x.GetAge(),
+		Gender:         x.GetGender(),
+		RegisterSource: x.GetRegisterSource(),
+		WechatOpenid:   x.GetWechatOpenid(),
+	})
+}
+
+// getUserRequest 验证获取用户请求
+func getUserRequest(x *rpc.GetUserRequest) error {
+	return validateUserID(x.GetUserId())
+}
+
+// updateUserRequest 验证更新用户请求
+func updateUserRequest(x *rpc.UpdateUserRequest) error {
+	return validate.ValidateStructWithCustomRules(&struct {
+		UserId   string `json:"userId" valid:"required"`
+		Username string `json:"username" valid:"username"`
+		Age      int32  `json:"age" valid:"user_age"`
+		Gender   int32  `json:"gender" valid:"user_gender"`
+	}{
+		UserId:   x.GetUserId(),
+		Username: x.GetUsername(),
+		Age:      x.GetAge(),
+		Gender:   x.GetGender(),
+	})
+}
+
+// deleteUserRequest 验证删除用户请求
+func deleteUserRequest(x *rpc.DeleteUserRequest) error {
+	return validateUserID(x.GetUserId())
+}
+
+// loginRequest 验证登录请求
+func loginRequest(x *rpc.LoginRequest) error {
+	return validate.ValidateStructWithCustomRules(&struct {
+		Username string `json:"username" valid:"required"`
+		Password string `json:"password" valid:"required"`
+	}{
+		Username: x.GetUsername(),
+		Password: x.GetPassword(),
+	})
+}
+
+// changePasswordRequest 验证修改密码请求，新密码的长度和复杂度由密码策略检查
+func changePasswordRequest(x *rpc.ChangePasswordRequest) error {
+	return validate.ValidateStructWithCustomRules(&struct {
+		UserId      string `json:"userId" valid:"required"`
+		OldPassword string `json:"oldPassword" valid:"required"`
+		NewPassword string `json:"newPassword" valid:"required"`
+	}{
+		UserId:      x.GetUserId(),
+		OldPassword: x.GetOldPassword(),
+		NewPassword: x.GetNewPassword(),
+	})
+}
+
+// batchGetUsersRequest 验证批量获取用户请求
+func batchGetUsersRequest(x *rpc.BatchGetUsersRequest) error {
+	return validate.ValidateStructWithCustomRules(&struct {
+		UserIds []string `json:"userIds" valid:"min_items(1),max_items(100),dive,required"`
+	}{
+		UserIds: x.GetUserIds(),
+	})
+}
+
+// validateUserID 验证只包含用户ID的请求，ID 的格式由 logic 检查
+func validateUserID(userID string) error {
+	return validate.ValidateStructWithCustomRules(&struct {
+		UserId string `json:"userId" valid:"required"`
+	}{UserId: userID})
+}
@@ -0,0 +1,31 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package rules
+
+import "github.com/clin211/miniblog-v3/pkg/validate"
+
+// 用户服务的参数校验规则别名. user.api 的 valid 标签和 Requests 中的 RPC 请求校验规则都引用这些别名，
+// 修改规则时只需要改这里. 使用别名的进程需要导入本包
+const (
+	// Username 用户名，3-20 个字母、数字或下划线，与 users.username 列的长度一致
+	Username = "username"
+	// Mobile 中国大陆手机号
+	Mobile = "cn_mobile"
+	// Age 年龄
+	Age = "user_age"
+	// Gender 性别：0-未设置，1-男，2-女，3-其他
+	Gender = "user_gender"
+	// RegisterSource 注册来源：1-web，2-app，3-wechat，4-qq，5-github，6-google
+	RegisterSource = "user_register_source"
+)
+
+func init() {
+	validate.RegisterAlias(Username, "length(3|20),matches(^[a-zA-Z0-9_]+$)", "用户名，3-20 个字母、数字或下划线")
+	validate.RegisterAlias(Mobile, "length(11),matches(^1[3-9]\\d{9}$)", "中国大陆手机号")
+	validate.RegisterAlias(Age, "range(1|120)", "年龄")
+	validate.RegisterAlias(Gender, "range(0|3)", "性别")
+	validate.RegisterAlias(RegisterSource, "range(1|6)", "注册来源")
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+// errcode 导出 errorx 中注册的全部错误码，供前端和文档使用.
+//
+//	go run ./cmd/errcode -format markdown -o docs/errcode.md
+//	go run ./cmd/errcode -format json
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/clin211/miniblog-v3/pkg/errorx"
+)
+
+var (
+	format = flag.String("format", "markdown", "output format: markdown or json")
+	output = flag.String("o", "", "output file, defaults to stdout")
+)
+
+// catalog 为 JSON 格式的导出内容
+type catalog struct {
+	Modules []errorx.Module `json:"modules"`
+	Errors  []errorx.Entry  `json:"errors"`
+}
+
+func main() {
+	flag.Parse()
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	bw := bufio.NewWriter(w)
+	var err error
+	switch *format {
+	case "markdown", "md":
+		err = writeMarkdown(bw)
+	case "json":
+		err = writeJSON(bw)
+	default:
+		err = fmt.Errorf("unknown format %q", *format)
+	}
+	if err == nil {
+		err = bw.Flush()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func writeJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(catalog{Modules: errorx.Modules(), Errors: errorx.Catalog()})
+}
+
+func writeMarkdown(w io.Writer) error {
+	entries := errorx.Catalog()
+
+	fmt.Fprintln(w, "# 错误码")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "> 本文件由 `go run ./cmd/errcode` 生成，请勿手动修改。")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "错误码共 6 位，前三位为 HTTP 状态码，后三位为模块内编号。")
+
+	for _, m := range errorx.Modules() {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "## %s (%03d-%03d)\n\n", m.Name, m.Min, m.Max)
+
+		n := 0
+		for _, e := range entries {
+			if e.Module != m.Name {
+				continue
+			}
+			if n == 0 {
+				fmt.Fprintln(w, "| 错误码 | HTTP | gRPC | 默认信息 | 说明 |")
+				fmt.Fprintln(w, "| --- | --- | --- | --- | --- |")
+			}
+			fmt.Fprintf(w, "| %d | %d | %s | %s | %s |\n", e.Code, e.HTTP, e.GRPC, escape(e.Message), escape(e.Description))
+			n++
+		}
+		if n == 0 {
+			fmt.Fprintln(w, "暂无错误码。")
+		}
+	}
+	return nil
+}
+
+// escape 转义 Markdown 表格中的竖线
+func escape(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -9,7 +9,7 @@ set -euo pipefail
 
 # ----------------------------------------------
 # 基于 deploy/dev/docker-compose.env.yml 的 MySQL 初始化脚本
-# - 通过 Docker 容器 miniblog-mysql 注入 SQL：deploy/sql/user.sql
+# - 通过 Docker 容器 miniblog-mysql 注入 SQL：deploy/sql/user.sql、deploy/sql/shortlink.sql
 # - 自动等待 MySQL 服务可用
 # ----------------------------------------------
 
@@ -22,7 +22,7 @@ MYSQL_CONTAINER_NAME="miniblog-mysql"
 
 SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
 REPO_ROOT="$(cd "$SCRIPT_DIR/../.." && pwd)"
-SQL_FILE="$REPO_ROOT/sql/user.sql"
+SQL_FILES=("$REPO_ROOT/sql/user.sql" "$REPO_ROOT/sql/shortlink.sql")
 
 abort() {
   echo "[ERROR] $*" >&2
@@ -33,9 +33,11 @@ info() {
   echo "[INFO] $*"
 }
 
-if [[ ! -f "$SQL_FILE" ]]; then
-  abort "未找到 SQL 文件：$SQL_FILE"
-fi
+for SQL_FILE in "${SQL_FILES[@]}"; do
+  if [[ ! -f "$SQL_FILE" ]]; then
+    abort "未找到 SQL 文件：$SQL_FILE"
+  fi
+done
 
 # 检查 Docker 与容器状态
 if ! command -v docker >/dev/null 2>&1; then
@@ -65,8 +67,10 @@ wait_for_mysql() {
 wait_for_mysql || abort "MySQL 在预期时间内未就绪。"
 
 # 执行导入
-info "开始导入 SQL：$SQL_FILE"
-docker exec -i "${MYSQL_CONTAINER_NAME}" sh -c "mysql -u${MYSQL_ROOT_USER} -p${MYSQL_ROOT_PASSWORD}" < "$SQL_FILE" || abort "SQL 导入失败。"
+for SQL_FILE in "${SQL_FILES[@]}"; do
+  info "开始导入 SQL：$SQL_FILE"
+  docker exec -i "${MYSQL_CONTAINER_NAME}" sh -c "mysql -u${MYSQL_ROOT_USER} -p${MYSQL_ROOT_PASSWORD}" < "$SQL_FILE" || abort "SQL 导入失败：$SQL_FILE"
+done
 
 info "SQL 初始化完成。"
 
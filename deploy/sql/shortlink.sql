@@ -0,0 +1,80 @@
+-- Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+-- Use of this source code is governed by a MIT style
+-- license that can be found in the LICENSE file. The original repo for
+-- this file is https://github.com/clin211/miniblog-v3.git.
+
+-- 创建数据库
+CREATE DATABASE IF NOT EXISTS miniblog_shortlink DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci;
+USE miniblog_shortlink;
+
+-- 删除已存在的表
+DROP TABLE IF EXISTS short_links;
+
+-- 短链表
+CREATE TABLE `short_links` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '自增 ID',
+    `code` VARCHAR(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL DEFAULT '' COMMENT '短码或自定义别名，区分大小写',
+    `user_id` VARCHAR(32) NOT NULL DEFAULT '' COMMENT '创建者用户ID',
+    `original_url` VARCHAR(2048) NOT NULL DEFAULT '' COMMENT '原始URL',
+    `is_alias` TINYINT DEFAULT 0 COMMENT '是否为自定义别名；1-是,0-否',
+    `password` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '访问密码的 bcrypt 哈希（encrypt.Encrypt），为空表示无需密码',
+    `max_clicks` BIGINT DEFAULT 0 COMMENT '最大点击次数，0 表示不限制',
+    `external_key` VARCHAR(64) NULL COMMENT '调用方的幂等键，同一用户下唯一，例如 post:123',
+    `status` TINYINT DEFAULT 1 COMMENT '状态：1-正常，0-已删除',
+    `expire_at` TIMESTAMP NULL COMMENT '过期时间，为空表示永不过期',
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP() COMMENT '创建时间',
+    `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP() ON UPDATE CURRENT_TIMESTAMP() COMMENT '更新时间',
+    `deleted_at` TIMESTAMP NULL COMMENT '删除时间',
+
+    PRIMARY KEY (`id`),
+
+    -- 唯一索引
+    UNIQUE KEY uk_code (`code`),
+    UNIQUE KEY uk_user_id_external_key (`user_id`, `external_key`),
+
+    -- 基础查询索引
+    INDEX idx_user_id (`user_id`),
+    INDEX idx_expire_at (`expire_at`)
+) COMMENT='短链表' ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+-- 删除已存在的表
+DROP TABLE IF EXISTS short_link_stats;
+
+-- 短链访问统计表，按小时和按天聚合点击次数
+-- 小时粒度只统计总点击次数，天粒度同时统计来源、浏览器、操作系统、设备和国家的分布
+CREATE TABLE `short_link_stats` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '自增 ID',
+    `code` VARCHAR(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL DEFAULT '' COMMENT '短码',
+    `granularity` TINYINT NOT NULL DEFAULT 1 COMMENT '聚合粒度：1-小时，2-天',
+    `bucket_at` DATETIME NOT NULL COMMENT '时间桶起始时间',
+    `dimension` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '统计维度：total、referrer、browser、os、device、country',
+    `value` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '维度取值，total 维度为空',
+    `clicks` BIGINT NOT NULL DEFAULT 0 COMMENT '点击次数',
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP() COMMENT '创建时间',
+    `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP() ON UPDATE CURRENT_TIMESTAMP() COMMENT '更新时间',
+
+    PRIMARY KEY (`id`),
+
+    -- 唯一索引，用于累加点击次数
+    UNIQUE KEY uk_code_granularity_bucket_at_dimension_value (`code`, `granularity`, `bucket_at`, `dimension`, `value`),
+
+    -- 基础查询索引
+    INDEX idx_code_dimension_granularity_bucket_at (`code`, `dimension`, `granularity`, `bucket_at`)
+) COMMENT='短链访问统计表' ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+-- 删除已存在的表
+DROP TABLE IF EXISTS short_link_clicks;
+
+-- 短链已占用的点击次数，仅记录设置了最大点击次数的短链，用于判断点击次数是否用完
+CREATE TABLE `short_link_clicks` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '自增 ID',
+    `code` VARCHAR(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL DEFAULT '' COMMENT '短码',
+    `clicks` BIGINT NOT NULL DEFAULT 0 COMMENT '已占用的点击次数',
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP() COMMENT '创建时间',
+    `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP() ON UPDATE CURRENT_TIMESTAMP() COMMENT '更新时间',
+
+    PRIMARY KEY (`id`),
+
+    -- 唯一索引，用于累加点击次数
+    UNIQUE KEY uk_code (`code`)
+) COMMENT='短链点击次数表' ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
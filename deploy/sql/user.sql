@@ -8,6 +8,8 @@ CREATE DATABASE IF NOT EXISTS miniblog_user DEFAULT CHARACTER SET utf8mb4 COLLAT
 USE miniblog_user;
 
 -- 删除已存在的表（按依赖关系逆序删除）
+DROP TABLE IF EXISTS user_outbox_events;
+DROP TABLE IF EXISTS user_password_histories;
 DROP TABLE IF EXISTS users;
 DROP TABLE IF EXISTS casbin_rule;
 
@@ -18,7 +20,7 @@ CREATE TABLE `users` (
     `age` INT DEFAULT 0 COMMENT '年龄',
     `avatar` VARCHAR(255) DEFAULT '' COMMENT '头像URL',
     `username` VARCHAR(20) NOT NULL DEFAULT '' COMMENT '用户名',
-    `password` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '密码',
+    `password` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '密码哈希，argon2id PHC 格式，早期用户为 bcrypt',
     `password_updated_at` TIMESTAMP NULL COMMENT '密码更新时间',
     `email` VARCHAR(100) NOT NULL DEFAULT '' COMMENT '邮箱',
     `email_verified` TINYINT DEFAULT 0 COMMENT '邮箱是否已验证；1-已验证,0-未验证',
@@ -51,6 +53,38 @@ CREATE TABLE `users` (
     INDEX idx_deleted_at (`deleted_at`)
 ) COMMENT='用户表' ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
 
+-- 密码历史表，用于禁止重复使用最近的密码
+CREATE TABLE `user_password_histories` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '自增 ID',
+    `user_id` VARCHAR(32) NOT NULL DEFAULT '' COMMENT '用户ID',
+    `password` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '密码哈希',
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP() COMMENT '创建时间',
+
+    PRIMARY KEY (`id`),
+    INDEX idx_user_id_id (`user_id`, `id`)
+) COMMENT='密码历史表' ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+-- 用户事件 outbox 表，与用户数据在同一个事务中写入，由 relay 按 id 顺序发布到 Kafka
+CREATE TABLE `user_outbox_events` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '自增 ID，决定发布顺序',
+    `event_id` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '事件ID',
+    `event_type` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '事件类型',
+    `aggregate_id` VARCHAR(32) NOT NULL DEFAULT '' COMMENT '用户ID，作为 Kafka 消息键',
+    `payload` TEXT NOT NULL COMMENT '事件信封 JSON',
+    `status` TINYINT NOT NULL DEFAULT 0 COMMENT '状态：0-待发布，1-已发布，2-发布中',
+    `attempts` INT NOT NULL DEFAULT 0 COMMENT '发布失败次数',
+    `last_error` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '最近一次发布失败的原因',
+    `claimed_by` VARCHAR(128) NOT NULL DEFAULT '' COMMENT '领取事件的 relay 实例',
+    `claim_until` TIMESTAMP NULL COMMENT '发布租约的到期时间，到期后其他 relay 可以重新领取',
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP() COMMENT '创建时间',
+    `published_at` TIMESTAMP NULL COMMENT '发布时间',
+
+    PRIMARY KEY (`id`),
+    UNIQUE KEY uk_event_id (`event_id`),
+    INDEX idx_status_id (`status`, `id`),
+    INDEX idx_published_at (`published_at`)
+) COMMENT='用户事件 outbox 表' ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
 -- casbin_rule
 CREATE TABLE `casbin_rule` (
   `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
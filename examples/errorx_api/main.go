@@ -89,7 +89,7 @@ func main() {
 				return
 			}
 			if id == "404" {
-				// 返回普通 error，将按统一入口渲染为 Not Found
+				// 返回普通 error，将按统一入口渲染为 500，错误详情只记录到日志
 				response.WriteResponse(r.Context(), w, fmt.Errorf("record not found: %s", id))
 				return
 			}
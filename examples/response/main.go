@@ -74,7 +74,7 @@ func main() {
 		Handler: func(w http.ResponseWriter, r *http.Request) {
 			// 示例：构造一个校验错误（演示自动转换为 reason 数组）
 			verr := validate.ValidationErrors{
-				&validate.ValidationError{Field: "Email", Message: "邮箱格式不正确", Value: "final-direct-testexample.com"},
+				&validate.ValidationError{Field: "email", Message: "邮箱格式不正确", Value: "final-direct-testexample.com"},
 			}
 			response.WriteResponse(r.Context(), w, verr)
 		},
@@ -102,7 +102,7 @@ func main() {
 		Method: http.MethodGet,
 		Path:   "/normal-error",
 		Handler: func(w http.ResponseWriter, r *http.Request) {
-			// 普通 error 会被转换为 404 Not Found
+			// 资源不存在使用 errorx.ErrResourceNotFound，普通 error 会被转换为 500
 			response.WriteResponse(r.Context(), w, errorx.ErrResourceNotFound)
 		},
 	}})
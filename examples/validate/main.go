@@ -79,9 +79,8 @@ func main() {
 
 	// 示例3: 展示验证规则
 	fmt.Println("3. 支持的验证规则:")
-	rules := validate.GetCommonRules()
-	for name, rule := range rules {
-		fmt.Printf("   - %s: %s\n", name, rule.Description)
+	for _, rule := range validate.Rules() {
+		fmt.Printf("   - %s [%s]: %s\n", rule.Name, rule.Kind, rule.Description)
 	}
 
 	fmt.Println()
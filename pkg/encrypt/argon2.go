@@ -0,0 +1,145 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package encrypt
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	// argon2idPrefix argon2id 哈希串的前缀
+	argon2idPrefix = "$argon2id$"
+	// argon2SaltLength 盐值长度
+	argon2SaltLength = 16
+	// argon2KeyLength 哈希长度
+	argon2KeyLength = 32
+)
+
+// Argon2id 使用 argon2id 计算密码哈希，哈希串为 PHC 格式：
+//
+//	$argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>
+//
+// 使用 pepper 时在参数中追加 pepper ID，例如 m=65536,t=3,p=2,k=2025a.
+type Argon2id struct {
+	Memory  uint32 // 内存开销，单位为 KiB
+	Time    uint32 // 迭代次数
+	Threads uint8  // 并行度
+
+	PepperID string            // 当前使用的 pepper，为空表示不使用 pepper
+	Peppers  map[string]string // 全部 pepper，键为 pepper ID
+}
+
+// argon2Params 为哈希串中记录的参数.
+type argon2Params struct {
+	memory   uint32
+	time     uint32
+	threads  uint8
+	pepperID string
+	salt     []byte
+	key      []byte
+}
+
+// Hash 计算密码哈希.
+func (a *Argon2id) Hash(password string) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey(peppered(password, a.Peppers[a.PepperID]), salt, a.Time, a.Memory, a.Threads, argon2KeyLength)
+
+	params := fmt.Sprintf("m=%d,t=%d,p=%d", a.Memory, a.Time, a.Threads)
+	if a.PepperID != "" {
+		params += ",k=" + a.PepperID
+	}
+	return fmt.Sprintf("%sv=%d$%s$%s$%s", argon2idPrefix, argon2.Version, params,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Compare 使用哈希串中记录的参数校验密码.
+func (a *Argon2id) Compare(hashed, password string) error {
+	p, err := parseArgon2id(hashed)
+	if err != nil {
+		return err
+	}
+	pepper, ok := a.Peppers[p.pepperID]
+	if p.pepperID != "" && !ok {
+		return ErrUnknownPepper
+	}
+
+	key := argon2.IDKey(peppered(password, pepper), p.salt, p.time, p.memory, p.threads, uint32(len(p.key)))
+	if subtle.ConstantTimeCompare(key, p.key) != 1 {
+		return ErrMismatchedPassword
+	}
+	return nil
+}
+
+// NeedsRehash 判断哈希的算法、参数或 pepper 是否与当前配置不同.
+func (a *Argon2id) NeedsRehash(hashed string) bool {
+	p, err := parseArgon2id(hashed)
+	if err != nil {
+		return true
+	}
+	return p.memory != a.Memory || p.time != a.Time || p.threads != a.Threads ||
+		p.pepperID != a.PepperID || len(p.key) != argon2KeyLength
+}
+
+// parseArgon2id 解析 PHC 格式的 argon2id 哈希串.
+func parseArgon2id(hashed string) (*argon2Params, error) {
+	parts := strings.Split(hashed, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" || parts[2] != fmt.Sprintf("v=%d", argon2.Version) {
+		return nil, ErrUnknownHash
+	}
+
+	p := &argon2Params{}
+	for _, kv := range strings.Split(parts[3], ",") {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			return nil, ErrUnknownHash
+		}
+		var err error
+		switch k {
+		case "m":
+			p.memory, err = parseUint32(v)
+		case "t":
+			p.time, err = parseUint32(v)
+		case "p":
+			var n uint64
+			n, err = strconv.ParseUint(v, 10, 8)
+			p.threads = uint8(n)
+		case "k":
+			p.pepperID = v
+		default:
+			err = ErrUnknownHash
+		}
+		if err != nil {
+			return nil, ErrUnknownHash
+		}
+	}
+	if p.memory == 0 || p.time == 0 || p.threads == 0 {
+		return nil, ErrUnknownHash
+	}
+
+	var err error
+	if p.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, ErrUnknownHash
+	}
+	if p.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(p.key) == 0 {
+		return nil, ErrUnknownHash
+	}
+	return p, nil
+}
+
+func parseUint32(s string) (uint32, error) {
+	n, err := strconv.ParseUint(s, 10, 32)
+	return uint32(n), err
+}
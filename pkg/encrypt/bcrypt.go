@@ -0,0 +1,30 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package encrypt
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Bcrypt 校验早期版本使用 bcrypt 生成的哈希. bcrypt 会截断超过 72 字节的密码，新哈希统一使用 argon2id.
+type Bcrypt struct{}
+
+// Compare 校验密码.
+func (Bcrypt) Compare(hashed, password string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return ErrMismatchedPassword
+	}
+	return err
+}
+
+// isBcrypt 判断是否为 bcrypt 哈希串.
+func isBcrypt(hashed string) bool {
+	return strings.HasPrefix(hashed, "$2a$") || strings.HasPrefix(hashed, "$2b$") || strings.HasPrefix(hashed, "$2y$")
+}
@@ -1,4 +1,4 @@
-// Copyright 2025 长林啊 &lt;767425412@qq.com>. All rights reserved.
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
 // Use of this source code is governed by a MIT style
 // license that can be found in the LICENSE file. The original repo for
 // this file is https://github.com/clin211/miniblog-v3.git.
@@ -8,12 +8,14 @@ package encrypt
 import "golang.org/x/crypto/bcrypt"
 
 // Encrypt 使用 bcrypt 加密纯文本.
+// 用于短链访问密码等可以公开尝试的场景，校验只消耗 CPU，不会像 argon2id 那样按次占用大量内存.
+// 用户密码使用 NewHasher 创建的 Hasher.
 func Encrypt(source string) (string, error) {
 	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(source), bcrypt.DefaultCost)
 	return string(hashedBytes), err
 }
 
-// Compare 比较密文和明文是否相同.
+// Compare 比较 Encrypt 生成的密文和明文是否相同.
 func Compare(hashedPassword, password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 }
@@ -0,0 +1,108 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package encrypt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testConfig 使用较小的参数加快测试.
+func testConfig() Config {
+	return Config{Memory: 1024, Time: 1, Threads: 1}
+}
+
+func TestArgon2id(t *testing.T) {
+	h := MustNewHasher(testConfig())
+
+	hashed, err := h.Hash("password123")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hashed, "$argon2id$v=19$m=1024,t=1,p=1$"))
+	assert.NoError(t, h.Compare(hashed, "password123"))
+	assert.ErrorIs(t, h.Compare(hashed, "password124"), ErrMismatchedPassword)
+	assert.False(t, h.NeedsRehash(hashed))
+
+	// 超过 72 字节的密码不会被截断
+	long := strings.Repeat("a", 80)
+	hashed, err = h.Hash(long)
+	require.NoError(t, err)
+	assert.ErrorIs(t, h.Compare(hashed, long[:72]), ErrMismatchedPassword)
+
+	// 同一密码每次的盐值不同
+	other, err := h.Hash(long)
+	require.NoError(t, err)
+	assert.NotEqual(t, hashed, other)
+}
+
+func TestBcryptCompatible(t *testing.T) {
+	h := MustNewHasher(testConfig())
+
+	legacy, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	assert.NoError(t, h.Compare(string(legacy), "password123"))
+	assert.ErrorIs(t, h.Compare(string(legacy), "password124"), ErrMismatchedPassword)
+	assert.True(t, h.NeedsRehash(string(legacy)))
+
+	assert.ErrorIs(t, h.Compare("plain", "plain"), ErrUnknownHash)
+	assert.ErrorIs(t, h.Compare("$argon2id$v=19$m=x$a$b", "plain"), ErrUnknownHash)
+}
+
+func TestNeedsRehash(t *testing.T) {
+	old := MustNewHasher(testConfig())
+	hashed, err := old.Hash("password123")
+	require.NoError(t, err)
+
+	c := testConfig()
+	c.Time = 2
+	h := MustNewHasher(c)
+	assert.True(t, h.NeedsRehash(hashed))
+	// 旧参数的哈希仍然可以校验
+	assert.NoError(t, h.Compare(hashed, "password123"))
+}
+
+func TestPepper(t *testing.T) {
+	c := testConfig()
+	c.PepperID = "v1"
+	c.Peppers = map[string]string{"v1": "pepper-1"}
+	h := MustNewHasher(c)
+
+	hashed, err := h.Hash("password123")
+	require.NoError(t, err)
+	assert.Contains(t, hashed, ",k=v1$")
+	assert.NoError(t, h.Compare(hashed, "password123"))
+
+	// 不带 pepper 的哈希需要升级
+	plain, err := MustNewHasher(testConfig()).Hash("password123")
+	require.NoError(t, err)
+	assert.NoError(t, h.Compare(plain, "password123"))
+	assert.True(t, h.NeedsRehash(plain))
+
+	// 轮换 pepper 后旧哈希仍可校验，并需要升级
+	c.PepperID = "v2"
+	c.Peppers = map[string]string{"v1": "pepper-1", "v2": "pepper-2"}
+	rotated := MustNewHasher(c)
+	assert.NoError(t, rotated.Compare(hashed, "password123"))
+	assert.True(t, rotated.NeedsRehash(hashed))
+
+	// pepper 被移除后无法校验
+	c.Peppers = map[string]string{"v2": "pepper-2"}
+	assert.ErrorIs(t, MustNewHasher(c).Compare(hashed, "password123"), ErrUnknownPepper)
+
+	c.PepperID = "v3"
+	_, err = NewHasher(c)
+	assert.ErrorIs(t, err, ErrUnknownPepper)
+}
+
+func TestEncrypt(t *testing.T) {
+	hashed, err := Encrypt("secret")
+	require.NoError(t, err)
+	assert.NoError(t, Compare(hashed, "secret"))
+	assert.Error(t, Compare(hashed, "Secret"))
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package encrypt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrMismatchedPassword 表示密码与哈希不匹配
+	ErrMismatchedPassword = errors.New("encrypt: hashed password does not match the given password")
+	// ErrUnknownHash 表示无法识别哈希串的算法
+	ErrUnknownHash = errors.New("encrypt: unknown hash format")
+	// ErrUnknownPepper 表示哈希使用的 pepper 未配置
+	ErrUnknownPepper = errors.New("encrypt: unknown pepper id")
+)
+
+// Hasher 定义了密码哈希算法. 哈希串自带算法标识和参数，升级算法或参数后仍能校验旧的哈希.
+type Hasher interface {
+	// Hash 使用当前的算法和参数计算密码哈希
+	Hash(password string) (string, error)
+	// Compare 校验密码，不匹配时返回 ErrMismatchedPassword
+	Compare(hashed, password string) error
+	// NeedsRehash 判断哈希是否使用了旧的算法、参数或 pepper，需要在下次校验成功后重新计算
+	NeedsRehash(hashed string) bool
+}
+
+// Config 定义了密码哈希的配置，新哈希统一使用 argon2id，bcrypt 仅用于校验已有的哈希
+type Config struct {
+	Memory  uint32 `json:",default=65536"` // argon2id 内存开销，单位为 KiB
+	Time    uint32 `json:",default=3"`     // argon2id 迭代次数
+	Threads uint8  `json:",default=2"`     // argon2id 并行度
+
+	// PepperID 当前使用的 pepper，为空表示不使用 pepper
+	PepperID string `json:",optional"`
+	// Peppers 服务端保存的 pepper，键为 pepper ID. 轮换时新增一个 pepper 并修改 PepperID，
+	// 旧的 pepper 需要保留到所有用户重新登录完成重新哈希为止
+	Peppers map[string]string `json:",optional"`
+}
+
+// hasher 使用 argon2id 计算新哈希，按哈希前缀选择校验算法.
+type hasher struct {
+	argon2 *Argon2id
+	bcrypt Bcrypt
+}
+
+// NewHasher 根据配置创建 Hasher.
+func NewHasher(c Config) (Hasher, error) {
+	if c.PepperID != "" {
+		if _, ok := c.Peppers[c.PepperID]; !ok {
+			return nil, ErrUnknownPepper
+		}
+	}
+	return &hasher{
+		argon2: &Argon2id{
+			Memory:   c.Memory,
+			Time:     c.Time,
+			Threads:  c.Threads,
+			PepperID: c.PepperID,
+			Peppers:  c.Peppers,
+		},
+	}, nil
+}
+
+// MustNewHasher 根据配置创建 Hasher，配置错误时 panic.
+func MustNewHasher(c Config) Hasher {
+	h, err := NewHasher(c)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+func (h *hasher) Hash(password string) (string, error) {
+	return h.argon2.Hash(password)
+}
+
+func (h *hasher) Compare(hashed, password string) error {
+	switch {
+	case strings.HasPrefix(hashed, argon2idPrefix):
+		return h.argon2.Compare(hashed, password)
+	case isBcrypt(hashed):
+		return h.bcrypt.Compare(hashed, password)
+	default:
+		return ErrUnknownHash
+	}
+}
+
+func (h *hasher) NeedsRehash(hashed string) bool {
+	return h.argon2.NeedsRehash(hashed)
+}
+
+// peppered 使用 HMAC-SHA256 将 pepper 混入密码，pepper 为空时原样返回.
+func peppered(password, pepper string) []byte {
+	if pepper == "" {
+		return []byte(password)
+	}
+	mac := hmac.New(sha256.New, []byte(pepper))
+	mac.Write([]byte(password))
+	return mac.Sum(nil)
+}
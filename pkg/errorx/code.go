@@ -8,43 +8,83 @@ package errorx
 import "net/http"
 
 // Code 定义了错误码的常量；前三位为 HTTP 状态码, 后三位为业务模块编号
+// 错误码通过 Register 在所属模块的码段中注册，重复或越界会在启动时 panic
 var (
 	// OK 代表请求成功.
-	OK = &Errno{HTTP: http.StatusOK, Code: 0, Message: "Success.", Data: nil, Reason: ""}
+	OK = Register(ModuleCommon, &Errno{HTTP: http.StatusOK, Code: 0, Message: "Success.", Data: nil, Reason: ""}, "请求成功")
 
 	// InternalServerError 表示所有未知的服务器端错误.
-	InternalServerError = &Errno{HTTP: http.StatusInternalServerError, Code: 500001, Message: "Internal server error.", Data: nil, Reason: ""}
+	InternalServerError = Register(ModuleCommon, &Errno{HTTP: http.StatusInternalServerError, Code: 500001, Message: "Internal server error.", Data: nil, Reason: ""}, "未知的服务器端错误")
 
 	// ErrResourceNotFound 表示资源不存在.
-	ErrResourceNotFound = &Errno{HTTP: http.StatusNotFound, Code: 404001, Message: "Resource not found.", Data: nil, Reason: ""}
+	ErrResourceNotFound = Register(ModuleCommon, &Errno{HTTP: http.StatusNotFound, Code: 404001, Message: "Resource not found.", Data: nil, Reason: ""}, "资源不存在")
 
 	// ErrBind 表示参数绑定错误.
-	ErrBind = &Errno{HTTP: http.StatusBadRequest, Code: 400001, Message: "Error occurred while binding the request body to the struct.", Data: nil, Reason: ""}
+	ErrBind = Register(ModuleCommon, &Errno{HTTP: http.StatusBadRequest, Code: 400001, Message: "Error occurred while binding the request body to the struct.", Data: nil, Reason: ""}, "参数绑定错误")
 
 	// ErrInvalidParameter 表示所有验证失败的错误.
-	ErrInvalidParameter = &Errno{HTTP: http.StatusBadRequest, Code: 400002, Message: "Parameter verification failed.", Data: nil, Reason: ""}
+	ErrInvalidParameter = Register(ModuleCommon, &Errno{HTTP: http.StatusBadRequest, Code: 400002, Message: "Parameter verification failed.", Data: nil, Reason: ""}, "参数校验失败")
+
+	// ErrInvalidCursor 表示分页游标无效或已过期.
+	ErrInvalidCursor = Register(ModuleCommon, &Errno{HTTP: http.StatusBadRequest, Code: 400003, Message: "Invalid pagination cursor.", Data: nil, Reason: ""}, "分页游标无效")
 
 	// ErrSignToken 表示签发 JWT Token 时出错.
-	ErrSignToken = &Errno{HTTP: http.StatusUnauthorized, Code: 401001, Message: "Error occurred while signing the JSON web token.", Data: nil, Reason: ""}
+	ErrSignToken = Register(ModuleCommon, &Errno{HTTP: http.StatusUnauthorized, Code: 401001, Message: "Error occurred while signing the JSON web token.", Data: nil, Reason: ""}, "签发 JWT Token 时出错")
 
 	// ErrTokenInvalid 表示 JWT Token 格式错误.
-	ErrTokenInvalid = &Errno{HTTP: http.StatusUnauthorized, Code: 401002, Message: "Token was invalid.", Data: nil, Reason: ""}
+	ErrTokenInvalid = Register(ModuleCommon, &Errno{HTTP: http.StatusUnauthorized, Code: 401002, Message: "Token was invalid.", Data: nil, Reason: ""}, "JWT Token 格式错误")
 
 	// ErrUnauthorized 表示请求没有被授权.
-	ErrUnauthorized = &Errno{HTTP: http.StatusUnauthorized, Code: 401003, Message: "Unauthorized.", Data: nil, Reason: ""}
+	ErrUnauthorized = Register(ModuleCommon, &Errno{HTTP: http.StatusUnauthorized, Code: 401003, Message: "Unauthorized.", Data: nil, Reason: ""}, "请求没有被授权")
+
+	// ErrForbidden 表示没有权限执行该操作.
+	ErrForbidden = Register(ModuleCommon, &Errno{HTTP: http.StatusForbidden, Code: 403001, Message: "Forbidden.", Data: nil, Reason: ""}, "没有权限执行该操作")
+
+	// ErrConflict 表示资源已存在或与当前状态冲突.
+	ErrConflict = Register(ModuleCommon, &Errno{HTTP: http.StatusConflict, Code: 409001, Message: "Resource conflict.", Data: nil, Reason: ""}, "资源已存在或与当前状态冲突")
 )
 
 // 用户模块 code 段的后三位区间为 100~199
 var (
 	// ErrUserAlreadyExists 表示用户已存在.
-	ErrUserAlreadyExists = &Errno{HTTP: http.StatusConflict, Code: 409101, Message: "User already exists.", Data: nil, Reason: ""}
+	ErrUserAlreadyExists = Register(ModuleUser, &Errno{HTTP: http.StatusConflict, Code: 409101, Message: "User already exists.", Data: nil, Reason: ""}, "用户已存在")
 
 	// ErrUserNotFound 表示用户不存在.
-	ErrUserNotFound = &Errno{HTTP: http.StatusNotFound, Code: 404102, Message: "User not found.", Data: nil, Reason: ""}
+	ErrUserNotFound = Register(ModuleUser, &Errno{HTTP: http.StatusNotFound, Code: 404102, Message: "User not found.", Data: nil, Reason: ""}, "用户不存在")
 
 	// ErrPasswordIncorrect 表示密码错误.
-	ErrPasswordIncorrect = &Errno{HTTP: http.StatusUnauthorized, Code: 401103, Message: "Password incorrect.", Data: nil, Reason: ""}
+	ErrPasswordIncorrect = Register(ModuleUser, &Errno{HTTP: http.StatusUnauthorized, Code: 401103, Message: "Password incorrect.", Data: nil, Reason: ""}, "密码错误")
 
 	// ErrUserDisabled 表示用户被禁用.
-	ErrUserDisabled = &Errno{HTTP: http.StatusForbidden, Code: 403104, Message: "User is disabled.", Data: nil, Reason: ""}
+	ErrUserDisabled = Register(ModuleUser, &Errno{HTTP: http.StatusForbidden, Code: 403104, Message: "User is disabled.", Data: nil, Reason: ""}, "用户被禁用")
+)
+
+// 短链模块 code 段的后三位区间为 200~299
+var (
+	// ErrShortLinkNotFound 表示短链不存在.
+	ErrShortLinkNotFound = Register(ModuleShortLink, &Errno{HTTP: http.StatusNotFound, Code: 404201, Message: "Short link not found.", Data: nil, Reason: ""}, "短链不存在")
+
+	// ErrShortLinkGone 表示短链已过期或已删除.
+	ErrShortLinkGone = Register(ModuleShortLink, &Errno{HTTP: http.StatusGone, Code: 410202, Message: "Short link has expired or been deleted.", Data: nil, Reason: ""}, "短链已过期或已删除")
+
+	// ErrShortLinkForbidden 表示无权操作该短链.
+	ErrShortLinkForbidden = Register(ModuleShortLink, &Errno{HTTP: http.StatusForbidden, Code: 403203, Message: "No permission to access the short link.", Data: nil, Reason: ""}, "无权操作该短链")
+
+	// ErrShortLinkAliasExists 表示自定义别名已被占用.
+	ErrShortLinkAliasExists = Register(ModuleShortLink, &Errno{HTTP: http.StatusConflict, Code: 409204, Message: "Short link alias already exists.", Data: nil, Reason: ""}, "自定义别名已被占用")
+
+	// ErrShortLinkAliasInvalid 表示自定义别名格式不正确或为保留字.
+	ErrShortLinkAliasInvalid = Register(ModuleShortLink, &Errno{HTTP: http.StatusBadRequest, Code: 400205, Message: "Short link alias is invalid or reserved.", Data: nil, Reason: ""}, "自定义别名格式不正确或为保留字")
+
+	// ErrShortLinkPasswordRequired 表示访问短链需要密码.
+	ErrShortLinkPasswordRequired = Register(ModuleShortLink, &Errno{HTTP: http.StatusUnauthorized, Code: 401206, Message: "Short link password required.", Data: nil, Reason: ""}, "访问短链需要密码")
+
+	// ErrShortLinkPasswordIncorrect 表示短链访问密码错误.
+	ErrShortLinkPasswordIncorrect = Register(ModuleShortLink, &Errno{HTTP: http.StatusUnauthorized, Code: 401207, Message: "Short link password incorrect.", Data: nil, Reason: ""}, "短链访问密码错误")
+
+	// ErrShortLinkPasswordLocked 表示短链访问密码错误次数过多，暂时禁止再次尝试.
+	ErrShortLinkPasswordLocked = Register(ModuleShortLink, &Errno{HTTP: http.StatusTooManyRequests, Code: 429208, Message: "Too many incorrect short link passwords, please try again later.", Data: nil, Reason: ""}, "短链访问密码错误次数过多")
+
+	// ErrShortLinkExternalKeyConflict 表示外部键已对应一条参数不同的短链.
+	ErrShortLinkExternalKeyConflict = Register(ModuleShortLink, &Errno{HTTP: http.StatusConflict, Code: 409209, Message: "External key is already bound to a short link with different options.", Data: nil, Reason: ""}, "外部键已对应参数不同的短链")
 )
@@ -5,15 +5,23 @@
 
 package errorx
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // Errno 定义了 miniblog 使用的错误类型.
+// 包级别定义的 Errno 是所有请求共享的，不可修改；With* 方法返回修改后的副本.
 type Errno struct {
 	HTTP    int         `json:"http"`
 	Code    int         `json:"code"`
 	Message string      `json:"message"`
 	Reason  string      `json:"reason"`
 	Data    interface{} `json:"data"`
+
+	cause  error         // 底层错误，只用于日志和 errors.As，不返回给调用方
+	format string        // WithMessage 设置的消息格式，作为本地化的键
+	args   []interface{} // WithMessage 设置的消息参数
 }
 
 // Error 实现 error 接口中的 `Error` 方法.
@@ -21,10 +29,47 @@ func (err *Errno) Error() string {
 	return err.Message
 }
 
-// SetMessage 设置 Errno 类型错误中的 Message 字段.
-func (err *Errno) SetMessage(format string, args ...interface{}) *Errno {
-	err.Message = fmt.Sprintf(format, args...)
-	return err
+// Unwrap 返回底层错误，使 errors.Is/errors.As 可以检查错误链.
+func (err *Errno) Unwrap() error {
+	return err.cause
+}
+
+// Is 按错误码判断是否为同一个错误，修改过 Message 等字段的副本与原错误相等.
+func (err *Errno) Is(target error) bool {
+	t, ok := target.(*Errno)
+	if !ok {
+		return false
+	}
+	return err.Code == t.Code
+}
+
+// WithMessage 返回 Message 被替换的副本.
+func (err *Errno) WithMessage(format string, args ...interface{}) *Errno {
+	e := *err
+	e.Message = fmt.Sprintf(format, args...)
+	e.format, e.args = format, args
+	return &e
+}
+
+// WithReason 返回 Reason 被替换的副本.
+func (err *Errno) WithReason(reason string) *Errno {
+	e := *err
+	e.Reason = reason
+	return &e
+}
+
+// WithData 返回 Data 被替换的副本.
+func (err *Errno) WithData(data interface{}) *Errno {
+	e := *err
+	e.Data = data
+	return &e
+}
+
+// WithCause 返回记录了底层错误的副本，底层错误不影响返回给调用方的 Message.
+func (err *Errno) WithCause(cause error) *Errno {
+	e := *err
+	e.cause = cause
+	return &e
 }
 
 // Decode 尝试从 err 中解析出业务错误码和错误信息.
@@ -33,10 +78,9 @@ func Decode(err error) (int, int, string) {
 		return OK.HTTP, OK.Code, OK.Message
 	}
 
-	switch typed := err.(type) {
-	case *Errno:
+	var typed *Errno
+	if errors.As(err, &typed) {
 		return typed.HTTP, typed.Code, typed.Message
-	default:
 	}
 
 	// 默认返回未知错误码和错误信息. 该错误代表服务端出错
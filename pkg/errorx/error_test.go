@@ -0,0 +1,89 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package errorx
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/clin211/miniblog-v3/pkg/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/status"
+)
+
+func TestWithDoesNotMutate(t *testing.T) {
+	e := ErrUserNotFound.WithMessage("用户 %s 不存在", "alice").WithReason("deleted").WithData(map[string]string{"id": "1"})
+
+	assert.Equal(t, "用户 alice 不存在", e.Message)
+	assert.Equal(t, "deleted", e.Reason)
+	assert.NotNil(t, e.Data)
+	assert.Equal(t, ErrUserNotFound.Code, e.Code)
+	assert.Equal(t, ErrUserNotFound.HTTP, e.HTTP)
+
+	assert.Equal(t, "User not found.", ErrUserNotFound.Message)
+	assert.Empty(t, ErrUserNotFound.Reason)
+	assert.Nil(t, ErrUserNotFound.Data)
+}
+
+func TestWithMessageConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			msg := fmt.Sprintf("message %d", i)
+			assert.Equal(t, msg, ErrInvalidParameter.WithMessage("%s", msg).Message)
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, "Parameter verification failed.", ErrInvalidParameter.Message)
+}
+
+func TestErrorsIsAndAs(t *testing.T) {
+	cause := errors.New("sql: no rows in result set")
+	err := fmt.Errorf("find user: %w", ErrUserNotFound.WithMessage("用户不存在").WithCause(cause))
+
+	assert.True(t, errors.Is(err, ErrUserNotFound))
+	assert.False(t, errors.Is(err, ErrUserDisabled))
+	assert.True(t, errors.Is(err, cause))
+
+	var e *Errno
+	assert.True(t, errors.As(err, &e))
+	assert.Equal(t, "用户不存在", e.Error())
+	assert.Equal(t, cause, errors.Unwrap(e))
+	assert.Nil(t, errors.Unwrap(ErrUserNotFound))
+
+	httpCode, code, msg := Decode(err)
+	assert.Equal(t, 404, httpCode)
+	assert.Equal(t, 404102, code)
+	assert.Equal(t, "用户不存在", msg)
+}
+
+func TestLocalize(t *testing.T) {
+	// 默认信息按错误码翻译
+	assert.Equal(t, "账户已被禁用", ErrUserDisabled.Localize(i18n.ZhCN).Message)
+	assert.Equal(t, "User is disabled.", ErrUserDisabled.Localize(i18n.EnUS).Message)
+
+	// 自定义信息按格式字符串翻译，保留参数
+	e := ErrInvalidParameter.WithMessage("单次上报的事件数不能超过%d", 100)
+	assert.Equal(t, "At most 100 events can be reported at once.", e.Localize(i18n.EnUS).Message)
+	assert.Equal(t, "单次上报的事件数不能超过100", e.Localize(i18n.ZhCN).Message)
+
+	// 没有译文时保留原信息
+	e = ErrInvalidParameter.WithMessage("没有译文的信息")
+	assert.Equal(t, "没有译文的信息", e.Localize(i18n.EnUS).Message)
+
+	// 经过 gRPC 传输后的自定义信息仍然可以翻译
+	st, ok := status.FromError(ToGRPCError(ErrPasswordIncorrect.WithMessage("用户名或密码错误")))
+	require.True(t, ok)
+	got := FromGRPCError(st.Err()).(*Errno)
+	assert.Equal(t, "Incorrect username or password.", got.Localize(i18n.EnUS).Message)
+
+	assert.Equal(t, "User is disabled.", ErrUserDisabled.Message)
+}
@@ -6,10 +6,56 @@
 package errorx
 
 import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// ErrorDomain errorx 错误在 gRPC ErrorInfo 中使用的 domain
+const ErrorDomain = "errorx"
+
+// ErrorInfo 的 Metadata 中保存 Errno 字段使用的键
+const (
+	metadataHTTP   = "http"
+	metadataCode   = "code"
+	metadataReason = "reason"
+	metadataData   = "data"
+	// metadataCustom 表示 Message 由 WithMessage 设置，不是错误码的默认信息
+	metadataCustom = "custom"
+)
+
+// GRPCStatus 将 Errno 转换为 gRPC 状态，完整的 Errno 作为 ErrorInfo 详情附加在状态中，
+// 调用方通过 FromGRPCError 无损还原. 底层错误不会传递给调用方
+func (err *Errno) GRPCStatus() *status.Status {
+	st := status.New(getGRPCCode(err.Code), err.Message)
+
+	info := &errdetails.ErrorInfo{
+		Reason: strconv.Itoa(err.Code),
+		Domain: ErrorDomain,
+		Metadata: map[string]string{
+			metadataHTTP:   strconv.Itoa(err.HTTP),
+			metadataCode:   strconv.Itoa(err.Code),
+			metadataReason: err.Reason,
+		},
+	}
+	if err.format != "" {
+		info.Metadata[metadataCustom] = "true"
+	}
+	if err.Data != nil {
+		if b, jerr := json.Marshal(err.Data); jerr == nil {
+			info.Metadata[metadataData] = string(b)
+		}
+	}
+	if ds, derr := st.WithDetails(info); derr == nil {
+		return ds
+	}
+	return st
+}
+
 // ToGRPCError 将 errorx 错误转换为 gRPC 错误
 func ToGRPCError(err error) error {
 	if err == nil {
@@ -17,49 +63,25 @@ func ToGRPCError(err error) error {
 	}
 
 	// 检查是否是 errorx 错误
-	if e, ok := err.(*Errno); ok {
-		return status.Error(getGRPCCode(e.Code), e.Message)
+	// Errno 实现了 GRPCStatus，直接返回以便服务端拦截器按请求的语言本地化
+	var e *Errno
+	if errors.As(err, &e) {
+		return e
+	}
+
+	// 自带 gRPC 状态的错误（如 validate.ValidationErrorsWithCode）原样返回，保留其中的详情
+	if _, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+		return err
 	}
 
 	// 如果不是 errorx 错误，返回内部错误
 	return status.Error(codes.Internal, err.Error())
 }
 
-// getGRPCCode 根据 errorx 错误码返回对应的 gRPC 错误码
+// getGRPCCode 根据 errorx 错误码返回对应的 gRPC 错误码，未注册的错误码根据前三位的 HTTP 状态码推断
 func getGRPCCode(errorxCode int) codes.Code {
-	switch errorxCode {
-	case 0:
-		return codes.OK
-	case 400001, 400002: // ErrBind, ErrInvalidParameter
-		return codes.InvalidArgument
-	case 401001, 401002, 401003, 401103: // ErrSignToken, ErrTokenInvalid, ErrUnauthorized, ErrPasswordIncorrect
-		return codes.Unauthenticated
-	case 403104: // ErrUserDisabled
-		return codes.PermissionDenied
-	case 404001, 404102: // ErrResourceNotFound, ErrUserNotFound
-		return codes.NotFound
-	case 409101: // ErrUserAlreadyExists
-		return codes.AlreadyExists
-	case 500001: // InternalServerError
-		return codes.Internal
-	default:
-		// 根据 HTTP 状态码推断 gRPC 错误码
-		httpCode := errorxCode / 1000
-		switch httpCode {
-		case 400:
-			return codes.InvalidArgument
-		case 401:
-			return codes.Unauthenticated
-		case 403:
-			return codes.PermissionDenied
-		case 404:
-			return codes.NotFound
-		case 409:
-			return codes.AlreadyExists
-		case 500:
-			return codes.Internal
-		default:
-			return codes.Unknown
-		}
+	if e, ok := Lookup(errorxCode); ok {
+		return grpcCodeFromHTTP(e.HTTP)
 	}
+	return grpcCodeFromHTTP(errorxCode / 1000)
 }
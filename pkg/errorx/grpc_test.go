@@ -47,7 +47,7 @@ func TestToGRPCError(t *testing.T) {
 			name:         "ErrUserNotFound",
 			err:          ErrUserNotFound,
 			expectedCode: codes.NotFound,
-			expectedMsg:  "用户不存在",
+			expectedMsg:  "User not found.",
 		},
 		{
 			name:         "ErrUserAlreadyExists",
@@ -69,7 +69,7 @@ func TestToGRPCError(t *testing.T) {
 		},
 		{
 			name:         "custom error with message",
-			err:          ErrUserNotFound.SetMessage("用户不存在"),
+			err:          ErrUserNotFound.WithMessage("用户不存在"),
 			expectedCode: codes.NotFound,
 			expectedMsg:  "用户不存在",
 		},
@@ -117,6 +117,7 @@ func TestGetGRPCCode(t *testing.T) {
 		{"ErrUserDisabled", 403104, codes.PermissionDenied},
 		{"ErrResourceNotFound", 404001, codes.NotFound},
 		{"ErrUserNotFound", 404102, codes.NotFound},
+		{"ErrConflict", 409001, codes.AlreadyExists},
 		{"ErrUserAlreadyExists", 409101, codes.AlreadyExists},
 		{"InternalServerError", 500001, codes.Internal},
 		{"Unknown 400", 400999, codes.InvalidArgument},
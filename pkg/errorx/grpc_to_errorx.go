@@ -6,41 +6,94 @@
 package errorx
 
 import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 // FromGRPCError 将 gRPC 错误转换为 errorx 错误
+// 错误由 ToGRPCError 产生时从 ErrorInfo 详情中还原原始的 Errno，否则根据 gRPC 错误码推断
 func FromGRPCError(err error) error {
 	if err == nil {
 		return nil
 	}
 
+	var e *Errno
+	if errors.As(err, &e) {
+		return e
+	}
+
 	// 检查是否是 gRPC 错误
 	st, ok := status.FromError(err)
 	if !ok {
 		// 如果不是 gRPC 错误，返回内部错误
-		return InternalServerError.SetMessage("%s", err.Error())
+		return InternalServerError.WithMessage("%s", err.Error()).WithCause(err)
+	}
+
+	if e, ok := FromGRPCStatus(st); ok {
+		return e
 	}
 
-	// 根据 gRPC 错误码转换为 errorx 错误
+	// 没有 errorx 详情（如来自其他服务或框架的错误），根据 gRPC 错误码转换为 errorx 错误
 	switch st.Code() {
 	case codes.OK:
 		return nil
 	case codes.InvalidArgument:
-		return ErrInvalidParameter.SetMessage("%s", st.Message())
+		return ErrInvalidParameter.WithMessage("%s", st.Message())
 	case codes.Unauthenticated:
-		return ErrUnauthorized.SetMessage("%s", st.Message())
+		return ErrUnauthorized.WithMessage("%s", st.Message())
 	case codes.PermissionDenied:
-		return ErrUserDisabled.SetMessage("%s", st.Message())
+		return ErrForbidden.WithMessage("%s", st.Message())
 	case codes.NotFound:
-		return ErrUserNotFound.SetMessage("%s", st.Message())
+		return ErrResourceNotFound.WithMessage("%s", st.Message())
 	case codes.AlreadyExists:
-		return ErrUserAlreadyExists.SetMessage("%s", st.Message())
+		return ErrConflict.WithMessage("%s", st.Message())
 	case codes.Internal:
-		return InternalServerError.SetMessage("%s", st.Message())
+		return InternalServerError.WithMessage("%s", st.Message())
 	default:
 		// 其他错误码返回内部错误
-		return InternalServerError.SetMessage("%s", st.Message())
+		return InternalServerError.WithMessage("%s", st.Message())
+	}
+}
+
+// FromGRPCStatus 从 gRPC 状态的 ErrorInfo 详情中还原 Errno，Data 还原为 JSON 解码后的值
+// 状态中没有 errorx 详情时返回 false
+func FromGRPCStatus(st *status.Status) (*Errno, bool) {
+	for _, d := range st.Details() {
+		info, ok := d.(*errdetails.ErrorInfo)
+		if !ok || info.Domain != ErrorDomain {
+			continue
+		}
+		code, err := strconv.Atoi(info.Metadata[metadataCode])
+		if err != nil {
+			continue
+		}
+		httpStatus, err := strconv.Atoi(info.Metadata[metadataHTTP])
+		if err != nil {
+			continue
+		}
+
+		e := &Errno{
+			HTTP:    httpStatus,
+			Code:    code,
+			Message: st.Message(),
+			Reason:  info.Metadata[metadataReason],
+		}
+		// 自定义消息已经格式化，以格式化后的消息作为本地化的键
+		if info.Metadata[metadataCustom] == "true" {
+			e.format = e.Message
+		}
+		if raw, ok := info.Metadata[metadataData]; ok {
+			var data interface{}
+			if json.Unmarshal([]byte(raw), &data) == nil {
+				e.Data = data
+			}
+		}
+		return e, true
 	}
+	return nil, false
 }
@@ -6,9 +6,11 @@
 package errorx
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -41,19 +43,19 @@ func TestFromGRPCError(t *testing.T) {
 		{
 			name:         "PermissionDenied error",
 			grpcError:    status.Error(codes.PermissionDenied, "权限不足"),
-			expectedCode: ErrUserDisabled.Code,
+			expectedCode: ErrForbidden.Code,
 			expectedMsg:  "权限不足",
 		},
 		{
 			name:         "NotFound error",
-			grpcError:    status.Error(codes.NotFound, "用户不存在"),
-			expectedCode: ErrUserNotFound.Code,
-			expectedMsg:  "用户不存在",
+			grpcError:    status.Error(codes.NotFound, "资源不存在"),
+			expectedCode: ErrResourceNotFound.Code,
+			expectedMsg:  "资源不存在",
 		},
 		{
 			name:         "AlreadyExists error",
 			grpcError:    status.Error(codes.AlreadyExists, "手机号已存在"),
-			expectedCode: ErrUserAlreadyExists.Code,
+			expectedCode: ErrConflict.Code,
 			expectedMsg:  "手机号已存在",
 		},
 		{
@@ -96,3 +98,42 @@ func TestFromGRPCError(t *testing.T) {
 		})
 	}
 }
+
+func TestGRPCRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *Errno
+	}{
+		{"predefined", ErrShortLinkForbidden},
+		{"custom message", ErrShortLinkNotFound.WithMessage("短链 %s 不存在", "abc")},
+		{"reason and data", ErrShortLinkAliasExists.WithReason("alias taken").WithData(map[string]interface{}{"alias": "abc"})},
+		{"unmapped code", &Errno{HTTP: 429, Code: 429301, Message: "Too many requests."}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 经过 gRPC 传输后只剩下状态
+			st, ok := status.FromError(ToGRPCError(tt.err))
+			require.True(t, ok)
+			wire := st.Err()
+
+			var got *Errno
+			require.True(t, errors.As(FromGRPCError(wire), &got))
+			assert.Equal(t, tt.err.HTTP, got.HTTP)
+			assert.Equal(t, tt.err.Code, got.Code)
+			assert.Equal(t, tt.err.Message, got.Message)
+			assert.Equal(t, tt.err.Reason, got.Reason)
+			assert.Equal(t, tt.err.Data, got.Data)
+			assert.True(t, errors.Is(got, tt.err))
+		})
+	}
+}
+
+func TestToGRPCErrorDropsCause(t *testing.T) {
+	cause := errors.New("dial tcp: connection refused")
+	st, ok := status.FromError(ToGRPCError(InternalServerError.WithCause(cause)))
+	require.True(t, ok)
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.NotContains(t, st.Message(), cause.Error())
+	assert.NotContains(t, st.String(), cause.Error())
+}
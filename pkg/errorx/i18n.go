@@ -0,0 +1,30 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package errorx
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/clin211/miniblog-v3/pkg/i18n"
+)
+
+// Localize 返回 Message 翻译为指定语言的副本.
+// 默认信息按错误码 errorx.<code> 查找；WithMessage 设置的信息以格式字符串为键查找译文，再用原参数格式化.
+// 目录中没有译文时保留原信息.
+func (err *Errno) Localize(locale i18n.Locale) *Errno {
+	e := *err
+	if err.format != "" {
+		if tmpl, ok := i18n.Lookup(locale, err.format); ok {
+			e.Message = fmt.Sprintf(tmpl, err.args...)
+		}
+		return &e
+	}
+	if msg, ok := i18n.Lookup(locale, "errorx."+strconv.Itoa(err.Code)); ok {
+		e.Message = msg
+	}
+	return &e
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package errorx
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Module 表示一个业务模块的错误码段，错误码的后三位必须落在 [Min, Max] 区间内.
+type Module struct {
+	Name string `json:"name"` // 模块名称
+	Min  int    `json:"min"`  // 码段下限（含）
+	Max  int    `json:"max"`  // 码段上限（含）
+}
+
+// Entry 为错误码目录中的一项.
+type Entry struct {
+	Module      string `json:"module"`      // 所属模块
+	Code        int    `json:"code"`        // 业务错误码
+	HTTP        int    `json:"http"`        // HTTP 状态码
+	GRPC        string `json:"grpc"`        // gRPC 状态码
+	Message     string `json:"message"`     // 默认错误信息
+	Description string `json:"description"` // 错误说明
+}
+
+var registry = struct {
+	sync.RWMutex
+	modules []*Module
+	entries map[int]Entry
+}{entries: make(map[int]Entry)}
+
+// 模块码段，新增模块时在这里声明，码段不能重叠
+var (
+	// ModuleCommon 通用错误，后三位区间为 0~99
+	ModuleCommon = RegisterModule("common", 0, 99)
+	// ModuleUser 用户模块，后三位区间为 100~199
+	ModuleUser = RegisterModule("user", 100, 199)
+	// ModuleShortLink 短链模块，后三位区间为 200~299
+	ModuleShortLink = RegisterModule("shortlink", 200, 299)
+	// ModuleBlog 博客模块，后三位区间为 300~399
+	ModuleBlog = RegisterModule("blog", 300, 399)
+)
+
+// RegisterModule 声明模块的错误码段，名称重复或码段与已有模块重叠时 panic.
+func RegisterModule(name string, min, max int) *Module {
+	if name == "" || min < 0 || max > 999 || min > max {
+		panic(fmt.Sprintf("errorx: invalid module %q [%d, %d]", name, min, max))
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	for _, m := range registry.modules {
+		if m.Name == name {
+			panic(fmt.Sprintf("errorx: module %q already registered", name))
+		}
+		if min <= m.Max && m.Min <= max {
+			panic(fmt.Sprintf("errorx: module %q [%d, %d] overlaps module %q [%d, %d]", name, min, max, m.Name, m.Min, m.Max))
+		}
+	}
+	m := &Module{Name: name, Min: min, Max: max}
+	registry.modules = append(registry.modules, m)
+	return m
+}
+
+// Register 在模块中声明错误码并返回该错误，通常用于初始化包级别的错误变量.
+// 错误码重复、后三位不在模块码段内或前三位与 HTTP 状态码不一致时 panic.
+func Register(m *Module, e *Errno, description string) *Errno {
+	if m == nil || e == nil {
+		panic("errorx: register nil module or errno")
+	}
+	// OK 的错误码为 0，不带 HTTP 前缀
+	if e.Code != 0 && e.Code/1000 != e.HTTP {
+		panic(fmt.Sprintf("errorx: code %d does not start with http status %d", e.Code, e.HTTP))
+	}
+	if seq := e.Code % 1000; seq < m.Min || seq > m.Max {
+		panic(fmt.Sprintf("errorx: code %d is outside module %q [%d, %d]", e.Code, m.Name, m.Min, m.Max))
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	if old, ok := registry.entries[e.Code]; ok {
+		panic(fmt.Sprintf("errorx: code %d already registered by module %q", e.Code, old.Module))
+	}
+	registry.entries[e.Code] = Entry{
+		Module:      m.Name,
+		Code:        e.Code,
+		HTTP:        e.HTTP,
+		GRPC:        grpcCodeFromHTTP(e.HTTP).String(),
+		Message:     e.Message,
+		Description: description,
+	}
+	return e
+}
+
+// Lookup 返回已注册的错误码.
+func Lookup(code int) (Entry, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	e, ok := registry.entries[code]
+	return e, ok
+}
+
+// Modules 返回已声明的模块，按码段排序.
+func Modules() []Module {
+	registry.RLock()
+	defer registry.RUnlock()
+	ms := make([]Module, 0, len(registry.modules))
+	for _, m := range registry.modules {
+		ms = append(ms, *m)
+	}
+	sort.Slice(ms, func(i, j int) bool { return ms[i].Min < ms[j].Min })
+	return ms
+}
+
+// Catalog 返回全部已注册的错误码，按模块码段和错误码排序.
+func Catalog() []Entry {
+	registry.RLock()
+	defer registry.RUnlock()
+	es := make([]Entry, 0, len(registry.entries))
+	for _, e := range registry.entries {
+		es = append(es, e)
+	}
+	sort.Slice(es, func(i, j int) bool {
+		si, sj := es[i].Code%1000, es[j].Code%1000
+		if si != sj {
+			return si < sj
+		}
+		return es[i].Code < es[j].Code
+	})
+	return es
+}
+
+// grpcCodeFromHTTP 根据 HTTP 状态码推断 gRPC 错误码
+func grpcCodeFromHTTP(status int) codes.Code {
+	switch status {
+	case http.StatusOK:
+		return codes.OK
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusInternalServerError:
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package errorx
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/clin211/miniblog-v3/pkg/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	e, ok := Lookup(ErrUserNotFound.Code)
+	assert.True(t, ok)
+	assert.Equal(t, "user", e.Module)
+	assert.Equal(t, "NotFound", e.GRPC)
+	assert.Equal(t, "用户不存在", e.Description)
+
+	// 码段重叠或重名
+	assert.Panics(t, func() { RegisterModule("user", 900, 999) })
+	assert.Panics(t, func() { RegisterModule("comment", 150, 250) })
+	assert.Panics(t, func() { RegisterModule("bad", 10, 1000) })
+
+	// 重复注册
+	assert.Panics(t, func() {
+		Register(ModuleUser, &Errno{HTTP: http.StatusNotFound, Code: ErrUserNotFound.Code}, "")
+	})
+	// 不在模块码段内
+	assert.Panics(t, func() {
+		Register(ModuleUser, &Errno{HTTP: http.StatusNotFound, Code: 404201}, "")
+	})
+	// 前三位与 HTTP 状态码不一致
+	assert.Panics(t, func() {
+		Register(ModuleBlog, &Errno{HTTP: http.StatusNotFound, Code: 400301}, "")
+	})
+
+	catalog := Catalog()
+	assert.Len(t, catalog, len(registry.entries))
+	for i := 1; i < len(catalog); i++ {
+		assert.LessOrEqual(t, catalog[i-1].Code%1000, catalog[i].Code%1000)
+	}
+}
+
+func TestCatalogLocalized(t *testing.T) {
+	// 每个注册的错误码在每种语言中都有默认信息
+	for _, e := range Catalog() {
+		for _, l := range i18n.Supported() {
+			_, ok := i18n.Lookup(l, "errorx."+strconv.Itoa(e.Code))
+			assert.True(t, ok, "%s: errorx.%d", l, e.Code)
+		}
+	}
+}
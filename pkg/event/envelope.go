@@ -0,0 +1,100 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package event
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// SpecVersion 信封结构的版本，信封增加字段不改变版本，删除或修改字段时递增主版本
+	SpecVersion = "1.0"
+
+	// HeaderID 消息头中的事件 ID
+	HeaderID = "event-id"
+	// HeaderType 消息头中的事件类型，消费方可以不解析消息体直接过滤
+	HeaderType = "event-type"
+)
+
+var (
+	// ErrInvalidEnvelope 表示消息不是合法的事件信封
+	ErrInvalidEnvelope = errors.New("event: invalid envelope")
+	// ErrUnsupportedSpec 表示信封版本不受支持
+	ErrUnsupportedSpec = errors.New("event: unsupported spec version")
+)
+
+// Envelope 为所有服务发布到 Kafka 的事件的统一信封.
+// 投递语义为至少一次，消费方需要按 ID 去重；同一 Subject 的事件写入同一分区，按发生顺序消费.
+type Envelope struct {
+	SpecVersion string          `json:"specversion"` // 信封结构版本
+	ID          string          `json:"id"`          // 事件唯一 ID
+	Type        string          `json:"type"`        // 事件类型，如 user.registered
+	Version     int             `json:"version"`     // Data 的结构版本，同一类型的 Data 不兼容修改时递增
+	Source      string          `json:"source"`      // 产生事件的服务
+	Subject     string          `json:"subject"`     // 事件主体的 ID，同时作为消息键
+	Time        time.Time       `json:"time"`        // 事件发生时间，UTC
+	Data        json.RawMessage `json:"data"`        // 事件内容
+}
+
+// New 创建事件信封，data 会被编码为 JSON.
+func New(typ string, version int, source, subject string, data any) (*Envelope, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("event: marshal data: %w", err)
+	}
+	return &Envelope{
+		SpecVersion: SpecVersion,
+		ID:          newID(),
+		Type:        typ,
+		Version:     version,
+		Source:      source,
+		Subject:     subject,
+		Time:        time.Now().UTC(),
+		Data:        raw,
+	}, nil
+}
+
+// Parse 解析事件信封，只接受主版本相同的信封.
+func Parse(b []byte) (*Envelope, error) {
+	var e Envelope
+	if err := json.Unmarshal(b, &e); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEnvelope, err)
+	}
+	if e.ID == "" || e.Type == "" {
+		return nil, fmt.Errorf("%w: missing id or type", ErrInvalidEnvelope)
+	}
+	if major(e.SpecVersion) != major(SpecVersion) {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedSpec, e.SpecVersion)
+	}
+	return &e, nil
+}
+
+// Decode 将事件内容解码到 v，调用方应先根据 Type 和 Version 选择 v 的类型.
+func (e *Envelope) Decode(v any) error {
+	return json.Unmarshal(e.Data, v)
+}
+
+// major 返回版本号的主版本部分.
+func major(version string) string {
+	for i := 0; i < len(version); i++ {
+		if version[i] == '.' {
+			return version[:i]
+		}
+	}
+	return version
+}
+
+// newID 生成 128 位随机事件 ID.
+func newID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package event
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvelope(t *testing.T) {
+	e, err := New(UserRegistered, UserEventVersion, "user-rpc", "mu-abc", &UserRegisteredData{UserId: "mu-abc", Username: "alice"})
+	require.NoError(t, err)
+	assert.Len(t, e.ID, 32)
+
+	b, err := json.Marshal(e)
+	require.NoError(t, err)
+	got, err := Parse(b)
+	require.NoError(t, err)
+	assert.Equal(t, e.ID, got.ID)
+	assert.Equal(t, UserRegistered, got.Type)
+	assert.Equal(t, 1, got.Version)
+	assert.True(t, e.Time.Equal(got.Time))
+
+	var data UserRegisteredData
+	require.NoError(t, got.Decode(&data))
+	assert.Equal(t, "alice", data.Username)
+
+	// 次版本升级和未知字段不影响解析
+	_, err = Parse([]byte(`{"specversion":"1.1","id":"1","type":"user.deleted","version":1,"extra":true,"data":{}}`))
+	assert.NoError(t, err)
+	_, err = Parse([]byte(`{"specversion":"2.0","id":"1","type":"user.deleted","version":1,"data":{}}`))
+	assert.ErrorIs(t, err, ErrUnsupportedSpec)
+	_, err = Parse([]byte(`{"specversion":"1.0"}`))
+	assert.ErrorIs(t, err, ErrInvalidEnvelope)
+	_, err = Parse([]byte(`not json`))
+	assert.ErrorIs(t, err, ErrInvalidEnvelope)
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package event
+
+// 用户生命周期事件，由用户服务通过 outbox 发布，消息键为用户 ID
+const (
+	// UserRegistered 用户注册
+	UserRegistered = "user.registered"
+	// UserUpdated 用户信息更新
+	UserUpdated = "user.updated"
+	// UserDeleted 用户删除
+	UserDeleted = "user.deleted"
+	// UserPasswordChanged 用户修改密码
+	UserPasswordChanged = "user.password_changed"
+	// UserLoggedIn 用户登录
+	UserLoggedIn = "user.logged_in"
+
+	// UserEventVersion 用户事件 Data 的当前版本
+	UserEventVersion = 1
+)
+
+// UserRegisteredData 为 user.registered 事件的内容.
+type UserRegisteredData struct {
+	UserId         string `json:"userId"`         // 用户ID
+	Username       string `json:"username"`       // 用户名
+	Avatar         string `json:"avatar"`         // 头像URL
+	RegisterSource int64  `json:"registerSource"` // 注册来源
+}
+
+// UserUpdatedData 为 user.updated 事件的内容，包含更新后的全部可修改字段.
+type UserUpdatedData struct {
+	UserId   string `json:"userId"`   // 用户ID
+	Username string `json:"username"` // 用户名
+	Age      int64  `json:"age"`      // 年龄
+	Gender   int64  `json:"gender"`   // 性别
+	Avatar   string `json:"avatar"`   // 头像URL
+}
+
+// UserDeletedData 为 user.deleted 事件的内容.
+type UserDeletedData struct {
+	UserId string `json:"userId"` // 用户ID
+}
+
+// UserPasswordChangedData 为 user.password_changed 事件的内容，消费方可以据此使会话失效.
+type UserPasswordChangedData struct {
+	UserId string `json:"userId"` // 用户ID
+}
+
+// UserLoggedInData 为 user.logged_in 事件的内容.
+type UserLoggedInData struct {
+	UserId string `json:"userId"` // 用户ID
+	IP     string `json:"ip"`     // 登录IP
+}
@@ -0,0 +1,29 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+// Package geo 提供 IP 地理位置查询，查询实现通过 Locator 接口注入，
+// 内置不做任何查询的 Nop 实现和基于离线 IP 库的 Offline 实现.
+package geo
+
+import "net/netip"
+
+// Location 为 IP 所在的地理位置.
+type Location struct {
+	Country string // 国家或地区代码，ISO 3166-1 alpha-2，例如 CN、US
+}
+
+// Locator 根据 IP 查询地理位置，未查到时 ok 为 false.
+// 实现必须是并发安全的.
+type Locator interface {
+	Lookup(addr netip.Addr) (loc Location, ok bool)
+}
+
+// Nop 为不做任何查询的 Locator，未配置离线 IP 库时使用.
+type Nop struct{}
+
+// Lookup 总是返回未查到.
+func (Nop) Lookup(netip.Addr) (Location, bool) {
+	return Location{}, false
+}
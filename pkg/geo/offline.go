@@ -0,0 +1,117 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package geo
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ipRange 为离线 IP 库中的一条记录，start 和 end 均为闭区间.
+type ipRange struct {
+	start   netip.Addr
+	end     netip.Addr
+	country string
+}
+
+// Offline 基于离线 IP 库的 Locator，全部记录加载到内存中按起始地址排序，查询使用二分查找.
+//
+// 离线库为 CSV 格式，每行为 "起始IP,结束IP,国家代码"，与 DB-IP 的 IP to Country Lite 数据格式一致，
+// IPv4 和 IPv6 记录可以混合出现.
+type Offline struct {
+	ranges []ipRange
+}
+
+var _ Locator = (*Offline)(nil)
+
+// NewOffline 从文件加载离线 IP 库.
+func NewOffline(path string) (*Offline, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return LoadOffline(f)
+}
+
+// LoadOffline 从 r 中读取 CSV 格式的离线 IP 库.
+func LoadOffline(r io.Reader) (*Offline, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+
+	var ranges []ipRange
+	for line := 1; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 3 {
+			return nil, fmt.Errorf("geo: line %d: expected at least 3 fields, got %d", line, len(record))
+		}
+
+		start, err := netip.ParseAddr(strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("geo: line %d: %w", line, err)
+		}
+		end, err := netip.ParseAddr(strings.TrimSpace(record[1]))
+		if err != nil {
+			return nil, fmt.Errorf("geo: line %d: %w", line, err)
+		}
+		start, end = start.Unmap(), end.Unmap()
+		if start.Is4() != end.Is4() || end.Less(start) {
+			return nil, fmt.Errorf("geo: line %d: invalid range %s-%s", line, start, end)
+		}
+
+		ranges = append(ranges, ipRange{
+			start:   start,
+			end:     end,
+			country: strings.ToUpper(strings.TrimSpace(record[2])),
+		})
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].start.Less(ranges[j].start)
+	})
+	return &Offline{ranges: ranges}, nil
+}
+
+// Lookup 查询 IP 所在的国家或地区.
+func (o *Offline) Lookup(addr netip.Addr) (Location, bool) {
+	if !addr.IsValid() {
+		return Location{}, false
+	}
+	addr = addr.Unmap()
+
+	// 找到最后一条起始地址不大于 addr 的记录
+	i := sort.Search(len(o.ranges), func(i int) bool {
+		return addr.Less(o.ranges[i].start)
+	}) - 1
+	if i < 0 {
+		return Location{}, false
+	}
+
+	r := o.ranges[i]
+	if r.start.Is4() != addr.Is4() || r.end.Less(addr) || r.country == "" {
+		return Location{}, false
+	}
+	return Location{Country: r.country}, true
+}
+
+// Len 返回离线库中的记录数.
+func (o *Offline) Len() int {
+	return len(o.ranges)
+}
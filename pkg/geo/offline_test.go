@@ -0,0 +1,69 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package geo
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDB = `2001:db8::,2001:db8:ffff:ffff:ffff:ffff:ffff:ffff,de
+1.0.1.0,1.0.3.255,cn
+8.8.8.0,8.8.8.255,US
+1.0.0.0,1.0.0.255,AU
+`
+
+func TestOffline_Lookup(t *testing.T) {
+	o, err := LoadOffline(strings.NewReader(testDB))
+	require.NoError(t, err)
+	assert.Equal(t, 4, o.Len())
+
+	tests := []struct {
+		ip      string
+		country string
+		ok      bool
+	}{
+		{ip: "1.0.0.1", country: "AU", ok: true},
+		{ip: "1.0.2.100", country: "CN", ok: true},
+		{ip: "1.0.3.255", country: "CN", ok: true},
+		{ip: "1.0.4.0", ok: false},
+		{ip: "8.8.8.8", country: "US", ok: true},
+		{ip: "::ffff:8.8.8.8", country: "US", ok: true},
+		{ip: "0.0.0.1", ok: false},
+		{ip: "2001:db8::1", country: "DE", ok: true},
+		{ip: "2001:db9::1", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			loc, ok := o.Lookup(netip.MustParseAddr(tt.ip))
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.country, loc.Country)
+		})
+	}
+
+	_, ok := o.Lookup(netip.Addr{})
+	assert.False(t, ok)
+}
+
+func TestLoadOffline_Invalid(t *testing.T) {
+	_, err := LoadOffline(strings.NewReader("1.0.0.0,CN\n"))
+	assert.Error(t, err)
+
+	_, err = LoadOffline(strings.NewReader("1.0.0.255,1.0.0.0,CN\n"))
+	assert.Error(t, err)
+
+	_, err = LoadOffline(strings.NewReader("1.0.0.0,::1,CN\n"))
+	assert.Error(t, err)
+}
+
+func TestNop(t *testing.T) {
+	_, ok := Nop{}.Lookup(netip.MustParseAddr("8.8.8.8"))
+	assert.False(t, ok)
+}
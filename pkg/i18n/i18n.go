@@ -0,0 +1,204 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+// Package i18n 提供多语言消息目录和语言协商.
+//
+// 消息目录为扁平的键值对，内置目录位于 locales 目录，按语言一个 JSON 文件.
+// 约定的键：
+//   - errorx.<code>：errorx 错误码的默认信息
+//   - validate.<ErrorCode>[.<rule>]：验证错误信息模板，模板中的 {name} 为参数占位符
+//   - 业务代码中 WithMessage 使用的中文原文：对应的译文，可以包含 fmt 格式化动词
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Locale 为语言标签，如 zh-CN.
+type Locale string
+
+const (
+	// ZhCN 简体中文
+	ZhCN Locale = "zh-CN"
+	// EnUS 英文
+	EnUS Locale = "en-US"
+
+	// DefaultLocale 请求没有指定语言或指定的语言都不支持时使用的语言
+	DefaultLocale = ZhCN
+)
+
+// supported 支持的语言，按优先级排列
+var supported = []Locale{ZhCN, EnUS}
+
+//go:embed locales/*.json
+var locales embed.FS
+
+var catalog = struct {
+	sync.RWMutex
+	messages map[Locale]map[string]string
+}{messages: make(map[Locale]map[string]string)}
+
+func init() {
+	for _, l := range supported {
+		b, err := locales.ReadFile(path.Join("locales", string(l)+".json"))
+		if err != nil {
+			panic(err)
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(b, &messages); err != nil {
+			panic("i18n: invalid catalog " + string(l) + ": " + err.Error())
+		}
+		Register(l, messages)
+	}
+}
+
+// Supported 返回支持的语言.
+func Supported() []Locale {
+	return append([]Locale(nil), supported...)
+}
+
+// Register 向语言的消息目录中添加消息，已有的键会被覆盖. 服务可以在 init 中注册自己的消息.
+func Register(locale Locale, messages map[string]string) {
+	catalog.Lock()
+	defer catalog.Unlock()
+	m, ok := catalog.messages[locale]
+	if !ok {
+		m = make(map[string]string, len(messages))
+		catalog.messages[locale] = m
+	}
+	for k, v := range messages {
+		m[k] = v
+	}
+}
+
+// Lookup 返回语言中键对应的消息.
+func Lookup(locale Locale, key string) (string, bool) {
+	catalog.RLock()
+	defer catalog.RUnlock()
+	msg, ok := catalog.messages[locale][key]
+	return msg, ok
+}
+
+// Translate 返回用 params 替换了占位符的消息. 键不存在或模板中的占位符没有对应参数时返回 false.
+func Translate(locale Locale, key string, params map[string]string) (string, bool) {
+	tmpl, ok := Lookup(locale, key)
+	if !ok {
+		return "", false
+	}
+	return render(tmpl, params)
+}
+
+// render 将模板中的 {name} 替换为参数值.
+func render(tmpl string, params map[string]string) (string, bool) {
+	if !strings.Contains(tmpl, "{") {
+		return tmpl, true
+	}
+
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(tmpl, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(tmpl[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+		v, ok := params[tmpl[start+1:end]]
+		if !ok {
+			return "", false
+		}
+		b.WriteString(tmpl[:start])
+		b.WriteString(v)
+		tmpl = tmpl[end+1:]
+	}
+	b.WriteString(tmpl)
+	return b.String(), true
+}
+
+// Parse 将语言标签转换为支持的语言，只匹配主语言，如 en、en-GB 都转换为 en-US.
+func Parse(tag string) (Locale, bool) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return "", false
+	}
+	base, _, _ := strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-")
+	for _, l := range supported {
+		if strings.EqualFold(string(l), tag) {
+			return l, true
+		}
+	}
+	for _, l := range supported {
+		lbase, _, _ := strings.Cut(string(l), "-")
+		if strings.EqualFold(lbase, base) {
+			return l, true
+		}
+	}
+	return "", false
+}
+
+// Negotiate 根据 Accept-Language 头选择语言，按权重从高到低选择第一个支持的语言.
+func Negotiate(acceptLanguage string) Locale {
+	best, bestQ := DefaultLocale, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, q := parseWeighted(part)
+		if q <= bestQ {
+			continue
+		}
+		if tag == "*" {
+			best, bestQ = DefaultLocale, q
+			continue
+		}
+		if l, ok := Parse(tag); ok {
+			best, bestQ = l, q
+		}
+	}
+	return best
+}
+
+// parseWeighted 解析 Accept-Language 中的一项，如 en-US;q=0.8.
+func parseWeighted(s string) (string, float64) {
+	tag, params, _ := strings.Cut(s, ";")
+	q := 1.0
+	for _, p := range strings.Split(params, ";") {
+		k, v, ok := strings.Cut(strings.TrimSpace(p), "=")
+		if !ok || strings.TrimSpace(k) != "q" {
+			continue
+		}
+		q = parseQ(strings.TrimSpace(v))
+	}
+	return strings.TrimSpace(tag), q
+}
+
+// parseQ 解析权重，格式不正确或超出 [0, 1] 时视为 0.
+func parseQ(s string) float64 {
+	q, err := strconv.ParseFloat(s, 64)
+	if err != nil || q < 0 || q > 1 {
+		return 0
+	}
+	return q
+}
+
+type localeKey struct{}
+
+// WithLocale 返回带有语言的上下文.
+func WithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// FromContext 返回上下文中的语言，没有时返回 DefaultLocale.
+func FromContext(ctx context.Context) Locale {
+	if l, ok := ctx.Value(localeKey{}).(Locale); ok {
+		return l
+	}
+	return DefaultLocale
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		want   Locale
+	}{
+		{"", ZhCN},
+		{"en-US", EnUS},
+		{"en", EnUS},
+		{"en-GB,en;q=0.9", EnUS},
+		{"zh-TW", ZhCN},
+		{"fr-FR, en;q=0.5", EnUS},
+		{"zh;q=0.3, en;q=0.8", EnUS},
+		{"en;q=0.2, *;q=0.5", ZhCN},
+		{"en;q=0, zh;q=0.1", ZhCN},
+		{"fr, de", ZhCN},
+		{"en;q=abc", ZhCN},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Negotiate(tt.header), tt.header)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	msg, ok := Translate(EnUS, "validate.INVALID_RANGE", map[string]string{"min": "1", "max": "120"})
+	assert.True(t, ok)
+	assert.Equal(t, "must be between 1 and 120", msg)
+
+	// 缺少参数
+	_, ok = Translate(EnUS, "validate.INVALID_RANGE", map[string]string{"min": "1"})
+	assert.False(t, ok)
+	_, ok = Translate(EnUS, "no.such.key", nil)
+	assert.False(t, ok)
+
+	Register(EnUS, map[string]string{"test.greeting": "hello {name}"})
+	msg, _ = Translate(EnUS, "test.greeting", map[string]string{"name": "alice"})
+	assert.Equal(t, "hello alice", msg)
+}
+
+func TestCatalogs(t *testing.T) {
+	// 中文目录中的键在英文目录中都有译文，占位符一致
+	for key, zh := range catalog.messages[ZhCN] {
+		en, ok := Lookup(EnUS, key)
+		if assert.True(t, ok, key) {
+			assert.Equal(t, strings.Count(zh, "{"), strings.Count(en, "{"), key)
+		}
+	}
+}
+
+func TestCatalogKeys(t *testing.T) {
+	// 错误码和校验规则的键在每种语言中都要有消息；以中文原文为键的消息只需要英文译文
+	keys := func(l Locale) map[string]bool {
+		b, err := locales.ReadFile(path.Join("locales", string(l)+".json"))
+		require.NoError(t, err)
+		var messages map[string]string
+		require.NoError(t, json.Unmarshal(b, &messages))
+		set := make(map[string]bool, len(messages))
+		for k := range messages {
+			if strings.HasPrefix(k, "errorx.") || strings.HasPrefix(k, "validate.") {
+				set[k] = true
+			}
+		}
+		return set
+	}
+	assert.Equal(t, keys(ZhCN), keys(EnUS))
+}
+
+func TestContext(t *testing.T) {
+	assert.Equal(t, DefaultLocale, FromContext(context.Background()))
+	assert.Equal(t, EnUS, FromContext(WithLocale(context.Background(), EnUS)))
+}
@@ -7,6 +7,18 @@ package id
 
 // Package id 提供了两种 ID 生成方案：基于 Sony 的分布式 ID 生成器 Sonyflake 和基于编码的字符串 ID 生成器
 
+import (
+	"errors"
+	"math/bits"
+)
+
+var (
+	// ErrInvalidCode 表示编码的长度或字符与配置不符
+	ErrInvalidCode = errors.New("id: invalid code")
+	// ErrCodeNotReversible 表示配置生成的编码会截断 ID，无法还原
+	ErrCodeNotReversible = errors.New("id: code options are not reversible")
+)
+
 // NewCode 根据数字 ID 生成一个唯一的字符串编码
 // 参数:
 //   - id: 输入的数字 ID，通常由 Sonyflake.Id() 生成
@@ -20,6 +32,11 @@ package id
 //  2. 使用扩散算法让每一位数字相互影响，增加编码复杂度
 //  3. 使用混淆算法（排列盒）重新排列编码，进一步提高安全性
 //
+// 备注:
+//   - 扩散和混淆使用 int 运算。早期版本使用 byte 运算，i*slIdx[0] 或 i*n2 超过 255 时会回绕，
+//     因此编码更长、字符集更大的配置（例如 30 个字符、14 位）生成的编码与早期版本不同；
+//     未回绕的配置（包括默认的 8 位编码和旧版 rid 的 6 位编码）输出保持不变
+//
 // 使用示例:
 //
 //	id := sf.Id(context.Background())
@@ -33,22 +50,123 @@ func NewCode(id uint64, options ...func(*CodeOptions)) string {
 	id = id*uint64(ops.n1) + ops.salt
 
 	code := make([]rune, 0, ops.l) // 预分配容量以提高性能
-	slIdx := make([]byte, ops.l)
+	slIdx := make([]int, ops.l)
 
 	charLen := len(ops.chars)
 	charLenUI := uint64(charLen)
 
 	// 扩散
 	for i := range ops.l {
-		slIdx[i] = byte(id % charLenUI)                          // 获取每个数字
-		slIdx[i] = (slIdx[i] + byte(i)*slIdx[0]) % byte(charLen) // 让个位数影响其他位
-		id /= charLenUI                                          // 右移
+		slIdx[i] = int(id % charLenUI)               // 获取每个数字
+		slIdx[i] = (slIdx[i] + i*slIdx[0]) % charLen // 让个位数影响其他位
+		id /= charLenUI                              // 右移
 	}
 
 	// 混淆（https://en.wikipedia.org/wiki/Permutation_box）
 	for i := range ops.l {
-		idx := (byte(i) * byte(ops.n2)) % byte(ops.l)
+		idx := (i * ops.n2) % ops.l
 		code = append(code, ops.chars[slIdx[idx]])
 	}
 	return string(code)
 }
+
+// DecodeCode 将 NewCode 生成的编码还原为数字 ID，options 必须与生成编码时一致
+// 参数:
+//   - code: NewCode 生成的编码
+//   - options: 配置函数列表，须与 NewCode 使用的配置相同
+//
+// 返回值:
+//   - 编码前的数字 ID
+//   - 编码长度或字符不合法时返回 ErrInvalidCode，配置不可逆时返回 ErrCodeNotReversible
+//
+// 备注:
+//   - 只有 len(chars)^l >= 2^64、n1 为奇数且 n2 与 l 互质时编码才可逆，
+//     例如默认的 30 个字符至少需要 14 位，36 个字符至少需要 13 位
+//   - 可逆的配置下任何合法字符组成的编码都能解出一个 ID，调用方需要自行校验 ID 是否存在
+//
+// 使用示例:
+//
+//	num, err := id.DecodeCode(code, id.WithCodeL(14))
+func DecodeCode(code string, options ...func(*CodeOptions)) (uint64, error) {
+	ops := getCodeOptionsOrSetDefault(nil)
+	for _, f := range options {
+		f(ops)
+	}
+	if !ops.reversible() {
+		return 0, ErrCodeNotReversible
+	}
+
+	runes := []rune(code)
+	if len(runes) != ops.l {
+		return 0, ErrInvalidCode
+	}
+	index := make(map[rune]int, len(ops.chars))
+	for i, c := range ops.chars {
+		index[c] = i
+	}
+
+	charLen := len(ops.chars)
+	charLenUI := uint64(charLen)
+
+	// 逆混淆：第 i 个字符来自第 (i*n2)%l 位
+	slIdx := make([]int, ops.l)
+	for i, c := range runes {
+		v, ok := index[c]
+		if !ok {
+			return 0, ErrInvalidCode
+		}
+		slIdx[(i*ops.n2)%ops.l] = v
+	}
+
+	// 逆扩散并按高位到低位还原扩大加盐后的值，超出 uint64 范围的编码不合法
+	var id uint64
+	for i := ops.l - 1; i >= 0; i-- {
+		digit := ((slIdx[i]-i*slIdx[0])%charLen + charLen) % charLen
+		hi, lo := bits.Mul64(id, charLenUI)
+		lo, carry := bits.Add64(lo, uint64(digit), 0)
+		if hi != 0 || carry != 0 {
+			return 0, ErrInvalidCode
+		}
+		id = lo
+	}
+
+	// 去掉盐值并缩小，n1 为奇数时在模 2^64 下存在逆元
+	return (id - ops.salt) * inverse(uint64(ops.n1)), nil
+}
+
+// reversible 判断当前配置生成的编码能否还原为数字 ID.
+func (ops *CodeOptions) reversible() bool {
+	if ops.n1%2 == 0 || ops.l <= 0 || gcd(ops.n2, ops.l) != 1 {
+		return false
+	}
+	// len(chars)^l >= 2^64 时编码才不会截断 ID 的高位
+	capacity := uint64(1)
+	for range ops.l {
+		hi, lo := bits.Mul64(capacity, uint64(len(ops.chars)))
+		if hi != 0 {
+			return true
+		}
+		capacity = lo
+	}
+	return false
+}
+
+// inverse 使用牛顿迭代计算奇数 n 在模 2^64 下的乘法逆元.
+func inverse(n uint64) uint64 {
+	x := n // n*n ≡ 1 (mod 8)，每次迭代精度翻倍
+	for range 5 {
+		x *= 2 - n*x
+	}
+	return x
+}
+
+// gcd 计算最大公约数.
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a < 0 {
+		return -a
+	}
+	return a
+}
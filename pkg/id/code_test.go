@@ -0,0 +1,129 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package id
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCodeCompatible(t *testing.T) {
+	// 改用 int 运算后，未溢出的配置生成的编码保持不变
+	assert.Equal(t, "VHB4JX86", NewCode(1))
+	assert.Equal(t, "57DYVKWB", NewCode(473829104562))
+	assert.Equal(t, "b8sccs", NewCode(617283947561283746,
+		WithCodeChars([]rune("abcdefghijklmnopqrstuvwxyz1234567890")), WithCodeL(6), WithCodeSalt(99)))
+}
+
+// byteNewCode 是改用 int 运算前的 NewCode 实现，用于固定未回绕配置下的编码输出.
+func byteNewCode(id uint64, options ...func(*CodeOptions)) string {
+	ops := getCodeOptionsOrSetDefault(nil)
+	for _, f := range options {
+		f(ops)
+	}
+	id = id*uint64(ops.n1) + ops.salt
+
+	code := make([]rune, 0, ops.l)
+	slIdx := make([]byte, ops.l)
+	charLen := len(ops.chars)
+	charLenUI := uint64(charLen)
+	for i := range ops.l {
+		slIdx[i] = byte(id % charLenUI)
+		slIdx[i] = (slIdx[i] + byte(i)*slIdx[0]) % byte(charLen)
+		id /= charLenUI
+	}
+	for i := range ops.l {
+		idx := (byte(i) * byte(ops.n2)) % byte(ops.l)
+		code = append(code, ops.chars[slIdx[idx]])
+	}
+	return string(code)
+}
+
+func TestNewCodeMatchesByteEncoding(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []func(*CodeOptions)
+	}{
+		{"default", nil},
+		{"legacy rid", []func(*CodeOptions){
+			WithCodeChars([]rune("abcdefghijklmnopqrstuvwxyz1234567890")),
+			WithCodeL(6),
+			WithCodeSalt(123456789),
+		}},
+	}
+	ids := []uint64{0, 1, 42, 1 << 40, 617283947561283746, math.MaxUint64}
+	for n := uint64(1); n < 1<<63; n = n*7 + 3 {
+		ids = append(ids, n)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, num := range ids {
+				assert.Equal(t, byteNewCode(num, tt.options...), NewCode(num, tt.options...), "id %d", num)
+			}
+		})
+	}
+}
+
+func TestDecodeCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []func(*CodeOptions)
+	}{
+		{"default chars", []func(*CodeOptions){WithCodeL(14)}},
+		{"custom chars", []func(*CodeOptions){
+			WithCodeChars([]rune("abcdefghijklmnopqrstuvwxyz1234567890")),
+			WithCodeL(13),
+			WithCodeSalt(987654321),
+		}},
+	}
+	ids := []uint64{0, 1, 42, 1 << 40, 617283947561283746, math.MaxUint64}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, want := range ids {
+				code := NewCode(want, tt.options...)
+				got, err := DecodeCode(code, tt.options...)
+				require.NoError(t, err)
+				assert.Equal(t, want, got, "code %s", code)
+			}
+		})
+	}
+}
+
+func TestDecodeCodeErrors(t *testing.T) {
+	// 默认 8 位编码会截断 ID
+	_, err := DecodeCode("VHB4JX86")
+	assert.ErrorIs(t, err, ErrCodeNotReversible)
+	_, err = DecodeCode("VHB4JX8623456A", WithCodeL(14), WithCodeN1(16))
+	assert.ErrorIs(t, err, ErrCodeNotReversible)
+	_, err = DecodeCode("VHB4JX8623456A", WithCodeL(14), WithCodeN2(7))
+	assert.ErrorIs(t, err, ErrCodeNotReversible)
+
+	code := NewCode(42, WithCodeL(14))
+	_, err = DecodeCode(code[:13], WithCodeL(14))
+	assert.ErrorIs(t, err, ErrInvalidCode)
+	_, err = DecodeCode(code[:13]+"0", WithCodeL(14))
+	assert.ErrorIs(t, err, ErrInvalidCode)
+	// 最高位过大，超出 uint64 范围
+	_, err = DecodeCode("YYYYYYYYYYYYYY", WithCodeL(14))
+	assert.ErrorIs(t, err, ErrInvalidCode)
+}
+
+func TestDecomposeSonyflake(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sf := NewSonyflake(WithSonyflakeMachineId(7), WithSonyflakeStartTime(start))
+	require.NoError(t, sf.Error)
+
+	before := time.Now()
+	num, err := sf.Id(t.Context())
+	require.NoError(t, err)
+	parts := DecomposeSonyflake(num, WithSonyflakeStartTime(start))
+	assert.Equal(t, uint16(7), parts.MachineID)
+	assert.WithinDuration(t, before, parts.Time, 100*time.Millisecond)
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package id
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/proc"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const (
+	// ModeStatic 使用配置中固定的机器 ID，仅适用于单实例部署
+	ModeStatic = "static"
+	// ModeEtcd 从 etcd 租用机器 ID
+	ModeEtcd = "etcd"
+	// ModeRedis 从 Redis 租用机器 ID
+	ModeRedis = "redis"
+
+	// leaseTimeout 启动时租用机器 ID 的超时时间
+	leaseTimeout = 10 * time.Second
+)
+
+// defaultSonyflake 进程级的 Sonyflake，由服务启动时通过 SetDefault 设置
+var defaultSonyflake atomic.Pointer[Sonyflake]
+
+// SonyflakeConf 定义了 Sonyflake 的配置，多实例部署时必须使用 etcd 或 redis 模式保证机器 ID 唯一
+type SonyflakeConf struct {
+	Mode             string        `json:",default=static,options=static|etcd|redis"` // 机器 ID 的分配方式
+	MachineId        uint16        `json:",default=1"`                                // static 模式下的机器 ID
+	Key              string        `json:",default=sonyflake"`                        // 租约键前缀，共用前缀的进程之间机器 ID 不会重复
+	TTL              time.Duration `json:",default=30s"`                              // 租约有效期，不能小于 1 秒，每隔三分之一有效期续约一次
+	MaxClockBackward time.Duration `json:",default=1s"`                               // 允许等待的最大时钟回拨
+	Etcd             struct {
+		Hosts []string
+		User  string `json:",optional"`
+		Pass  string `json:",optional"`
+	} `json:",optional"` // etcd 模式下的 etcd 地址
+	Redis redis.RedisConf `json:",optional"` // redis 模式下的 Redis 配置
+}
+
+// MustNewSonyflake 根据配置创建 Sonyflake，租用不到机器 ID 时退出进程.
+// 租用的机器 ID 在进程退出时释放.
+func MustNewSonyflake(c SonyflakeConf) *Sonyflake {
+	sf, err := NewSonyflakeFromConf(c)
+	logx.Must(err)
+	return sf
+}
+
+// NewSonyflakeFromConf 根据配置创建 Sonyflake，etcd 和 redis 模式下租用的机器 ID 在进程退出时释放.
+func NewSonyflakeFromConf(c SonyflakeConf) (*Sonyflake, error) {
+	options := []func(*SonyflakeOptions){WithSonyflakeMaxClockBackward(c.MaxClockBackward)}
+
+	if c.Mode != ModeStatic {
+		if c.TTL < time.Second {
+			return nil, fmt.Errorf("id: machine id lease ttl must be at least 1s, got %s", c.TTL)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), leaseTimeout)
+		defer cancel()
+
+		lease, cleanup, err := leaseFromConf(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		proc.AddShutdownListener(func() {
+			if err := lease.Close(); err != nil {
+				logx.Errorw("释放机器 ID 失败",
+					logx.Field("machineId", lease.MachineID()),
+					logx.Field("error", err))
+			}
+			cleanup()
+		})
+		logx.Infow("租用机器 ID 成功",
+			logx.Field("mode", c.Mode),
+			logx.Field("machineId", lease.MachineID()))
+		options = append(options, WithSonyflakeLease(lease))
+	} else {
+		options = append(options, WithSonyflakeMachineId(c.MachineId))
+	}
+
+	sf := NewSonyflake(options...)
+	return sf, sf.Error
+}
+
+// leaseFromConf 按配置从 etcd 或 Redis 租用机器 ID，cleanup 在释放租约后关闭客户端.
+func leaseFromConf(ctx context.Context, c SonyflakeConf) (*Lease, func(), error) {
+	switch c.Mode {
+	case ModeEtcd:
+		cli, err := clientv3.New(clientv3.Config{
+			Endpoints:   c.Etcd.Hosts,
+			Username:    c.Etcd.User,
+			Password:    c.Etcd.Pass,
+			DialTimeout: leaseTimeout,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		lease, err := LeaseMachineIDFromEtcd(ctx, cli, c.Key, c.TTL)
+		if err != nil {
+			_ = cli.Close()
+			return nil, nil, err
+		}
+		return lease, func() { _ = cli.Close() }, nil
+	case ModeRedis:
+		rds, err := redis.NewRedis(c.Redis)
+		if err != nil {
+			return nil, nil, err
+		}
+		lease, err := LeaseMachineIDFromRedis(ctx, rds, c.Key, c.TTL)
+		return lease, func() {}, err
+	default:
+		return nil, nil, fmt.Errorf("id: unknown machine id mode %q", c.Mode)
+	}
+}
+
+// SetDefault 设置进程级的 Sonyflake，服务启动时调用一次.
+func SetDefault(sf *Sonyflake) {
+	defaultSonyflake.Store(sf)
+}
+
+// Default 返回进程级的 Sonyflake，未设置时返回 nil.
+func Default() *Sonyflake {
+	return defaultSonyflake.Load()
+}
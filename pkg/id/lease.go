@@ -0,0 +1,197 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package id
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const (
+	// maxMachineID 可租用的最大机器 ID，机器 ID 的范围为 1-65535
+	maxMachineID = 1<<16 - 1
+	// releaseTimeout 释放租约的超时时间
+	releaseTimeout = 3 * time.Second
+)
+
+// ErrNoMachineID 表示所有机器 ID 都已被占用.
+var ErrNoMachineID = errors.New("id: no machine id available")
+
+// leaseStore 机器 ID 租约的存储，由 etcd 或 Redis 实现.
+type leaseStore interface {
+	// acquire 尝试占用机器 ID，已被其他进程占用时返回 false
+	acquire(ctx context.Context, machineID uint16) (bool, error)
+	// renew 续约，租约已被其他进程占用时返回 false；租约已过期但未被占用时重新占用
+	renew(ctx context.Context, machineID uint16) (bool, error)
+	// release 释放机器 ID
+	release(ctx context.Context, machineID uint16) error
+}
+
+// Lease 为租用的机器 ID，后台按 TTL 的三分之一定时续约.
+// 续约失败时租约在上一次续约成功的 TTL 之后失效，失效期间 Sonyflake 停止生成 ID，避免与其他进程重复.
+// 租约失效或机器 ID 被其他进程占用后，后台继续尝试重新租用，新的机器 ID 可能与原来的不同，
+// 租用成功后 Sonyflake 使用新的机器 ID 恢复生成.
+type Lease struct {
+	ttl   time.Duration
+	store leaseStore
+	state atomic.Pointer[leaseState]
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// leaseState 为租约的当前状态，重新租用时整体替换，保证机器 ID 与失效时间一致.
+type leaseState struct {
+	machineID uint16
+	deadline  int64 // 租约失效的时间，Unix 纳秒
+}
+
+// leaseMachineID 租用一个机器 ID，成功后启动后台续约.
+func leaseMachineID(ctx context.Context, store leaseStore, ttl time.Duration) (*Lease, error) {
+	state, err := acquireMachineID(ctx, store, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Lease{
+		ttl:   ttl,
+		store: store,
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	l.state.Store(state)
+	go l.keepAlive()
+	return l, nil
+}
+
+// acquireMachineID 从随机位置开始依次尝试占用机器 ID.
+func acquireMachineID(ctx context.Context, store leaseStore, ttl time.Duration) (*leaseState, error) {
+	start := rand.IntN(maxMachineID)
+	for i := range maxMachineID {
+		machineID := uint16((start+i)%maxMachineID + 1)
+		begin := time.Now()
+		ok, err := store.acquire(ctx, machineID)
+		if err != nil {
+			return nil, fmt.Errorf("id: lease machine id: %w", err)
+		}
+		if ok {
+			return &leaseState{machineID: machineID, deadline: begin.Add(ttl).UnixNano()}, nil
+		}
+	}
+	return nil, ErrNoMachineID
+}
+
+// MachineID 返回当前租用的机器 ID，重新租用后可能变化.
+func (l *Lease) MachineID() uint16 {
+	return l.state.Load().machineID
+}
+
+// Valid 判断租约当前是否有效.
+func (l *Lease) Valid() bool {
+	_, ok := l.current()
+	return ok
+}
+
+// current 返回当前的机器 ID 及租约是否有效.
+func (l *Lease) current() (uint16, bool) {
+	state := l.state.Load()
+	return state.machineID, time.Now().UnixNano() < state.deadline
+}
+
+// Close 停止续约并释放机器 ID，可重复调用.
+func (l *Lease) Close() error {
+	var err error
+	l.closeOnce.Do(func() {
+		close(l.stop)
+		<-l.done
+		machineID := l.MachineID()
+		l.state.Store(&leaseState{machineID: machineID})
+
+		ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+		defer cancel()
+		err = l.store.release(ctx, machineID)
+	})
+	return err
+}
+
+// keepAlive 定时续约直到 Close 被调用.
+func (l *Lease) keepAlive() {
+	defer close(l.done)
+
+	interval := l.ttl / 3
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+		}
+		l.refresh(interval)
+	}
+}
+
+// refresh 续约一次，租约已失效或机器 ID 已被其他进程占用时重新租用.
+func (l *Lease) refresh(timeout time.Duration) {
+	machineID := l.MachineID()
+	begin := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	ok, err := l.store.renew(ctx, machineID)
+	cancel()
+	switch {
+	case err != nil:
+		logx.Errorw("机器 ID 续约失败",
+			logx.Field("machineId", machineID),
+			logx.Field("error", err))
+	case !ok:
+		// 其他进程已经占用，立即停止使用该机器 ID
+		logx.Errorw("机器 ID 已被其他进程占用",
+			logx.Field("machineId", machineID))
+		l.state.Store(&leaseState{machineID: machineID})
+	default:
+		l.state.Store(&leaseState{machineID: machineID, deadline: begin.Add(l.ttl).UnixNano()})
+		return
+	}
+
+	if !l.Valid() {
+		l.reacquire(timeout)
+	}
+}
+
+// reacquire 重新租用机器 ID，失败时等待下一次续约再试.
+func (l *Lease) reacquire(timeout time.Duration) {
+	old := l.MachineID()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	state, err := acquireMachineID(ctx, l.store, l.ttl)
+	if err != nil {
+		logx.Errorw("重新租用机器 ID 失败",
+			logx.Field("machineId", old),
+			logx.Field("error", err))
+		return
+	}
+	l.state.Store(state)
+	logx.Infow("重新租用机器 ID 成功",
+		logx.Field("oldMachineId", old),
+		logx.Field("machineId", state.machineID))
+}
+
+// leaseOwner 返回标识当前进程的租约持有者.
+func leaseOwner() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s:%d:%x", host, os.Getpid(), rand.Uint32())
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package id
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// etcdLeaseStore 基于 etcd 的机器 ID 租约，机器 ID 键绑定在同一个 etcd lease 上.
+type etcdLeaseStore struct {
+	cli    *clientv3.Client
+	prefix string
+	owner  string
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	leaseID clientv3.LeaseID
+}
+
+// LeaseMachineIDFromEtcd 从 etcd 租用一个机器 ID，共用 prefix 的进程之间机器 ID 不会重复.
+// 返回的租约需要在进程退出前调用 Close 释放，未释放的机器 ID 在 ttl 之后自动回收.
+func LeaseMachineIDFromEtcd(ctx context.Context, cli *clientv3.Client, prefix string, ttl time.Duration) (*Lease, error) {
+	return leaseMachineID(ctx, &etcdLeaseStore{
+		cli:    cli,
+		prefix: prefix,
+		owner:  leaseOwner(),
+		ttl:    ttl,
+	}, ttl)
+}
+
+func (s *etcdLeaseStore) acquire(ctx context.Context, machineID uint16) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.leaseID == clientv3.NoLease {
+		resp, err := s.cli.Grant(ctx, int64(s.ttl/time.Second))
+		if err != nil {
+			return false, err
+		}
+		s.leaseID = resp.ID
+	}
+
+	key := s.key(machineID)
+	resp, err := s.cli.Txn(ctx).
+		If(clientv3.Compare(clientv3.CreateRevision(key), "=", 0)).
+		Then(clientv3.OpPut(key, s.owner, clientv3.WithLease(s.leaseID))).
+		Commit()
+	if err != nil {
+		return false, err
+	}
+	return resp.Succeeded, nil
+}
+
+func (s *etcdLeaseStore) renew(ctx context.Context, machineID uint16) (bool, error) {
+	s.mu.Lock()
+	leaseID := s.leaseID
+	s.mu.Unlock()
+
+	_, err := s.cli.KeepAliveOnce(ctx, leaseID)
+	if !errors.Is(err, rpctypes.ErrLeaseNotFound) {
+		return err == nil, err
+	}
+
+	// lease 已过期，机器 ID 未被其他进程占用时使用新的 lease 重新占用
+	s.mu.Lock()
+	s.leaseID = clientv3.NoLease
+	s.mu.Unlock()
+	return s.acquire(ctx, machineID)
+}
+
+func (s *etcdLeaseStore) release(ctx context.Context, _ uint16) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.leaseID == clientv3.NoLease {
+		return nil
+	}
+	_, err := s.cli.Revoke(ctx, s.leaseID)
+	s.leaseID = clientv3.NoLease
+	return err
+}
+
+func (s *etcdLeaseStore) key(machineID uint16) string {
+	return fmt.Sprintf("%s/%d", s.prefix, machineID)
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package id
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+// renewScript 续约：键属于当前进程时延长过期时间，键已过期时重新占用，被其他进程占用时返回 0.
+//
+// KEYS[1]: 机器 ID 键
+// ARGV[1]: 租约持有者；ARGV[2]: 过期时间（毫秒）
+var renewScript = redis.NewScript(`
+local owner = redis.call('GET', KEYS[1])
+if owner == ARGV[1] then
+  return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+if not owner then
+  redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+  return 1
+end
+return 0
+`)
+
+// releaseScript 释放：仅删除属于当前进程的键.
+//
+// KEYS[1]: 机器 ID 键；ARGV[1]: 租约持有者
+var releaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// redisLeaseStore 基于 Redis 的机器 ID 租约，每个机器 ID 对应一个带过期时间的键.
+type redisLeaseStore struct {
+	rds    *redis.Redis
+	prefix string
+	owner  string
+	ttl    time.Duration
+}
+
+// LeaseMachineIDFromRedis 从 Redis 租用一个机器 ID，共用 prefix 的进程之间机器 ID 不会重复.
+// 返回的租约需要在进程退出前调用 Close 释放，未释放的机器 ID 在 ttl 之后自动回收.
+func LeaseMachineIDFromRedis(ctx context.Context, rds *redis.Redis, prefix string, ttl time.Duration) (*Lease, error) {
+	return leaseMachineID(ctx, &redisLeaseStore{
+		rds:    rds,
+		prefix: prefix,
+		owner:  leaseOwner(),
+		ttl:    ttl,
+	}, ttl)
+}
+
+func (s *redisLeaseStore) acquire(ctx context.Context, machineID uint16) (bool, error) {
+	return s.rds.SetnxExCtx(ctx, s.key(machineID), s.owner, int(s.ttl/time.Second))
+}
+
+func (s *redisLeaseStore) renew(ctx context.Context, machineID uint16) (bool, error) {
+	ret, err := s.rds.ScriptRunCtx(ctx, renewScript, []string{s.key(machineID)}, s.owner, s.ttl.Milliseconds())
+	if err != nil {
+		return false, err
+	}
+	n, ok := ret.(int64)
+	if !ok {
+		return false, errors.New("unexpected renew script result")
+	}
+	return n == 1, nil
+}
+
+func (s *redisLeaseStore) release(ctx context.Context, machineID uint16) error {
+	_, err := s.rds.ScriptRunCtx(ctx, releaseScript, []string{s.key(machineID)}, s.owner)
+	return err
+}
+
+func (s *redisLeaseStore) key(machineID uint16) string {
+	return fmt.Sprintf("%s:%d", s.prefix, machineID)
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package id
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+func TestLeaseMachineIDFromRedis(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rds := redis.New(mr.Addr())
+
+	// 多个进程租用的机器 ID 互不重复
+	seen := make(map[uint16]bool)
+	leases := make([]*Lease, 0, 10)
+	for range 10 {
+		lease, err := LeaseMachineIDFromRedis(t.Context(), rds, "sonyflake", 3*time.Second)
+		require.NoError(t, err)
+		require.False(t, seen[lease.MachineID()])
+		seen[lease.MachineID()] = true
+		leases = append(leases, lease)
+
+		assert.True(t, lease.Valid())
+		key := "sonyflake:" + strconv.Itoa(int(lease.MachineID()))
+		assert.True(t, mr.Exists(key))
+	}
+
+	// 释放后键被删除，租约失效
+	lease := leases[0]
+	require.NoError(t, lease.Close())
+	require.NoError(t, lease.Close())
+	assert.False(t, lease.Valid())
+	assert.False(t, mr.Exists("sonyflake:"+strconv.Itoa(int(lease.MachineID()))))
+
+	for _, l := range leases[1:] {
+		require.NoError(t, l.Close())
+	}
+}
+
+func TestRedisLeaseRenew(t *testing.T) {
+	mr := miniredis.RunT(t)
+	store := &redisLeaseStore{rds: redis.New(mr.Addr()), prefix: "sonyflake", owner: "a", ttl: 3 * time.Second}
+	other := &redisLeaseStore{rds: redis.New(mr.Addr()), prefix: "sonyflake", owner: "b", ttl: 3 * time.Second}
+
+	ok, err := store.acquire(t.Context(), 7)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = other.acquire(t.Context(), 7)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// 续约延长过期时间
+	mr.FastForward(2 * time.Second)
+	ok, err = store.renew(t.Context(), 7)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, mr.TTL("sonyflake:7"))
+
+	// 键过期后重新占用
+	mr.FastForward(4 * time.Second)
+	ok, err = store.renew(t.Context(), 7)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// 被其他进程占用时续约失败，也不能释放其他进程的键
+	mr.FastForward(4 * time.Second)
+	ok, err = other.acquire(t.Context(), 7)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = store.renew(t.Context(), 7)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	require.NoError(t, store.release(t.Context(), 7))
+	assert.True(t, mr.Exists("sonyflake:7"))
+}
+
+func TestSonyflakeLeaseExpired(t *testing.T) {
+	lease := &Lease{}
+	lease.state.Store(&leaseState{machineID: 9, deadline: time.Now().Add(time.Hour).UnixNano()})
+
+	sf := NewSonyflake(WithSonyflakeLease(lease))
+	num, err := sf.Id(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, uint16(9), DecomposeSonyflake(num).MachineID)
+
+	lease.state.Store(&leaseState{machineID: 9})
+	_, err = sf.Id(t.Context())
+	assert.ErrorIs(t, err, ErrLeaseExpired)
+
+	// 重新租用后使用新的机器 ID 恢复生成
+	lease.state.Store(&leaseState{machineID: 12, deadline: time.Now().Add(time.Hour).UnixNano()})
+	num, err = sf.Id(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, uint16(12), DecomposeSonyflake(num).MachineID)
+}
+
+func TestLeaseReacquire(t *testing.T) {
+	mr := miniredis.RunT(t)
+	store := &redisLeaseStore{rds: redis.New(mr.Addr()), prefix: "sonyflake", owner: "a", ttl: 3 * time.Second}
+
+	lease, err := leaseMachineID(t.Context(), store, 3*time.Second)
+	require.NoError(t, err)
+	defer lease.Close()
+	lost := lease.MachineID()
+
+	// 机器 ID 被其他进程占用后立即失效，并重新租用另一个机器 ID
+	mr.Set("sonyflake:"+strconv.Itoa(int(lost)), "b")
+	lease.refresh(time.Second)
+	assert.True(t, lease.Valid())
+	assert.NotEqual(t, lost, lease.MachineID())
+	owner, err := mr.Get("sonyflake:" + strconv.Itoa(int(lease.MachineID())))
+	require.NoError(t, err)
+	assert.Equal(t, "a", owner)
+
+	// 存储不可用时租约在 TTL 之后失效，恢复后重新租用
+	mr.SetError("unavailable")
+	lease.state.Store(&leaseState{machineID: lease.MachineID()})
+	lease.refresh(time.Second)
+	assert.False(t, lease.Valid())
+
+	mr.SetError("")
+	lease.refresh(time.Second)
+	assert.True(t, lease.Valid())
+}
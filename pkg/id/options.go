@@ -101,8 +101,10 @@ func getCodeOptionsOrSetDefault(options *CodeOptions) *CodeOptions {
 
 // SonyflakeOptions 定义了 Sonyflake ID 生成器的配置选项
 type SonyflakeOptions struct {
-	machineId uint16    // 机器 ID，用于分布式环境中标识不同机器
-	startTime time.Time // 时间起点，Sonyflake 从该时间点开始计算时间差
+	machineId        uint16        // 机器 ID，用于分布式环境中标识不同机器
+	startTime        time.Time     // 时间起点，Sonyflake 从该时间点开始计算时间差
+	lease            *Lease        // 机器 ID 租约，设置后使用租约中的机器 ID
+	maxClockBackward time.Duration // 允许等待的最大时钟回拨
 }
 
 // WithSonyflakeMachineId 设置机器 ID
@@ -127,6 +129,28 @@ func WithSonyflakeStartTime(startTime time.Time) func(*SonyflakeOptions) {
 	}
 }
 
+// WithSonyflakeLease 使用租约中的机器 ID，租约失效后停止生成 ID
+// 参数:
+//   - lease: 从 etcd 或 Redis 租用的机器 ID，参见 LeaseMachineID
+func WithSonyflakeLease(lease *Lease) func(*SonyflakeOptions) {
+	return func(options *SonyflakeOptions) {
+		if lease != nil {
+			getSonyflakeOptionsOrSetDefault(options).lease = lease
+		}
+	}
+}
+
+// WithSonyflakeMaxClockBackward 设置允许等待的最大时钟回拨
+// 参数:
+//   - d: 时钟回拨不超过 d 时等待时钟追上，超过时生成 ID 直接返回错误
+func WithSonyflakeMaxClockBackward(d time.Duration) func(*SonyflakeOptions) {
+	return func(options *SonyflakeOptions) {
+		if d > 0 {
+			getSonyflakeOptionsOrSetDefault(options).maxClockBackward = d
+		}
+	}
+}
+
 // getSonyflakeOptionsOrSetDefault 获取 SonyflakeOptions，如果为空则创建默认配置
 // 参数:
 //   - options: 可选的 SonyflakeOptions 实例
@@ -136,8 +160,9 @@ func WithSonyflakeStartTime(startTime time.Time) func(*SonyflakeOptions) {
 func getSonyflakeOptionsOrSetDefault(options *SonyflakeOptions) *SonyflakeOptions {
 	if options == nil {
 		return &SonyflakeOptions{
-			machineId: 1,
-			startTime: time.Date(2022, 10, 10, 0, 0, 0, 0, time.UTC),
+			machineId:        1,
+			startTime:        time.Date(2022, 10, 10, 0, 0, 0, 0, time.UTC),
+			maxClockBackward: time.Second,
 		}
 	}
 	return options
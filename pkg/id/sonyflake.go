@@ -9,16 +9,42 @@ package id
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/sony/sonyflake"
 )
 
-// Sonyflake 结构体封装了 Sony 的分布式 ID 生成器，它可以生成分布式环境下的唯一 ID，可用于数据库主键等场景
+const (
+	// sonyflakeTimeUnit Sonyflake 的时间单位，10 毫秒
+	sonyflakeTimeUnit = int64(10 * time.Millisecond)
+	// maskSequence 序列号掩码
+	maskSequence = uint16(1<<sonyflake.BitLenSequence - 1)
+)
+
+var (
+	// ErrStartTimeAhead 表示时间起点晚于当前时间
+	ErrStartTimeAhead = errors.New("id: sonyflake start time is ahead of now")
+	// ErrOverTimeLimit 表示距时间起点已超过 Sonyflake 可表示的范围（约 174 年）
+	ErrOverTimeLimit = errors.New("id: sonyflake over the time limit")
+	// ErrClockBackward 表示系统时钟回拨超过了允许等待的范围
+	ErrClockBackward = errors.New("id: clock moved backwards")
+	// ErrLeaseExpired 表示机器 ID 的租约已失效，继续生成可能与其他进程重复.
+	// 租约在后台重新租用机器 ID，成功后恢复生成
+	ErrLeaseExpired = errors.New("id: machine id lease expired")
+)
+
+// Sonyflake 结构体实现了 Sony 的分布式 ID 生成算法，它可以生成分布式环境下的唯一 ID，可用于数据库主键等场景
+// ID 由 39 位时间（10 毫秒）、8 位序列号和 16 位机器 ID 组成，与 github.com/sony/sonyflake 兼容
 type Sonyflake struct {
-	ops   SonyflakeOptions     // Sonyflake 的配置选项
-	sf    *sonyflake.Sonyflake // 内部的 Sonyflake 实例
-	Error error                // 初始化过程中的错误信息
+	ops   SonyflakeOptions // Sonyflake 的配置选项
+	Error error            // 初始化过程中的错误信息
+
+	mu        sync.Mutex
+	startTime int64            // 时间起点，单位为 10 毫秒
+	elapsed   int64            // 最近一次生成 ID 的时间，单位为 10 毫秒
+	sequence  uint16           // 最近一次生成 ID 的序列号
+	now       func() time.Time // 当前时间，测试时可替换
 }
 
 // NewSonyflake 创建一个新的 Sonyflake 实例
@@ -31,62 +57,137 @@ type Sonyflake struct {
 // 使用示例:
 //
 //	sf := id.NewSonyflake(id.WithSonyflakeMachineId(1))
-//	id := sf.Id(context.Background())
+//	id, err := sf.Id(context.Background())
 func NewSonyflake(options ...func(*SonyflakeOptions)) *Sonyflake {
 	ops := getSonyflakeOptionsOrSetDefault(nil)
 	for _, f := range options {
 		f(ops)
 	}
 	sf := &Sonyflake{
-		ops: *ops,
+		ops:       *ops,
+		startTime: toSonyflakeTime(ops.startTime),
+		sequence:  maskSequence,
+		now:       time.Now,
 	}
-	st := sonyflake.Settings{
-		StartTime: ops.startTime,
+	if ops.startTime.After(time.Now()) {
+		sf.Error = ErrStartTimeAhead
 	}
-	if ops.machineId > 0 {
-		st.MachineID = func() (uint16, error) {
-			return ops.machineId, nil
-		}
-	}
-	ins := sonyflake.NewSonyflake(st)
-	if ins == nil {
-		sf.Error = errors.New("create snoyflake failed")
-	}
-	_, err := ins.NextID()
-	if err != nil {
-		sf.Error = errors.New("invalid start time")
-	}
-	sf.sf = ins
 	return sf
 }
 
+// MachineID 返回生成 ID 使用的机器 ID，使用租约时为租约当前的机器 ID.
+func (s *Sonyflake) MachineID() uint16 {
+	if s.ops.lease != nil {
+		return s.ops.lease.MachineID()
+	}
+	return s.ops.machineId
+}
+
 // Id 生成一个新的唯一 ID
 // 参数:
-//   - ctx: 上下文对象，可用于取消操作
+//   - ctx: 上下文对象，等待时钟追上或下一个时间单位时可取消
 //
 // 返回值:
 //   - 一个 uint64 类型的唯一 ID
+//   - 初始化失败、租约失效、时钟回拨过多或上下文取消时返回错误
 //
 // 备注:
-//   - 如果生成失败会进行指数退避重试
-func (s *Sonyflake) Id(ctx context.Context) uint64 {
-	var id uint64
+//   - 同一时间单位内的序列号用尽时等待下一个时间单位
+//   - 时钟回拨不超过 maxClockBackward 时等待时钟追上，否则直接返回 ErrClockBackward
+func (s *Sonyflake) Id(ctx context.Context) (uint64, error) {
 	if s.Error != nil {
-		return id
+		return 0, s.Error
 	}
-	var err error
-	id, err = s.sf.NextID()
-	if err == nil {
-		return id
+	for {
+		id, wait, err := s.next()
+		if err != nil || wait <= 0 {
+			return id, err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return 0, ctx.Err()
+		case <-timer.C:
+		}
 	}
+}
 
-	sleep := 1
-	for {
-		time.Sleep(time.Duration(sleep) * time.Millisecond)
-		id, err = s.sf.NextID()
-		if err == nil {
-			return id
+// next 尝试生成 ID，需要等待时返回等待时长.
+func (s *Sonyflake) next() (uint64, time.Duration, error) {
+	machineID := s.ops.machineId
+	if s.ops.lease != nil {
+		var ok bool
+		if machineID, ok = s.ops.lease.current(); !ok {
+			return 0, 0, ErrLeaseExpired
 		}
-		sleep *= 2
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	current := toSonyflakeTime(now) - s.startTime
+	switch {
+	case current > s.elapsed:
+		s.elapsed = current
+		s.sequence = 0
+	case current == s.elapsed:
+		if s.sequence == maskSequence {
+			// 序列号用尽，等待下一个时间单位
+			return 0, timeUntil(now, s.startTime+s.elapsed+1), nil
+		}
+		s.sequence++
+	default:
+		// 时钟回拨：小幅回拨等待时钟追上，避免生成重复 ID
+		backward := time.Duration((s.elapsed - current) * sonyflakeTimeUnit)
+		if backward > s.ops.maxClockBackward {
+			return 0, 0, ErrClockBackward
+		}
+		return 0, timeUntil(now, s.startTime+s.elapsed), nil
+	}
+
+	if s.elapsed >= 1<<sonyflake.BitLenTime {
+		return 0, 0, ErrOverTimeLimit
+	}
+	return uint64(s.elapsed)<<(sonyflake.BitLenSequence+sonyflake.BitLenMachineID) |
+		uint64(s.sequence)<<sonyflake.BitLenMachineID |
+		uint64(machineID), 0, nil
+}
+
+// toSonyflakeTime 将时间转换为 Sonyflake 的时间单位.
+func toSonyflakeTime(t time.Time) int64 {
+	return t.UTC().UnixNano() / sonyflakeTimeUnit
+}
+
+// timeUntil 返回从 now 到 Sonyflake 时间 t 的时长.
+func timeUntil(now time.Time, t int64) time.Duration {
+	return time.Duration(t*sonyflakeTimeUnit - now.UTC().UnixNano())
+}
+
+// SonyflakeParts 为 Sonyflake ID 拆分后的各组成部分
+type SonyflakeParts struct {
+	Time      time.Time // 生成时间，精度为 10 毫秒
+	Sequence  uint16    // 同一时间单位内的序列号
+	MachineID uint16    // 生成该 ID 的机器 ID
+}
+
+// DecomposeSonyflake 将 Sonyflake ID 拆分为生成时间、序列号和机器 ID
+// 参数:
+//   - id: Sonyflake 生成的 ID
+//   - options: 配置函数列表，时间起点须与生成 ID 时一致
+//
+// 返回值:
+//   - ID 的各组成部分
+func DecomposeSonyflake(id uint64, options ...func(*SonyflakeOptions)) SonyflakeParts {
+	ops := getSonyflakeOptionsOrSetDefault(nil)
+	for _, f := range options {
+		f(ops)
+	}
+	return SonyflakeParts{
+		Time:      ops.startTime.Add(sonyflake.ElapsedTime(id)),
+		Sequence:  uint16(sonyflake.SequenceNumber(id)),
+		MachineID: uint16(sonyflake.MachineID(id)),
 	}
 }
@@ -0,0 +1,120 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package id
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zeromicro/go-zero/core/conf"
+)
+
+// fakeClock 可手动调整的时钟.
+type fakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+func TestSonyflakeUnique(t *testing.T) {
+	sf := NewSonyflake(WithSonyflakeMachineId(3))
+	require.NoError(t, sf.Error)
+
+	seen := make(map[uint64]bool)
+	for range 2000 {
+		num, err := sf.Id(t.Context())
+		require.NoError(t, err)
+		require.False(t, seen[num])
+		seen[num] = true
+		assert.Equal(t, uint16(3), DecomposeSonyflake(num).MachineID)
+	}
+}
+
+func TestSonyflakeClockBackward(t *testing.T) {
+	clock := &fakeClock{t: time.Now()}
+	sf := NewSonyflake(WithSonyflakeMaxClockBackward(time.Second))
+	sf.now = clock.Now
+
+	first, err := sf.Id(t.Context())
+	require.NoError(t, err)
+
+	// 小幅回拨：等待时钟追上，期间可以取消
+	clock.Add(-500 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+	_, err = sf.Id(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// 时钟追上后继续生成，不与回拨前的 ID 重复
+	clock.Add(500 * time.Millisecond)
+	second, err := sf.Id(t.Context())
+	require.NoError(t, err)
+	assert.Greater(t, second, first)
+
+	// 回拨超过上限直接返回错误
+	clock.Add(-2 * time.Second)
+	_, err = sf.Id(t.Context())
+	assert.ErrorIs(t, err, ErrClockBackward)
+}
+
+func TestSonyflakeSequenceExhausted(t *testing.T) {
+	clock := &fakeClock{t: time.Now()}
+	sf := NewSonyflake()
+	sf.now = clock.Now
+
+	for range int(maskSequence) + 1 {
+		_, err := sf.Id(t.Context())
+		require.NoError(t, err)
+	}
+	// 同一时间单位内序列号用尽，时钟不前进时一直等待
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+	_, err := sf.Id(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	clock.Add(10 * time.Millisecond)
+	num, err := sf.Id(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, uint16(0), DecomposeSonyflake(num).Sequence)
+}
+
+func TestSonyflakeStartTimeAhead(t *testing.T) {
+	sf := NewSonyflake(WithSonyflakeStartTime(time.Now().Add(time.Hour)))
+	assert.ErrorIs(t, sf.Error, ErrStartTimeAhead)
+	_, err := sf.Id(t.Context())
+	assert.ErrorIs(t, err, ErrStartTimeAhead)
+}
+
+func TestSonyflakeConf(t *testing.T) {
+	var c SonyflakeConf
+	require.NoError(t, conf.LoadFromYamlBytes([]byte("MachineId: 5"), &c))
+	assert.Equal(t, ModeStatic, c.Mode)
+	assert.Equal(t, 30*time.Second, c.TTL)
+
+	sf, err := NewSonyflakeFromConf(c)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(5), sf.MachineID())
+
+	c.Mode = ModeRedis
+	c.TTL = 100 * time.Millisecond
+	_, err = NewSonyflakeFromConf(c)
+	assert.Error(t, err)
+}
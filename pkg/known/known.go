@@ -19,4 +19,16 @@ const (
 
 	// XUsername 用来定义上下文的键，代表请求用户名.
 	XUsername = "x-username"
+
+	// XLocale 用来定义 gRPC 元数据的键，代表 API 层与客户端协商出的语言，如 zh-CN.
+	XLocale = "x-locale"
+
+	// XRequestID 用来定义上下文的键，代表请求 ID，用于关联错误响应与服务端日志.
+	XRequestID = "x-request-id"
+
+	// XClientIP 用来定义上下文和 gRPC 元数据的键，代表网关识别出的客户端 IP.
+	XClientIP = "x-client-ip"
+
+	// XServiceToken 用来定义 gRPC 元数据的键，代表内部服务之间调用时携带的服务密钥.
+	XServiceToken = "x-service-token"
 )
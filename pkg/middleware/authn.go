@@ -7,6 +7,8 @@ package middleware
 
 import (
 	"context"
+	"crypto/subtle"
+	"net"
 	"net/http"
 
 	"github.com/clin211/miniblog-v3/pkg/errorx"
@@ -15,6 +17,8 @@ import (
 	"github.com/clin211/miniblog-v3/pkg/token"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -76,14 +80,41 @@ func AuthnMiddlewareFunc() func(http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// AuthnOption AuthnInterceptor 的可选配置
+type AuthnOption func(*authnOptions)
+
+type authnOptions struct {
+	serviceSecret  string
+	serviceMethods map[string]bool
+}
+
+// WithServiceMethods 指定仅供内部服务调用的方法. 这些方法不接受用户 token，
+// 调用方必须通过 ServiceClientInterceptor 在元数据中携带相同的服务密钥，密钥为空时一律拒绝
+func WithServiceMethods(secret string, methods ...string) AuthnOption {
+	return func(o *authnOptions) {
+		o.serviceSecret = secret
+		for _, method := range methods {
+			o.serviceMethods[method] = true
+		}
+	}
+}
+
 // AuthnInterceptor gRPC认证拦截器
-// 从gRPC元数据中解析JWT token，验证用户身份，并将用户ID存储到上下文中
-func AuthnInterceptor() grpc.UnaryServerInterceptor {
+// 从gRPC元数据中解析JWT token，验证用户身份，并将用户ID存储到上下文中；
+// 通过 WithServiceMethods 指定的方法改为校验服务密钥
+func AuthnInterceptor(opts ...AuthnOption) grpc.UnaryServerInterceptor {
+	o := &authnOptions{serviceMethods: make(map[string]bool)}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		// 定义不需要认证的方法
 		noAuthMethods := map[string]bool{
 			"/rpc.User/Login":    true,
 			"/rpc.User/Register": true,
+			// 短链重定向为公开访问
+			"/rpc.ShortLink/ResolveShortLink": true,
 		}
 
 		// 检查当前方法是否需要认证
@@ -92,6 +123,14 @@ func AuthnInterceptor() grpc.UnaryServerInterceptor {
 			return handler(ctx, req)
 		}
 
+		// 仅供内部服务调用的方法校验服务密钥
+		if o.serviceMethods[info.FullMethod] {
+			if !validServiceToken(ctx, o.serviceSecret) {
+				return nil, status.Error(codes.Unauthenticated, "invalid service token")
+			}
+			return handler(ctx, req)
+		}
+
 		// 需要认证的方法解析JWT token
 		claims, err := token.ParseRequest(ctx)
 		if err != nil {
@@ -103,3 +142,36 @@ func AuthnInterceptor() grpc.UnaryServerInterceptor {
 		return handler(ctx, req)
 	}
 }
+
+// validServiceToken 检查元数据中的服务密钥，使用常量时间比较避免按耗时猜测密钥
+func validServiceToken(ctx context.Context, secret string) bool {
+	if secret == "" {
+		return false
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	vals := md.Get(known.XServiceToken)
+	return len(vals) == 1 && subtle.ConstantTimeCompare([]byte(vals[0]), []byte(secret)) == 1
+}
+
+// ClientIP 返回 gRPC 请求的客户端 IP. 调用方携带有效的服务密钥时使用元数据中由 API 层转发的客户端 IP，
+// 否则使用连接的对端地址，不信任请求体或元数据中自行填写的 IP
+func ClientIP(ctx context.Context, serviceSecret string) string {
+	if validServiceToken(ctx, serviceSecret) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if vals := md.Get(known.XClientIP); len(vals) == 1 && vals[0] != "" {
+			return vals[0]
+		}
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
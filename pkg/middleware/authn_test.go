@@ -6,6 +6,7 @@ package middleware
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -16,6 +17,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
 func TestAuthnMiddleware(t *testing.T) {
@@ -202,3 +204,74 @@ func TestAuthnInterceptor(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthnInterceptorServiceMethods(t *testing.T) {
+	const method = "/rpc.ShortLink/RecordClicks"
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "success", nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+
+	// 通过客户端拦截器携带服务密钥
+	outgoing := func(secret string) context.Context {
+		var ctx context.Context
+		invoker := func(c context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			ctx = c
+			return nil
+		}
+		_ = ServiceClientInterceptor(secret)(context.Background(), method, nil, nil, nil, invoker)
+		md, _ := metadata.FromOutgoingContext(ctx)
+		return metadata.NewIncomingContext(context.Background(), md)
+	}
+
+	tests := []struct {
+		name          string
+		secret        string
+		ctx           context.Context
+		expectedError bool
+	}{
+		{"valid service token", "s3cret", outgoing("s3cret"), false},
+		{"wrong service token", "s3cret", outgoing("guess"), true},
+		{"missing service token", "s3cret", context.Background(), true},
+		{"empty secret rejects all", "", outgoing(""), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := AuthnInterceptor(WithServiceMethods(tt.secret, method))
+			_, err := interceptor(tt.ctx, "test-request", info, handler)
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	// 用户 token 不能调用仅供内部服务调用的方法
+	token.Init(token.Config{Secret: "test-secret", IdentityKey: "user_id", Expiration: time.Hour})
+	tokenString, _, _ := token.Sign("user_123")
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		"authorization": "Bearer " + tokenString,
+	}))
+	_, err := AuthnInterceptor(WithServiceMethods("s3cret", method))(ctx, "test-request", info, handler)
+	assert.Error(t, err)
+}
+
+func TestClientIP(t *testing.T) {
+	p := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.8"), Port: 52000},
+	})
+	withMD := func(pairs ...string) context.Context {
+		return metadata.NewIncomingContext(p, metadata.Pairs(pairs...))
+	}
+
+	// 携带服务密钥时使用 API 层转发的客户端 IP
+	assert.Equal(t, "203.0.113.7", ClientIP(withMD(known.XServiceToken, "s3cret", known.XClientIP, "203.0.113.7"), "s3cret"))
+	// 没有服务密钥或密钥错误时不信任元数据中的 IP
+	assert.Equal(t, "10.0.0.8", ClientIP(withMD(known.XClientIP, "203.0.113.7"), "s3cret"))
+	assert.Equal(t, "10.0.0.8", ClientIP(withMD(known.XServiceToken, "guess", known.XClientIP, "203.0.113.7"), "s3cret"))
+	// 没有转发的 IP 时使用对端地址
+	assert.Equal(t, "10.0.0.8", ClientIP(withMD(known.XServiceToken, "s3cret"), "s3cret"))
+	assert.Equal(t, "", ClientIP(context.Background(), "s3cret"))
+}
@@ -8,6 +8,7 @@ package middleware
 import (
 	"context"
 
+	"github.com/clin211/miniblog-v3/pkg/known"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
@@ -27,3 +28,12 @@ func TokenClientInterceptor() grpc.UnaryClientInterceptor {
 		return invoker(ctx, method, req, reply, cc, opts...)
 	}
 }
+
+// ServiceClientInterceptor gRPC客户端拦截器，在元数据中携带服务密钥，
+// 用于调用服务端通过 WithServiceMethods 限定为内部服务调用的方法
+func ServiceClientInterceptor(secret string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = metadata.AppendToOutgoingContext(ctx, known.XServiceToken, secret)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/clin211/miniblog-v3/pkg/errorx"
+	"github.com/clin211/miniblog-v3/pkg/i18n"
+	"github.com/clin211/miniblog-v3/pkg/known"
+	"github.com/clin211/miniblog-v3/pkg/validate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// LocaleMiddleware HTTP语言协商中间件
+// 根据 Accept-Language 请求头选择语言并存储到上下文中，response.WriteResponse 按该语言输出错误信息
+func LocaleMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		locale := i18n.Negotiate(r.Header.Get("Accept-Language"))
+		w.Header().Set("Content-Language", string(locale))
+		w.Header().Add("Vary", "Accept-Language")
+		next.ServeHTTP(w, r.WithContext(i18n.WithLocale(r.Context(), locale)))
+	}
+}
+
+// LocaleClientInterceptor gRPC客户端拦截器，将上下文中的语言通过元数据传递给RPC服务
+func LocaleClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = metadata.AppendToOutgoingContext(ctx, known.XLocale, string(i18n.FromContext(ctx)))
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// LocaleInterceptor gRPC语言拦截器
+// 从元数据中读取语言存储到上下文中，并将返回的 errorx 和验证错误翻译为该语言.
+// 优先使用 x-locale，没有时按 accept-language 协商
+func LocaleInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		locale := localeFromMetadata(ctx)
+		resp, err := handler(i18n.WithLocale(ctx, locale), req)
+		if err != nil {
+			return resp, localizeError(err, locale)
+		}
+		return resp, nil
+	}
+}
+
+// localeFromMetadata 从 gRPC 元数据中获取语言
+func localeFromMetadata(ctx context.Context) i18n.Locale {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return i18n.DefaultLocale
+	}
+	if vals := md.Get(known.XLocale); len(vals) > 0 {
+		if locale, ok := i18n.Parse(vals[0]); ok {
+			return locale
+		}
+	}
+	if vals := md.Get("accept-language"); len(vals) > 0 {
+		return i18n.Negotiate(vals[0])
+	}
+	return i18n.DefaultLocale
+}
+
+// localizeError 将错误信息翻译为指定语言，不支持本地化的错误原样返回
+func localizeError(err error, locale i18n.Locale) error {
+	var e *errorx.Errno
+	if errors.As(err, &e) {
+		return e.Localize(locale)
+	}
+	var es validate.ValidationErrorsWithCode
+	if errors.As(err, &es) {
+		return es.Localize(locale)
+	}
+	return err
+}
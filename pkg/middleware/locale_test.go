@@ -0,0 +1,75 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/clin211/miniblog-v3/pkg/errorx"
+	"github.com/clin211/miniblog-v3/pkg/i18n"
+	"github.com/clin211/miniblog-v3/pkg/known"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestLocaleMiddleware(t *testing.T) {
+	var got i18n.Locale
+	handler := LocaleMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		got = i18n.FromContext(r.Context())
+	})
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("Accept-Language", "fr;q=1, en;q=0.8, zh;q=0.5")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, i18n.EnUS, got)
+	assert.Equal(t, "en-US", rr.Header().Get("Content-Language"))
+	assert.Equal(t, "Accept-Language", rr.Header().Get("Vary"))
+}
+
+func TestLocaleInterceptor(t *testing.T) {
+	interceptor := LocaleInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Test"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errorx.ErrUserDisabled
+	}
+
+	tests := []struct {
+		name    string
+		md      metadata.MD
+		message string
+	}{
+		{name: "x-locale", md: metadata.Pairs(known.XLocale, "en-US"), message: "User is disabled."},
+		{name: "accept-language", md: metadata.Pairs("accept-language", "en"), message: "User is disabled."},
+		{name: "default", md: metadata.MD{}, message: "账户已被禁用"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			_, err := interceptor(ctx, nil, info, handler)
+			e, ok := err.(*errorx.Errno)
+			assert.True(t, ok)
+			assert.Equal(t, tt.message, e.Message)
+			assert.Equal(t, errorx.ErrUserDisabled.Code, e.Code)
+		})
+	}
+	// 包级别的错误不应被修改
+	assert.Equal(t, "User is disabled.", errorx.ErrUserDisabled.Message)
+}
+
+func TestLocaleClientInterceptor(t *testing.T) {
+	ctx := i18n.WithLocale(context.Background(), i18n.EnUS)
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		assert.Equal(t, []string{"en-US"}, md.Get(known.XLocale))
+		return nil
+	}
+	assert.NoError(t, LocaleClientInterceptor()(ctx, "/test.Service/Test", nil, nil, nil, invoker))
+}
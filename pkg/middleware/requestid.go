@@ -0,0 +1,43 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/clin211/miniblog-v3/pkg/known"
+	"github.com/clin211/miniblog-v3/pkg/response"
+	"github.com/google/uuid"
+	"github.com/zeromicro/go-zero/core/trace"
+)
+
+// RequestIDMiddleware HTTP请求ID中间件
+// 优先使用请求头 X-Request-ID，没有时使用链路追踪 ID 或生成 UUID，存储到上下文中并写入响应头
+func RequestIDMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(known.XRequestID)
+		if id == "" {
+			id = trace.TraceIDFromContext(r.Context())
+		}
+		if id == "" {
+			id = uuid.NewString()
+		}
+		w.Header().Set(known.XRequestID, id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), known.XRequestID, id)))
+	}
+}
+
+// ProblemMiddleware HTTP错误格式中间件
+// 请求头 Accept 包含 application/problem+json 时，response.WriteResponse 按 RFC 7807 输出错误
+func ProblemMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if response.AcceptsProblem(r) {
+			r = r.WithContext(response.WithProblem(r.Context(), r.URL.Path))
+		}
+		next.ServeHTTP(w, r)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/clin211/miniblog-v3/pkg/errorx"
+	"github.com/clin211/miniblog-v3/pkg/known"
+	"github.com/clin211/miniblog-v3/pkg/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var got string
+	handler := RequestIDMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		got = response.RequestID(r.Context())
+	})
+
+	// 使用请求头中的请求 ID
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("X-Request-ID", "req-1")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, "req-1", got)
+	assert.Equal(t, "req-1", rr.Header().Get(known.XRequestID))
+
+	// 没有时生成新的请求 ID
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/test", nil))
+	assert.NotEmpty(t, got)
+	assert.NotEqual(t, "req-1", got)
+	assert.Equal(t, got, rr.Header().Get(known.XRequestID))
+}
+
+func TestProblemMiddleware(t *testing.T) {
+	handler := ProblemMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		response.WriteResponse(r.Context(), w, errorx.ErrUnauthorized)
+	})
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("Accept", "application/problem+json")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, response.ContentTypeProblem, rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Body.String(), `"instance":"/test"`)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/test", nil))
+	assert.Contains(t, rr.Header().Get("Content-Type"), "application/json")
+}
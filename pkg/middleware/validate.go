@@ -0,0 +1,56 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package middleware
+
+import (
+	"context"
+	"errors"
+
+	"github.com/clin211/miniblog-v3/pkg/errorx"
+	"github.com/clin211/miniblog-v3/pkg/validate"
+	"google.golang.org/grpc"
+)
+
+// ValidateInterceptor gRPC参数校验拦截器
+// 方法在 rules 中登记了校验函数时先执行校验函数，否则请求实现 validate.Validator 时调用 Validate，
+// 失败时直接返回参数错误，不再调用 handler.
+// 应放在 LocaleInterceptor 之后，以便错误信息按请求的语言翻译
+func ValidateInterceptor(rules ...validate.RequestRules) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := validateRequest(rules, info.FullMethod, req); err != nil {
+			return nil, validationError(err)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// validateRequest 按 rules 中登记的校验函数或请求自身的 Validate 校验请求
+func validateRequest(rules []validate.RequestRules, method string, req interface{}) error {
+	for _, r := range rules {
+		if rule, ok := r[method]; ok {
+			return rule(req)
+		}
+	}
+	if v, ok := req.(validate.Validator); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+// validationError 将 Validate 返回的错误转换为 gRPC 错误.
+// ValidationErrors 转换为带错误代码的验证错误，保留全部字段的错误；其他错误作为参数错误返回
+func validationError(err error) error {
+	var es validate.ValidationErrors
+	if errors.As(err, &es) {
+		return es.WithCode()
+	}
+	var esc validate.ValidationErrorsWithCode
+	var e *errorx.Errno
+	if errors.As(err, &esc) || errors.As(err, &e) {
+		return errorx.ToGRPCError(err)
+	}
+	return errorx.ErrInvalidParameter.WithMessage("%s", err.Error())
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/clin211/miniblog-v3/pkg/errorx"
+	"github.com/clin211/miniblog-v3/pkg/known"
+	"github.com/clin211/miniblog-v3/pkg/validate"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type validatedRequest struct {
+	Username string `json:"username" valid:"required,length(3|20)"`
+	Email    string `json:"email" valid:"required,email"`
+}
+
+func (r *validatedRequest) Validate() error {
+	return validate.ValidateStructWithCustomRules(r)
+}
+
+type customErrorRequest struct{ err error }
+
+func (r *customErrorRequest) Validate() error { return r.err }
+
+func TestValidateInterceptor(t *testing.T) {
+	interceptor := ValidateInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Test"}
+	called := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		return "ok", nil
+	}
+
+	t.Run("校验通过", func(t *testing.T) {
+		called = false
+		resp, err := interceptor(context.Background(), &validatedRequest{Username: "john", Email: "john@example.com"}, info, handler)
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", resp)
+		assert.True(t, called)
+	})
+
+	t.Run("未实现 Validator", func(t *testing.T) {
+		called = false
+		_, err := interceptor(context.Background(), struct{}{}, info, handler)
+		assert.NoError(t, err)
+		assert.True(t, called)
+	})
+
+	t.Run("校验失败", func(t *testing.T) {
+		called = false
+		_, err := interceptor(context.Background(), &validatedRequest{Username: "jo"}, info, handler)
+		assert.False(t, called)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		es, ok := validate.FromGRPCError(err)
+		assert.True(t, ok)
+		assert.Len(t, es, 2)
+		assert.Equal(t, "username", es[0].Field)
+		assert.Equal(t, validate.ErrorCodeInvalidLength, es[0].Code)
+		assert.Equal(t, "email", es[1].Field)
+		assert.Equal(t, validate.ErrorCodeRequired, es[1].Code)
+	})
+
+	t.Run("其他错误", func(t *testing.T) {
+		_, err := interceptor(context.Background(), &customErrorRequest{err: errors.New("缺少参数")}, info, handler)
+		e, ok := err.(*errorx.Errno)
+		assert.True(t, ok)
+		assert.Equal(t, errorx.ErrInvalidParameter.Code, e.Code)
+		assert.Equal(t, "缺少参数", e.Message)
+
+		_, err = interceptor(context.Background(), &customErrorRequest{err: errorx.ErrUserDisabled}, info, handler)
+		assert.Equal(t, errorx.ErrUserDisabled, err)
+	})
+}
+
+func TestValidateInterceptorRules(t *testing.T) {
+	type loginRequest struct{ Username string }
+	rules := validate.RequestRules{
+		"/test.Service/Login": validate.ForRequest(func(req *loginRequest) error {
+			return validate.ValidateStructWithCustomRules(&struct {
+				Username string `json:"username" valid:"required"`
+			}{Username: req.Username})
+		}),
+		// 登记的规则优先于请求自身的 Validate
+		"/test.Service/Test": validate.ForRequest(func(req *validatedRequest) error { return nil }),
+	}
+	interceptor := ValidateInterceptor(rules)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	_, err := interceptor(context.Background(), &loginRequest{}, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Login"}, handler)
+	es, ok := validate.FromGRPCError(err)
+	assert.True(t, ok)
+	assert.Len(t, es, 1)
+	assert.Equal(t, "username", es[0].Field)
+
+	resp, err := interceptor(context.Background(), &loginRequest{Username: "john"}, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Login"}, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	_, err = interceptor(context.Background(), &validatedRequest{}, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Test"}, handler)
+	assert.NoError(t, err)
+
+	// 没有登记的方法仍然调用 Validate
+	_, err = interceptor(context.Background(), &validatedRequest{}, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Other"}, handler)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// 请求类型与登记的规则不匹配时作为参数错误返回
+	_, err = interceptor(context.Background(), struct{}{}, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Login"}, handler)
+	e, ok := err.(*errorx.Errno)
+	assert.True(t, ok)
+	assert.Equal(t, errorx.ErrInvalidParameter.Code, e.Code)
+}
+
+func TestValidateInterceptorLocale(t *testing.T) {
+	locale, validator := LocaleInterceptor(), ValidateInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Test"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return validator(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(known.XLocale, "en-US"))
+	_, err := locale(ctx, &validatedRequest{Username: "john"}, info, handler)
+	es, ok := validate.FromGRPCError(err)
+	assert.True(t, ok)
+	assert.Len(t, es, 1)
+	assert.Equal(t, "is required", es[0].Message)
+}
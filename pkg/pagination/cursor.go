@@ -0,0 +1,111 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+const (
+	// cursorVersion 游标格式版本，格式变更时递增，旧版本的游标将无法解析
+	cursorVersion = 1
+	// cursorPayloadSize 版本号 + created_at（纳秒）+ id
+	cursorPayloadSize = 1 + 8 + 8
+	// cursorMACSize 截断后的签名长度
+	cursorMACSize = 16
+)
+
+var (
+	// ErrInvalidCursor 表示游标格式错误、签名不匹配或由其他密钥签发
+	ErrInvalidCursor = errors.New("pagination: invalid cursor")
+	// ErrOffsetTooLarge 表示偏移量超过上限，更深的翻页需要使用游标
+	ErrOffsetTooLarge = errors.New("pagination: offset is too large")
+	// ErrMissingSecret 表示没有配置游标签名密钥
+	ErrMissingSecret = errors.New("pagination: cursor secret is required")
+)
+
+// Cursor 表示一条记录在 (created_at, id) 倒序中的位置，下一页从该位置之后开始.
+type Cursor struct {
+	CreatedAt time.Time // 记录的创建时间
+	ID        int64     // 记录的自增 ID，创建时间相同时用于排序
+}
+
+// Codec 负责游标的编码和签名. 游标对客户端不透明，签名防止客户端伪造游标扫描数据.
+type Codec struct {
+	secret       []byte
+	defaultLimit int
+	maxLimit     int
+	maxOffset    int
+}
+
+// NewCodec 根据配置创建 Codec.
+func NewCodec(c Config) (*Codec, error) {
+	if c.Secret == "" {
+		return nil, ErrMissingSecret
+	}
+	if c.DefaultLimit <= 0 {
+		c.DefaultLimit = DefaultLimit
+	}
+	if c.MaxLimit <= 0 {
+		c.MaxLimit = MaxLimit
+	}
+	if c.MaxOffset <= 0 {
+		c.MaxOffset = MaxOffset
+	}
+	return &Codec{
+		secret:       []byte(c.Secret),
+		defaultLimit: min(c.DefaultLimit, c.MaxLimit),
+		maxLimit:     c.MaxLimit,
+		maxOffset:    c.MaxOffset,
+	}, nil
+}
+
+// MustNewCodec 根据配置创建 Codec，配置错误时 panic.
+func MustNewCodec(c Config) *Codec {
+	codec, err := NewCodec(c)
+	if err != nil {
+		panic(err)
+	}
+	return codec
+}
+
+// Encode 将游标编码为 URL 安全的字符串.
+func (c *Codec) Encode(cur Cursor) string {
+	buf := make([]byte, cursorPayloadSize, cursorPayloadSize+cursorMACSize)
+	buf[0] = cursorVersion
+	binary.BigEndian.PutUint64(buf[1:9], uint64(cur.CreatedAt.UnixNano()))
+	binary.BigEndian.PutUint64(buf[9:17], uint64(cur.ID))
+	buf = append(buf, c.sign(buf)...)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// Decode 解析并校验游标，游标无效时返回 ErrInvalidCursor.
+func (c *Codec) Decode(s string) (Cursor, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(buf) != cursorPayloadSize+cursorMACSize || buf[0] != cursorVersion {
+		return Cursor{}, ErrInvalidCursor
+	}
+	payload, mac := buf[:cursorPayloadSize], buf[cursorPayloadSize:]
+	if !hmac.Equal(mac, c.sign(payload)) {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return Cursor{
+		CreatedAt: time.Unix(0, int64(binary.BigEndian.Uint64(payload[1:9]))),
+		ID:        int64(binary.BigEndian.Uint64(payload[9:17])),
+	}, nil
+}
+
+// sign 计算截断后的 HMAC-SHA256 签名
+func (c *Codec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)[:cursorMACSize]
+}
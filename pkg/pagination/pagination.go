@@ -0,0 +1,109 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+// Package pagination 提供列表接口统一的分页参数、游标和响应结构.
+//
+// 列表按 (created_at, id) 倒序排列，优先使用游标（keyset）分页，
+// 没有游标时按 offset 分页，两种方式返回的游标可以继续翻页.
+package pagination
+
+const (
+	// DefaultLimit 默认每页条数
+	DefaultLimit = 20
+	// MaxLimit 每页最多条数
+	MaxLimit = 100
+	// MaxOffset 最大偏移量，offset 分页需要扫描并丢弃前面的记录，过深的翻页使用游标
+	MaxOffset = 10000
+)
+
+// Config 分页配置.
+type Config struct {
+	// Secret 游标签名密钥，多实例部署时必须一致，修改后已签发的游标失效
+	Secret       string
+	DefaultLimit int `json:",default=20"`    // 默认每页条数
+	MaxLimit     int `json:",default=100"`   // 每页最多条数
+	MaxOffset    int `json:",default=10000"` // 最大偏移量
+}
+
+// Request 客户端传入的分页参数.
+type Request struct {
+	Cursor string // 上一页返回的游标，为空时从第一页开始
+	Offset int    // 偏移量，仅 Cursor 为空时生效，超过上限时返回 ErrOffsetTooLarge
+	Limit  int    // 每页条数，0 表示默认条数，超过上限时按上限处理
+}
+
+// Page 校验后的分页参数.
+type Page struct {
+	Limit  int     // 每页条数
+	Offset int     // 偏移量，After 不为 nil 时为 0
+	After  *Cursor // 不为 nil 时从该位置之后开始查询
+}
+
+// Parse 校验分页参数，游标无效时返回 ErrInvalidCursor，偏移量超过上限时返回 ErrOffsetTooLarge.
+func (c *Codec) Parse(r Request) (Page, error) {
+	p := Page{Limit: r.Limit}
+	if p.Limit <= 0 {
+		p.Limit = c.defaultLimit
+	}
+	if p.Limit > c.maxLimit {
+		p.Limit = c.maxLimit
+	}
+
+	if r.Cursor == "" {
+		if r.Offset > c.maxOffset {
+			return Page{}, ErrOffsetTooLarge
+		}
+		p.Offset = max(r.Offset, 0)
+		return p, nil
+	}
+	cur, err := c.Decode(r.Cursor)
+	if err != nil {
+		return Page{}, err
+	}
+	p.After = &cur
+	return p, nil
+}
+
+// IsFirst 判断是否为第一页，通常只在第一页统计总数.
+func (p Page) IsFirst() bool {
+	return p.After == nil && p.Offset == 0
+}
+
+// Result 列表接口统一的分页结果.
+type Result[T any] struct {
+	Items      []T    `json:"items"`                // 当前页的记录
+	NextCursor string `json:"nextCursor,omitempty"` // 下一页的游标，没有下一页时为空
+	HasMore    bool   `json:"hasMore"`              // 是否有下一页
+	Total      *int64 `json:"total,omitempty"`      // 总数，没有统计时为空
+}
+
+// NewResult 根据多取一条的查询结果生成分页结果，key 返回记录的游标.
+func NewResult[T any](c *Codec, p Page, rows []T, key func(T) Cursor) Result[T] {
+	r := Result[T]{Items: rows}
+	if len(rows) > p.Limit {
+		r.Items = rows[:p.Limit]
+		r.HasMore = true
+		r.NextCursor = c.Encode(key(r.Items[len(r.Items)-1]))
+	}
+	if r.Items == nil {
+		r.Items = []T{}
+	}
+	return r
+}
+
+// WithTotal 返回带有总数的分页结果.
+func (r Result[T]) WithTotal(total int64) Result[T] {
+	r.Total = &total
+	return r
+}
+
+// Map 转换分页结果中的记录，通常用于将数据库模型转换为接口类型.
+func Map[T, U any](r Result[T], fn func(T) U) Result[U] {
+	items := make([]U, len(r.Items))
+	for i, item := range r.Items {
+		items[i] = fn(item)
+	}
+	return Result[U]{Items: items, NextCursor: r.NextCursor, HasMore: r.HasMore, Total: r.Total}
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCodec(t *testing.T) *Codec {
+	c, err := NewCodec(Config{Secret: "test-secret"})
+	require.NoError(t, err)
+	return c
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	c := newCodec(t)
+	cur := Cursor{CreatedAt: time.Date(2025, 6, 1, 8, 30, 0, 123456789, time.Local), ID: 42}
+
+	s := c.Encode(cur)
+	got, err := c.Decode(s)
+	require.NoError(t, err)
+	assert.True(t, cur.CreatedAt.Equal(got.CreatedAt))
+	assert.Equal(t, cur.ID, got.ID)
+}
+
+func TestDecodeInvalidCursor(t *testing.T) {
+	c := newCodec(t)
+	s := c.Encode(Cursor{CreatedAt: time.Now(), ID: 1})
+
+	other := MustNewCodec(Config{Secret: "other-secret"})
+	tampered := []byte(s)
+	tampered[3] ^= 1
+
+	for _, in := range []string{"", "not-base64!", "AAAA", string(tampered)} {
+		_, err := c.Decode(in)
+		assert.ErrorIs(t, err, ErrInvalidCursor, in)
+	}
+	_, err := other.Decode(s)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, err = NewCodec(Config{})
+	assert.ErrorIs(t, err, ErrMissingSecret)
+}
+
+func TestParse(t *testing.T) {
+	c := newCodec(t)
+
+	p, err := c.Parse(Request{})
+	require.NoError(t, err)
+	assert.Equal(t, Page{Limit: DefaultLimit}, p)
+	assert.True(t, p.IsFirst())
+
+	p, err = c.Parse(Request{Offset: -5, Limit: 1000})
+	require.NoError(t, err)
+	assert.Equal(t, Page{Limit: MaxLimit}, p)
+
+	cur := Cursor{CreatedAt: time.Unix(1700000000, 0), ID: 7}
+	p, err = c.Parse(Request{Cursor: c.Encode(cur), Offset: 40, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 10, p.Limit)
+	assert.Zero(t, p.Offset)
+	require.NotNil(t, p.After)
+	assert.Equal(t, cur.ID, p.After.ID)
+	assert.False(t, p.IsFirst())
+
+	_, err = c.Parse(Request{Cursor: "bad"})
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	p, err = c.Parse(Request{Offset: MaxOffset})
+	require.NoError(t, err)
+	assert.Equal(t, MaxOffset, p.Offset)
+	_, err = c.Parse(Request{Offset: MaxOffset + 1})
+	assert.ErrorIs(t, err, ErrOffsetTooLarge)
+	// 使用游标时忽略偏移量
+	_, err = c.Parse(Request{Cursor: c.Encode(cur), Offset: MaxOffset + 1})
+	assert.NoError(t, err)
+}
+
+func TestBuild(t *testing.T) {
+	query, args := Page{Limit: 20}.Build("select * from `posts`", "")
+	assert.Equal(t, "select * from `posts` order by `created_at` desc, `id` desc limit ?", query)
+	assert.Equal(t, []any{21}, args)
+
+	query, args = Page{Limit: 20, Offset: 40}.Build("select * from `posts`", "`user_id` = ?", "u1")
+	assert.Equal(t, "select * from `posts` where (`user_id` = ?) order by `created_at` desc, `id` desc limit ? offset ?", query)
+	assert.Equal(t, []any{"u1", 21, 40}, args)
+
+	at := time.Unix(1700000000, 0)
+	query, args = Page{Limit: 10, After: &Cursor{CreatedAt: at, ID: 7}}.Build("select * from `posts`", "`user_id` = ?", "u1")
+	assert.Equal(t, "select * from `posts` where (`user_id` = ?) and "+keysetCondition+
+		" order by `created_at` desc, `id` desc limit ?", query)
+	assert.Equal(t, []any{"u1", at, at, int64(7), 11}, args)
+
+	assert.Equal(t, "select count(*) from `posts` where `user_id` = ?", CountQuery("`posts`", "`user_id` = ?"))
+}
+
+type row struct {
+	id        int64
+	createdAt time.Time
+}
+
+func TestNewResult(t *testing.T) {
+	c := newCodec(t)
+	key := func(r row) Cursor { return Cursor{CreatedAt: r.createdAt, ID: r.id} }
+	now := time.Now()
+	rows := []row{{3, now}, {2, now}, {1, now.Add(-time.Minute)}}
+
+	// 多取的一条表示还有下一页，游标指向当前页最后一条
+	r := NewResult(c, Page{Limit: 2}, rows, key)
+	assert.Len(t, r.Items, 2)
+	assert.True(t, r.HasMore)
+	cur, err := c.Decode(r.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), cur.ID)
+
+	r = NewResult(c, Page{Limit: 3}, rows, key).WithTotal(3)
+	assert.Len(t, r.Items, 3)
+	assert.False(t, r.HasMore)
+	assert.Empty(t, r.NextCursor)
+	assert.Equal(t, int64(3), *r.Total)
+
+	empty := NewResult(c, Page{Limit: 3}, []row(nil), key)
+	assert.NotNil(t, empty.Items)
+
+	ids := Map(r, func(r row) int64 { return r.id })
+	assert.Equal(t, []int64{3, 2, 1}, ids.Items)
+	assert.Equal(t, r.Total, ids.Total)
+}
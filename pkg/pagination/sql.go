@@ -0,0 +1,55 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package pagination
+
+import (
+	"fmt"
+	"strings"
+)
+
+// keysetCondition 取排在游标之后的记录，需要 (created_at, id) 上的联合索引
+const keysetCondition = "(`created_at` < ? or (`created_at` = ? and `id` < ?))"
+
+// Build 在 query 上追加分页条件、排序和 limit，返回查询语句和参数.
+// query 为不含 where 的 select 语句，where 为已有的过滤条件，没有时为空，args 为 where 的参数.
+// 查询多取一条记录，配合 NewResult 判断是否有下一页.
+//
+//	query, args := page.Build("select "+rows+" from "+table, "`user_id` = ?", userId)
+//	err := conn.QueryRowsCtx(ctx, &resp, query, args...)
+func (p Page) Build(query, where string, args ...any) (string, []any) {
+	args = append([]any(nil), args...)
+
+	var conds []string
+	if where != "" {
+		conds = append(conds, "("+where+")")
+	}
+	if p.After != nil {
+		conds = append(conds, keysetCondition)
+		args = append(args, p.After.CreatedAt, p.After.CreatedAt, p.After.ID)
+	}
+
+	var b strings.Builder
+	b.WriteString(query)
+	if len(conds) > 0 {
+		b.WriteString(" where ")
+		b.WriteString(strings.Join(conds, " and "))
+	}
+	b.WriteString(" order by `created_at` desc, `id` desc limit ?")
+	args = append(args, p.Limit+1)
+	if p.After == nil && p.Offset > 0 {
+		b.WriteString(" offset ?")
+		args = append(args, p.Offset)
+	}
+	return b.String(), args
+}
+
+// CountQuery 返回统计 table 中满足 where 条件的记录数的查询，where 为空时统计全部记录.
+func CountQuery(table, where string) string {
+	if where == "" {
+		return fmt.Sprintf("select count(*) from %s", table)
+	}
+	return fmt.Sprintf("select count(*) from %s where %s", table, where)
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package password
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// prefixLength k-anonymity 查询使用的 SHA-1 前缀长度，每个前缀对应数百个哈希，数据源无法得知具体的密码
+const prefixLength = 5
+
+// BreachedSource 为泄露密码数据源，按 k-anonymity 方式查询：只提交 SHA-1 的前 5 位，
+// 返回该前缀下所有哈希的其余 35 位及其泄露次数，由调用方在本地比对.
+type BreachedSource interface {
+	// Range 返回前缀下的哈希后缀（大写十六进制）及泄露次数
+	Range(ctx context.Context, prefix string) (map[string]int, error)
+}
+
+// dirSource 从本地目录读取离线的泄露密码列表.
+type dirSource struct {
+	dir string
+}
+
+// NewDirSource 创建读取本地目录的泄露密码数据源，目录格式见 Config.BreachedDir.
+func NewDirSource(dir string) (BreachedSource, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("password: breached dir: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("password: breached dir %q is not a directory", dir)
+	}
+	return &dirSource{dir: dir}, nil
+}
+
+func (s *dirSource) Range(_ context.Context, prefix string) (map[string]int, error) {
+	f, err := os.Open(filepath.Join(s.dir, prefix+".txt"))
+	if errors.Is(err, fs.ErrNotExist) {
+		// 没有对应文件说明该前缀下没有泄露的密码
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("password: read breached list: %w", err)
+	}
+	defer f.Close()
+
+	result := make(map[string]int)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		suffix, count, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			continue
+		}
+		result[strings.ToUpper(suffix)] = n
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("password: read breached list: %w", err)
+	}
+	return result, nil
+}
+
+// breachedCount 返回密码的泄露次数，只有 SHA-1 的前缀会传给数据源.
+func breachedCount(ctx context.Context, source BreachedSource, password string) (int, error) {
+	sum := sha1.Sum([]byte(password))
+	digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	suffixes, err := source.Range(ctx, digest[:prefixLength])
+	if err != nil {
+		return 0, err
+	}
+	return suffixes[digest[prefixLength:]], nil
+}
@@ -0,0 +1,255 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package password
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/clin211/miniblog-v3/pkg/validate"
+)
+
+const (
+	// defaultField 违规项中默认使用的字段名
+	defaultField = "password"
+	// minSimilarLength 参与相似度检查的用户名或邮箱的最小长度，过短的值容易误判
+	minSimilarLength = 3
+)
+
+// ErrInvalidConfig 表示密码策略配置不正确.
+var ErrInvalidConfig = errors.New("password: invalid policy config")
+
+// Config 定义了密码策略，注册、修改密码和重置密码共用同一份策略
+type Config struct {
+	MinLength     int  `json:",default=6"`     // 最小长度，按字符计算
+	MaxLength     int  `json:",default=32"`    // 最大长度，按字符计算
+	RequireLetter bool `json:",default=true"`  // 必须包含字母
+	RequireDigit  bool `json:",default=true"`  // 必须包含数字
+	RequireUpper  bool `json:",default=false"` // 必须包含大写字母
+	RequireLower  bool `json:",default=false"` // 必须包含小写字母
+	RequireSymbol bool `json:",default=false"` // 必须包含符号
+
+	// DisallowSimilar 禁止密码包含用户名或邮箱，或与其逆序相同
+	DisallowSimilar bool `json:",default=true"`
+	// HistorySize 禁止重复使用最近的密码个数，为 0 表示不检查
+	HistorySize int `json:",default=5"`
+
+	// BreachedDir 泄露密码哈希列表所在的目录，为空表示不检查. 目录中每个文件为 <SHA-1 前 5 位>.txt，
+	// 每行的格式为 <SHA-1 其余 35 位>:<泄露次数>，与 Have I Been Pwned 的 range 接口格式一致
+	BreachedDir string `json:",optional"`
+	// BreachedMinCount 泄露次数达到该值时拒绝密码
+	BreachedMinCount int `json:",default=1"`
+}
+
+// Comparer 校验密码与哈希是否匹配，encrypt.Hasher 实现了该接口.
+type Comparer interface {
+	Compare(hashed, password string) error
+}
+
+// Input 为待检查的密码及其上下文.
+type Input struct {
+	Field    string   // 违规项中使用的字段名，为空时使用 password
+	Password string   // 明文密码
+	Username string   // 用户名，用于相似度检查
+	Email    string   // 邮箱，用于相似度检查
+	History  []string // 用户最近使用过的密码哈希，按时间倒序，只检查前 HistorySize 个
+}
+
+// Policy 为密码策略.
+type Policy struct {
+	c        Config
+	comparer Comparer
+	breached BreachedSource
+}
+
+// NewPolicy 根据配置创建密码策略，comparer 用于检查密码历史.
+func NewPolicy(c Config, comparer Comparer) (*Policy, error) {
+	if c.MinLength < 1 || c.MaxLength < c.MinLength {
+		return nil, fmt.Errorf("%w: length range [%d, %d]", ErrInvalidConfig, c.MinLength, c.MaxLength)
+	}
+	if c.HistorySize < 0 {
+		return nil, fmt.Errorf("%w: negative history size", ErrInvalidConfig)
+	}
+	if c.HistorySize > 0 && comparer == nil {
+		return nil, fmt.Errorf("%w: comparer is required when history size > 0", ErrInvalidConfig)
+	}
+
+	p := &Policy{c: c, comparer: comparer}
+	if c.BreachedDir != "" {
+		source, err := NewDirSource(c.BreachedDir)
+		if err != nil {
+			return nil, err
+		}
+		p.breached = source
+	}
+	return p, nil
+}
+
+// MustNewPolicy 根据配置创建密码策略，配置错误时 panic.
+func MustNewPolicy(c Config, comparer Comparer) *Policy {
+	p, err := NewPolicy(c, comparer)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// WithBreachedSource 使用自定义的泄露密码数据源，替换 BreachedDir 配置的目录.
+func (p *Policy) WithBreachedSource(source BreachedSource) *Policy {
+	p.breached = source
+	return p
+}
+
+// HistorySize 返回需要检查的密码历史个数.
+func (p *Policy) HistorySize() int {
+	return p.c.HistorySize
+}
+
+// Check 按策略检查密码，返回所有违规项. 只有内部错误（如读取泄露密码列表失败）才返回 error.
+// 长度、字符类别和相似度检查通过后才检查密码历史和泄露列表，避免不必要的哈希计算和 IO.
+// 违规项中不包含密码明文.
+func (p *Policy) Check(ctx context.Context, in Input) (validate.ValidationErrorsWithCode, error) {
+	field := in.Field
+	if field == "" {
+		field = defaultField
+	}
+
+	var es validate.ValidationErrorsWithCode
+	if n := utf8.RuneCountInString(in.Password); n < p.c.MinLength || n > p.c.MaxLength {
+		e := validate.NewValidationError(validate.ErrorCodeInvalidLength, field,
+			fmt.Sprintf("密码长度必须在 %d 到 %d 个字符之间", p.c.MinLength, p.c.MaxLength),
+			nil, fmt.Sprintf("length(%d|%d)", p.c.MinLength, p.c.MaxLength))
+		e.Params = map[string]string{"min": strconv.Itoa(p.c.MinLength), "max": strconv.Itoa(p.c.MaxLength)}
+		es = append(es, e)
+	}
+	es = append(es, p.checkClasses(field, in.Password)...)
+	if p.c.DisallowSimilar {
+		if e := checkSimilar(field, in); e != nil {
+			es = append(es, e)
+		}
+	}
+	if len(es) > 0 {
+		return es, nil
+	}
+
+	if e := p.checkHistory(field, in); e != nil {
+		return validate.ValidationErrorsWithCode{e}, nil
+	}
+
+	e, err := p.checkBreached(ctx, field, in.Password)
+	if err != nil {
+		return nil, err
+	}
+	if e != nil {
+		return validate.ValidationErrorsWithCode{e}, nil
+	}
+	return nil, nil
+}
+
+// checkClasses 检查密码包含的字符类别.
+func (p *Policy) checkClasses(field, password string) validate.ValidationErrorsWithCode {
+	var letter, digit, upper, lower, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			letter = true
+			upper = upper || unicode.IsUpper(r)
+			lower = lower || unicode.IsLower(r)
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			symbol = true
+		}
+	}
+
+	rules := []struct {
+		required bool
+		ok       bool
+		rule     string
+		message  string
+	}{
+		{p.c.RequireLetter, letter, "letter", "密码必须包含字母"},
+		{p.c.RequireDigit, digit, "digit", "密码必须包含数字"},
+		{p.c.RequireUpper, upper, "upper", "密码必须包含大写字母"},
+		{p.c.RequireLower, lower, "lower", "密码必须包含小写字母"},
+		{p.c.RequireSymbol, symbol, "symbol", "密码必须包含符号"},
+	}
+
+	var es validate.ValidationErrorsWithCode
+	for _, r := range rules {
+		if r.required && !r.ok {
+			es = append(es, validate.NewValidationError(validate.ErrorCodePasswordTooWeak, field, r.message, nil, r.rule))
+		}
+	}
+	return es
+}
+
+// checkSimilar 检查密码是否包含用户名、邮箱或邮箱前缀，或者与它们逆序相同，比较时忽略大小写.
+func checkSimilar(field string, in Input) *validate.ValidationErrorWithCode {
+	password := strings.ToLower(in.Password)
+	email := strings.ToLower(in.Email)
+	local, _, _ := strings.Cut(email, "@")
+
+	for _, s := range []string{strings.ToLower(in.Username), email, local} {
+		if utf8.RuneCountInString(s) < minSimilarLength {
+			continue
+		}
+		if strings.Contains(password, s) || password == reverse(s) {
+			return validate.NewValidationError(validate.ErrorCodePasswordSimilar, field,
+				"密码不能包含用户名或邮箱", nil, "not_similar")
+		}
+	}
+	return nil
+}
+
+// checkHistory 检查密码是否与最近使用过的密码相同.
+func (p *Policy) checkHistory(field string, in Input) *validate.ValidationErrorWithCode {
+	history := in.History
+	if len(history) > p.c.HistorySize {
+		history = history[:p.c.HistorySize]
+	}
+	for _, hashed := range history {
+		// 无法识别的旧哈希视为不匹配
+		if p.comparer.Compare(hashed, in.Password) == nil {
+			e := validate.NewValidationError(validate.ErrorCodePasswordReused, field,
+				fmt.Sprintf("不能使用最近 %d 次使用过的密码", p.c.HistorySize), nil,
+				fmt.Sprintf("history(%d)", p.c.HistorySize))
+			e.Params = map[string]string{"size": strconv.Itoa(p.c.HistorySize)}
+			return e
+		}
+	}
+	return nil
+}
+
+// checkBreached 检查密码是否出现在泄露密码列表中.
+func (p *Policy) checkBreached(ctx context.Context, field, password string) (*validate.ValidationErrorWithCode, error) {
+	if p.breached == nil {
+		return nil, nil
+	}
+	count, err := breachedCount(ctx, p.breached, password)
+	if err != nil {
+		return nil, err
+	}
+	if count < max(p.c.BreachedMinCount, 1) {
+		return nil, nil
+	}
+	return validate.NewValidationError(validate.ErrorCodePasswordBreached, field,
+		"该密码已在公开的数据泄露中出现，请更换密码", nil, "not_breached"), nil
+}
+
+// reverse 返回逆序的字符串.
+func reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
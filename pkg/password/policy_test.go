@@ -0,0 +1,128 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package password
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clin211/miniblog-v3/pkg/validate"
+)
+
+// plainComparer 以 "hash:" 前缀模拟哈希，便于测试密码历史
+type plainComparer struct{}
+
+func (plainComparer) Compare(hashed, password string) error {
+	if hashed != "hash:"+password {
+		return errors.New("mismatch")
+	}
+	return nil
+}
+
+func defaultConfig() Config {
+	return Config{
+		MinLength:        6,
+		MaxLength:        32,
+		RequireLetter:    true,
+		RequireDigit:     true,
+		DisallowSimilar:  true,
+		HistorySize:      2,
+		BreachedMinCount: 1,
+	}
+}
+
+func codes(es validate.ValidationErrorsWithCode) []validate.ErrorCode {
+	var result []validate.ErrorCode
+	for _, e := range es {
+		result = append(result, e.Code)
+	}
+	return result
+}
+
+func TestNewPolicy(t *testing.T) {
+	_, err := NewPolicy(Config{MinLength: 8, MaxLength: 6}, nil)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+	_, err = NewPolicy(Config{MinLength: 6, MaxLength: 32, HistorySize: 3}, nil)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+	_, err = NewPolicy(Config{MinLength: 6, MaxLength: 32, BreachedDir: filepath.Join(t.TempDir(), "missing")}, nil)
+	assert.Error(t, err)
+}
+
+func TestCheck(t *testing.T) {
+	p := MustNewPolicy(defaultConfig(), plainComparer{})
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		input Input
+		want  []validate.ErrorCode
+	}{
+		{"合法密码", Input{Password: "s3cretPass", Username: "alice", Email: "alice@example.com"}, nil},
+		{"过短且缺少数字", Input{Password: "abc"}, []validate.ErrorCode{validate.ErrorCodeInvalidLength, validate.ErrorCodePasswordTooWeak}},
+		{"按字符计算长度", Input{Password: "密码密码密1"}, nil},
+		{"包含用户名", Input{Password: "Alice2025", Username: "alice"}, []validate.ErrorCode{validate.ErrorCodePasswordSimilar}},
+		{"包含邮箱前缀", Input{Password: "bob.smith1", Email: "bob.smith@example.com"}, []validate.ErrorCode{validate.ErrorCodePasswordSimilar}},
+		{"用户名逆序", Input{Password: "1ecila", Username: "alice1"}, []validate.ErrorCode{validate.ErrorCodePasswordSimilar}},
+		{"最近使用过", Input{Password: "s3cretPass", History: []string{"hash:other1", "hash:s3cretPass"}}, []validate.ErrorCode{validate.ErrorCodePasswordReused}},
+		{"超出历史个数", Input{Password: "s3cretPass", History: []string{"hash:a1", "hash:b2", "hash:s3cretPass"}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es, err := p.Check(ctx, tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, codes(es))
+			for _, e := range es {
+				assert.Equal(t, defaultField, e.Field)
+				assert.Nil(t, e.Value)
+			}
+		})
+	}
+
+	c := defaultConfig()
+	c.RequireUpper, c.RequireLower, c.RequireSymbol = true, true, true
+	p = MustNewPolicy(c, plainComparer{})
+	es, err := p.Check(ctx, Input{Field: "newPassword", Password: "abcdef12"})
+	require.NoError(t, err)
+	require.Len(t, es, 2)
+	assert.Equal(t, "upper", es[0].Rule)
+	assert.Equal(t, "symbol", es[1].Rule)
+	assert.Equal(t, "newPassword", es[0].Field)
+
+	es, err = p.Check(ctx, Input{Password: "Abcdef12!"})
+	require.NoError(t, err)
+	assert.Empty(t, es)
+}
+
+func TestCheckBreached(t *testing.T) {
+	dir := t.TempDir()
+	// SHA-1("password1") = E38AD214943DAAD1D64C102FAEC29DE4AFE9DA3D
+	content := "0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n214943DAAD1D64C102FAEC29DE4AFE9DA3D:2427158\r\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "E38AD.txt"), []byte(content), 0o644))
+
+	c := defaultConfig()
+	c.BreachedDir = dir
+	p := MustNewPolicy(c, plainComparer{})
+
+	es, err := p.Check(context.Background(), Input{Password: "password1"})
+	require.NoError(t, err)
+	assert.Equal(t, []validate.ErrorCode{validate.ErrorCodePasswordBreached}, codes(es))
+
+	es, err = p.Check(context.Background(), Input{Password: "s3cretPass"})
+	require.NoError(t, err)
+	assert.Empty(t, es)
+
+	c.BreachedMinCount = 3000000
+	p = MustNewPolicy(c, plainComparer{})
+	es, err = p.Check(context.Background(), Input{Password: "password1"})
+	require.NoError(t, err)
+	assert.Empty(t, es)
+}
@@ -0,0 +1,579 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+// Package qrcode 实现 QR 码（ISO/IEC 18004）编码，并渲染为 PNG 或 SVG.
+//
+// 内容统一使用字节模式编码，自动选择能容纳内容的最小版本和惩罚分最低的掩码，
+// 适用于短链等 URL 类内容，由 shortlink-api 的 GET /s/:code/qr 使用.
+package qrcode
+
+import (
+	"errors"
+	"strings"
+)
+
+// Level 为纠错等级，等级越高可恢复的损坏面积越大，同样内容生成的码也越密.
+type Level int
+
+const (
+	// Low 约可恢复 7% 的码字
+	Low Level = iota
+	// Medium 约可恢复 15% 的码字
+	Medium
+	// Quartile 约可恢复 25% 的码字
+	Quartile
+	// High 约可恢复 30% 的码字，中间叠加 logo 时建议使用
+	High
+)
+
+const (
+	// MinVersion 最小版本
+	MinVersion = 1
+	// MaxVersion 最大版本
+	MaxVersion = 40
+)
+
+var (
+	// ErrInvalidLevel 表示纠错等级不合法.
+	ErrInvalidLevel = errors.New("qrcode: invalid error correction level")
+	// ErrContentTooLong 表示内容超过了最大版本的容量.
+	ErrContentTooLong = errors.New("qrcode: content too long")
+)
+
+// ParseLevel 解析纠错等级，支持 L、M、Q、H，忽略大小写.
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToUpper(s) {
+	case "L":
+		return Low, nil
+	case "M":
+		return Medium, nil
+	case "Q":
+		return Quartile, nil
+	case "H":
+		return High, nil
+	default:
+		return 0, ErrInvalidLevel
+	}
+}
+
+// String 返回纠错等级的字母表示.
+func (l Level) String() string {
+	switch l {
+	case Low:
+		return "L"
+	case Medium:
+		return "M"
+	case Quartile:
+		return "Q"
+	case High:
+		return "H"
+	default:
+		return "?"
+	}
+}
+
+// QRCode 为编码后的 QR 码.
+type QRCode struct {
+	version int
+	level   Level
+	mask    int
+	size    int
+
+	modules    [][]bool // 模块颜色，true 为深色
+	isFunction [][]bool // 是否为功能图形，功能图形不参与数据填充和掩码
+}
+
+// New 使用字节模式编码内容.
+func New(content string, level Level) (*QRCode, error) {
+	if level < Low || level > High {
+		return nil, ErrInvalidLevel
+	}
+
+	data := []byte(content)
+	version := MinVersion
+	for ; version <= MaxVersion; version++ {
+		if dataBitsLen(len(data), version) <= numDataCodewords(version, level)*8 {
+			break
+		}
+	}
+	if version > MaxVersion {
+		return nil, ErrContentTooLong
+	}
+
+	q := &QRCode{
+		version: version,
+		level:   level,
+		size:    version*4 + 17,
+	}
+	q.modules = newGrid(q.size)
+	q.isFunction = newGrid(q.size)
+
+	q.drawFunctionPatterns()
+	q.drawCodewords(q.addECCAndInterleave(encodeData(data, version, level)))
+	q.applyBestMask()
+	return q, nil
+}
+
+// Version 返回版本号，1~40.
+func (q *QRCode) Version() int {
+	return q.version
+}
+
+// Level 返回纠错等级.
+func (q *QRCode) Level() Level {
+	return q.level
+}
+
+// Size 返回每边的模块数，不含静区.
+func (q *QRCode) Size() int {
+	return q.size
+}
+
+// Dark 返回 (x, y) 处的模块是否为深色，超出范围时返回 false.
+func (q *QRCode) Dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= q.size || y >= q.size {
+		return false
+	}
+	return q.modules[y][x]
+}
+
+// dataBitsLen 返回字节模式下编码 n 个字节所需的位数.
+func dataBitsLen(n, version int) int {
+	return 4 + charCountBits(version) + n*8
+}
+
+// charCountBits 返回字节模式下字符计数指示符的位数.
+func charCountBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// encodeData 将内容编码为数据码字：模式指示符、字符计数、数据、终止符和填充.
+func encodeData(data []byte, version int, level Level) []byte {
+	capacity := numDataCodewords(version, level) * 8
+
+	var bb bitBuffer
+	bb.append(0x4, 4) // 字节模式
+	bb.append(len(data), charCountBits(version))
+	for _, b := range data {
+		bb.append(int(b), 8)
+	}
+
+	bb.append(0, min(4, capacity-bb.len()))
+	bb.append(0, (8-bb.len()%8)%8)
+	for pad := 0xEC; bb.len() < capacity; pad ^= 0xEC ^ 0x11 {
+		bb.append(pad, 8)
+	}
+	return bb.bytes()
+}
+
+// addECCAndInterleave 将数据码字分块、计算纠错码字并交织.
+func (q *QRCode) addECCAndInterleave(data []byte) []byte {
+	numBlocks := numErrorCorrectionBlocks[q.level][q.version]
+	blockECCLen := eccCodewordsPerBlock[q.level][q.version]
+	rawCodewords := numRawDataModules(q.version) / 8
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	divisor := reedSolomonDivisor(blockECCLen)
+	blocks := make([][]byte, 0, numBlocks)
+	for i, k := 0, 0; i < numBlocks; i++ {
+		datLen := shortBlockLen - blockECCLen
+		if i >= numShortBlocks {
+			datLen++
+		}
+		dat := data[k : k+datLen]
+		k += datLen
+
+		block := make([]byte, 0, shortBlockLen+1)
+		block = append(block, dat...)
+		if i < numShortBlocks {
+			// 短块补一个占位码字，使所有块等长，交织时跳过
+			block = append(block, 0)
+		}
+		block = append(block, reedSolomonRemainder(dat, divisor)...)
+		blocks = append(blocks, block)
+	}
+
+	result := make([]byte, 0, rawCodewords)
+	for i := range blocks[0] {
+		for j, block := range blocks {
+			if i != shortBlockLen-blockECCLen || j >= numShortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+// drawFunctionPatterns 绘制定位图形、分隔符、定时图形、校正图形以及格式和版本信息.
+func (q *QRCode) drawFunctionPatterns() {
+	for i := 0; i < q.size; i++ {
+		q.setFunction(6, i, i%2 == 0)
+		q.setFunction(i, 6, i%2 == 0)
+	}
+
+	q.drawFinderPattern(3, 3)
+	q.drawFinderPattern(q.size-4, 3)
+	q.drawFinderPattern(3, q.size-4)
+
+	positions := alignmentPatternPositions(q.version)
+	n := len(positions)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			// 与定位图形重叠的三个位置不绘制
+			if (i == 0 && j == 0) || (i == 0 && j == n-1) || (i == n-1 && j == 0) {
+				continue
+			}
+			q.drawAlignmentPattern(positions[i], positions[j])
+		}
+	}
+
+	// 先占位格式信息，选定掩码后再写入
+	q.drawFormatBits(0)
+	q.drawVersion()
+}
+
+// drawFinderPattern 以 (x, y) 为中心绘制定位图形及其分隔符.
+func (q *QRCode) drawFinderPattern(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || yy < 0 || xx >= q.size || yy >= q.size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			q.setFunction(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+// drawAlignmentPattern 以 (x, y) 为中心绘制校正图形.
+func (q *QRCode) drawAlignmentPattern(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			q.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// drawFormatBits 绘制纠错等级和掩码的格式信息，两份副本分别位于左上角和右上、左下角.
+func (q *QRCode) drawFormatBits(mask int) {
+	bits := formatInfo(q.level, mask)
+
+	for i := 0; i <= 5; i++ {
+		q.setFunction(8, i, bit(bits, i))
+	}
+	q.setFunction(8, 7, bit(bits, 6))
+	q.setFunction(8, 8, bit(bits, 7))
+	q.setFunction(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		q.setFunction(14-i, 8, bit(bits, i))
+	}
+
+	for i := 0; i < 8; i++ {
+		q.setFunction(q.size-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		q.setFunction(8, q.size-15+i, bit(bits, i))
+	}
+	// 固定的深色模块
+	q.setFunction(8, q.size-8, true)
+}
+
+// drawVersion 绘制版本信息，仅版本 7 及以上需要.
+func (q *QRCode) drawVersion() {
+	if q.version < 7 {
+		return
+	}
+
+	bits := versionInfo(q.version)
+	for i := 0; i < 18; i++ {
+		dark := bit(bits, i)
+		a, b := q.size-11+i%3, i/3
+		q.setFunction(a, b, dark)
+		q.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords 按之字形顺序从右下角开始填充数据和纠错码字.
+func (q *QRCode) drawCodewords(data []byte) {
+	i := 0
+	for right := q.size - 1; right >= 1; right -= 2 {
+		// 跳过竖直定时图形所在的列
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < q.size; vert++ {
+			y := vert
+			if upward {
+				y = q.size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if q.isFunction[y][x] || i >= len(data)*8 {
+					continue
+				}
+				q.modules[y][x] = bit(int(data[i>>3]), 7-i&7)
+				i++
+			}
+		}
+	}
+}
+
+// applyBestMask 依次尝试 8 种掩码，选择惩罚分最低的一种.
+func (q *QRCode) applyBestMask() {
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask)
+		q.drawFormatBits(mask)
+		if p := q.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		// 掩码为异或运算，再次应用即可还原
+		q.applyMask(mask)
+	}
+
+	q.mask = best
+	q.applyMask(best)
+	q.drawFormatBits(best)
+}
+
+// applyMask 对数据区域应用掩码.
+func (q *QRCode) applyMask(mask int) {
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if !q.isFunction[y][x] && maskBit(mask, x, y) {
+				q.modules[y][x] = !q.modules[y][x]
+			}
+		}
+	}
+}
+
+// maskBit 返回掩码在 (x, y) 处是否翻转模块.
+func maskBit(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+// penalty 按标准的四条规则计算当前符号的惩罚分.
+func (q *QRCode) penalty() int {
+	result := 0
+
+	// 规则 1 和 3：行列中连续同色模块以及类似定位图形的序列
+	for i := 0; i < q.size; i++ {
+		row := make([]bool, q.size)
+		col := make([]bool, q.size)
+		for j := 0; j < q.size; j++ {
+			row[j] = q.modules[i][j]
+			col[j] = q.modules[j][i]
+		}
+		result += runPenalty(row) + finderLikePenalty(row)
+		result += runPenalty(col) + finderLikePenalty(col)
+	}
+
+	// 规则 2：2x2 同色块
+	dark := 0
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if q.modules[y][x] {
+				dark++
+			}
+			if x < q.size-1 && y < q.size-1 {
+				c := q.modules[y][x]
+				if c == q.modules[y][x+1] && c == q.modules[y+1][x] && c == q.modules[y+1][x+1] {
+					result += 3
+				}
+			}
+		}
+	}
+
+	// 规则 4：深色模块比例偏离 50% 的程度
+	total := q.size * q.size
+	result += abs(dark*100/total-50) / 5 * 10
+	return result
+}
+
+// runPenalty 计算连续 5 个及以上同色模块的惩罚分.
+func runPenalty(line []bool) int {
+	result := 0
+	run := 1
+	for i := 1; i <= len(line); i++ {
+		if i < len(line) && line[i] == line[i-1] {
+			run++
+			continue
+		}
+		if run >= 5 {
+			result += 3 + run - 5
+		}
+		run = 1
+	}
+	return result
+}
+
+// finderLikePattern 为 1:1:3:1:1 的类定位图形，一侧带 4 个浅色模块.
+var (
+	finderLikeBefore = []bool{false, false, false, false, true, false, true, true, true, false, true}
+	finderLikeAfter  = []bool{true, false, true, true, true, false, true, false, false, false, false}
+)
+
+// finderLikePenalty 计算类定位图形序列的惩罚分.
+func finderLikePenalty(line []bool) int {
+	result := 0
+	for i := 0; i+len(finderLikeBefore) <= len(line); i++ {
+		if matchAt(line, i, finderLikeBefore) {
+			result += 40
+		}
+		if matchAt(line, i, finderLikeAfter) {
+			result += 40
+		}
+	}
+	return result
+}
+
+// matchAt 判断 line 从 i 开始是否与 pattern 相同.
+func matchAt(line []bool, i int, pattern []bool) bool {
+	for j, p := range pattern {
+		if line[i+j] != p {
+			return false
+		}
+	}
+	return true
+}
+
+// setFunction 设置功能图形模块.
+func (q *QRCode) setFunction(x, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.isFunction[y][x] = true
+}
+
+// formatInfo 计算 15 位格式信息：纠错等级和掩码的 BCH(15,5) 编码，再与固定掩码异或.
+func formatInfo(level Level, mask int) int {
+	data := formatBits[level]<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// versionInfo 计算 18 位版本信息：版本号的 BCH(18,6) 编码.
+func versionInfo(version int) int {
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	return version<<12 | rem
+}
+
+// reedSolomonDivisor 返回指定次数的 Reed-Solomon 生成多项式系数，最高次项系数 1 省略.
+func reedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+// reedSolomonRemainder 计算数据多项式除以生成多项式的余数，即纠错码字.
+func reedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, d := range divisor {
+			result[i] ^= gfMultiply(d, factor)
+		}
+	}
+	return result
+}
+
+// gfMultiply 为 GF(2^8) 上的乘法，本原多项式为 x^8 + x^4 + x^3 + x^2 + 1.
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+// bitBuffer 为按位追加的缓冲区.
+type bitBuffer struct {
+	bits []bool
+}
+
+// append 追加 val 的低 n 位，高位在前.
+func (bb *bitBuffer) append(val, n int) {
+	for i := n - 1; i >= 0; i-- {
+		bb.bits = append(bb.bits, (val>>i)&1 != 0)
+	}
+}
+
+// len 返回位数.
+func (bb *bitBuffer) len() int {
+	return len(bb.bits)
+}
+
+// bytes 将位序列按高位在前打包为字节，位数必须是 8 的倍数.
+func (bb *bitBuffer) bytes() []byte {
+	result := make([]byte, len(bb.bits)/8)
+	for i, b := range bb.bits {
+		if b {
+			result[i>>3] |= 1 << (7 - i&7)
+		}
+	}
+	return result
+}
+
+// bit 返回 x 的第 i 位是否为 1.
+func bit(x, i int) bool {
+	return (x>>i)&1 != 0
+}
+
+// newGrid 创建 size x size 的二维数组.
+func newGrid(size int) [][]bool {
+	grid := make([][]bool, size)
+	for i := range grid {
+		grid[i] = make([]bool, size)
+	}
+	return grid
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,241 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package qrcode
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReedSolomon(t *testing.T) {
+	// ISO/IEC 18004 附录中 "HELLO WORLD" 1-M 的数据码字和纠错码字
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	assert.Equal(t, want, reedSolomonRemainder(data, reedSolomonDivisor(len(want))))
+}
+
+func TestFormatAndVersionInfo(t *testing.T) {
+	assert.Equal(t, 0b111011111000100, formatInfo(Low, 0))
+	assert.Equal(t, 0b110011000101111, formatInfo(Low, 4))
+	assert.Equal(t, 0b101010000010010, formatInfo(Medium, 0))
+	assert.Equal(t, 0b001011010001001, formatInfo(High, 0))
+
+	assert.Equal(t, 0b000111110010010100, versionInfo(7))
+	assert.Equal(t, 0b101000110001101001, versionInfo(40))
+}
+
+func TestCapacity(t *testing.T) {
+	// 标准中字节模式的容量
+	tests := []struct {
+		version  int
+		level    Level
+		capacity int
+	}{
+		{1, Low, 17},
+		{1, High, 7},
+		{5, Quartile, 60},
+		{10, Medium, 213},
+		{40, Low, 2953},
+		{40, High, 1273},
+	}
+	for _, tt := range tests {
+		bits := numDataCodewords(tt.version, tt.level)*8 - 4 - charCountBits(tt.version)
+		assert.Equal(t, tt.capacity, bits/8, "version %d level %s", tt.version, tt.level)
+	}
+}
+
+func TestAlignmentPatternPositions(t *testing.T) {
+	assert.Empty(t, alignmentPatternPositions(1))
+	assert.Equal(t, []int{6, 18}, alignmentPatternPositions(2))
+	assert.Equal(t, []int{6, 22, 38}, alignmentPatternPositions(7))
+	assert.Equal(t, []int{6, 34, 60, 86, 112, 138}, alignmentPatternPositions(32))
+	assert.Equal(t, []int{6, 30, 58, 86, 114, 142, 170}, alignmentPatternPositions(40))
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		content string
+		level   Level
+		version int
+	}{
+		{"https://clin.pro/s/AB23CD45", Low, 2},
+		{"https://clin.pro/s/AB23CD45", High, 4},
+		{strings.Repeat("a", 200), Medium, 10},
+		{strings.Repeat("中", 300), Quartile, 29},
+	}
+	for _, tt := range tests {
+		q, err := New(tt.content, tt.level)
+		require.NoError(t, err)
+		assert.Equal(t, tt.version, q.Version())
+		assert.Equal(t, tt.version*4+17, q.Size())
+
+		// 定位图形
+		for _, c := range [][2]int{{0, 0}, {q.Size() - 7, 0}, {0, q.Size() - 7}} {
+			assert.True(t, q.Dark(c[0], c[1]))
+			assert.False(t, q.Dark(c[0]+1, c[1]+1))
+			assert.True(t, q.Dark(c[0]+3, c[1]+3))
+		}
+		// 固定的深色模块
+		assert.True(t, q.Dark(8, q.Size()-8))
+
+		// 从符号中读回格式信息和数据，验证与编码时一致
+		assert.Equal(t, []byte(tt.content), decode(t, q))
+	}
+
+	_, err := New(strings.Repeat("a", 2954), Low)
+	assert.ErrorIs(t, err, ErrContentTooLong)
+	_, err = New("a", Level(4))
+	assert.ErrorIs(t, err, ErrInvalidLevel)
+}
+
+func TestParseLevel(t *testing.T) {
+	for s, want := range map[string]Level{"l": Low, "M": Medium, "q": Quartile, "H": High} {
+		level, err := ParseLevel(s)
+		require.NoError(t, err)
+		assert.Equal(t, want, level)
+	}
+	_, err := ParseLevel("X")
+	assert.ErrorIs(t, err, ErrInvalidLevel)
+}
+
+func TestRender(t *testing.T) {
+	q, err := New("https://clin.pro/s/AB23CD45", Medium)
+	require.NoError(t, err)
+
+	data, err := q.PNG(RenderOptions{Size: 256})
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 256, 256), img.Bounds())
+
+	// 左上角为静区，定位图形左上角为深色
+	scale, offset, err := q.layout(256)
+	require.NoError(t, err)
+	assert.Equal(t, color.GrayModel.Convert(color.White), color.GrayModel.Convert(img.At(0, 0)))
+	assert.Equal(t, color.GrayModel.Convert(color.Black), color.GrayModel.Convert(img.At(offset+scale/2, offset+scale/2)))
+
+	logo := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	data, err = q.PNG(RenderOptions{Size: 256, Logo: logo})
+	require.NoError(t, err)
+	_, err = png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	svg, err := q.SVG(RenderOptions{Size: 256, Logo: logo})
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(svg, []byte("<svg ")))
+	assert.Contains(t, string(svg), "data:image/png;base64,")
+
+	_, err = q.PNG(RenderOptions{Size: 10})
+	assert.ErrorIs(t, err, ErrSizeTooSmall)
+}
+
+// decode 按标准的读取顺序从符号中解出数据，仅支持本包生成的字节模式符号.
+func decode(t *testing.T, q *QRCode) []byte {
+	t.Helper()
+
+	// 1. 读取两份格式信息并确认纠错等级和掩码
+	var first, second int
+	for i := 0; i <= 5; i++ {
+		first |= boolToInt(q.modules[i][8]) << i
+	}
+	first |= boolToInt(q.modules[7][8])<<6 | boolToInt(q.modules[8][8])<<7 | boolToInt(q.modules[8][7])<<8
+	for i := 9; i < 15; i++ {
+		first |= boolToInt(q.modules[8][14-i]) << i
+	}
+	for i := 0; i < 8; i++ {
+		second |= boolToInt(q.modules[8][q.size-1-i]) << i
+	}
+	for i := 8; i < 15; i++ {
+		second |= boolToInt(q.modules[q.size-15+i][8]) << i
+	}
+	require.Equal(t, formatInfo(q.level, q.mask), first)
+	require.Equal(t, first, second)
+
+	// 2. 去掉掩码后按之字形顺序读出码字
+	var bb bitBuffer
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < q.size; vert++ {
+			y := vert
+			if upward {
+				y = q.size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if !q.isFunction[y][x] {
+					bb.bits = append(bb.bits, q.modules[y][x] != maskBit(q.mask, x, y))
+				}
+			}
+		}
+	}
+	bb.bits = bb.bits[:numRawDataModules(q.version)/8*8]
+	codewords := bb.bytes()
+
+	// 3. 反交织并校验每个块的纠错码字
+	numBlocks := numErrorCorrectionBlocks[q.level][q.version]
+	eccLen := eccCodewordsPerBlock[q.level][q.version]
+	numShortBlocks := numBlocks - len(codewords)%numBlocks
+	shortDataLen := len(codewords)/numBlocks - eccLen
+
+	blocks := make([][]byte, numBlocks)
+	k := 0
+	for i := 0; i < shortDataLen+1; i++ {
+		for j := range blocks {
+			if i < shortDataLen || j >= numShortBlocks {
+				blocks[j] = append(blocks[j], codewords[k])
+				k++
+			}
+		}
+	}
+	var data []byte
+	divisor := reedSolomonDivisor(eccLen)
+	for j := range blocks {
+		ecc := make([]byte, eccLen)
+		for i := range ecc {
+			ecc[i] = codewords[k+i*numBlocks+j]
+		}
+		require.Equal(t, reedSolomonRemainder(blocks[j], divisor), ecc)
+		data = append(data, blocks[j]...)
+	}
+
+	// 4. 解析字节模式的数据段
+	require.Equal(t, byte(0x4), data[0]>>4)
+	var bits bitBuffer
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+	read := func(pos, n int) int {
+		v := 0
+		for i := 0; i < n; i++ {
+			v = v<<1 | boolToInt(bits.bits[pos+i])
+		}
+		return v
+	}
+	ccBits := charCountBits(q.version)
+	n := read(4, ccBits)
+	result := make([]byte, n)
+	for i := range result {
+		result[i] = byte(read(4+ccBits+i*8, 8))
+	}
+	return result
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
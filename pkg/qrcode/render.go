@@ -0,0 +1,165 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package qrcode
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+)
+
+const (
+	// quietZone 静区宽度，标准要求四周至少留出 4 个模块的空白
+	quietZone = 4
+	// logoRatio logo 边长占符号边长的最大比例，过大会超出纠错能力
+	logoRatio = 0.2
+	// logoPadding logo 四周白色边框的宽度，单位为像素
+	logoPadding = 4
+)
+
+// ErrSizeTooSmall 表示图片尺寸不足以让每个模块至少占一个像素.
+var ErrSizeTooSmall = errors.New("qrcode: image size too small")
+
+// RenderOptions 为渲染选项.
+type RenderOptions struct {
+	// Size 图片边长，单位为像素，含静区. 模块按整数像素绘制，多余的像素均分到四周的静区.
+	Size int
+	// Logo 叠加在中间的 logo，为空表示不叠加. logo 会遮挡部分数据模块，应配合 Quartile 或 High 纠错等级使用.
+	Logo image.Image
+}
+
+// PNG 将 QR 码渲染为 PNG 图片.
+func (q *QRCode) PNG(opts RenderOptions) ([]byte, error) {
+	scale, offset, err := q.layout(opts.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	var img draw.Image
+	if opts.Logo == nil {
+		// 没有 logo 时使用双色调色板，图片体积更小
+		img = image.NewPaletted(image.Rect(0, 0, opts.Size, opts.Size), color.Palette{color.White, color.Black})
+	} else {
+		img = image.NewRGBA(image.Rect(0, 0, opts.Size, opts.Size))
+	}
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if !q.modules[y][x] {
+				continue
+			}
+			rect := image.Rect(offset+x*scale, offset+y*scale, offset+(x+1)*scale, offset+(y+1)*scale)
+			draw.Draw(img, rect, image.Black, image.Point{}, draw.Src)
+		}
+	}
+
+	if opts.Logo != nil {
+		box := q.logoBox(opts.Logo.Bounds(), scale, offset)
+		draw.Draw(img, box.Inset(-logoPadding), image.White, image.Point{}, draw.Src)
+		drawScaled(img, box, opts.Logo)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SVG 将 QR 码渲染为 SVG 图片，logo 以内嵌的 PNG 图片叠加.
+func (q *QRCode) SVG(opts RenderOptions) ([]byte, error) {
+	scale, offset, err := q.layout(opts.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		opts.Size, opts.Size, opts.Size, opts.Size)
+	b.WriteString(`<rect width="100%" height="100%" fill="#ffffff"/>`)
+
+	// 将每行连续的深色模块合并为一个矩形，减小文件体积
+	b.WriteString(`<path fill="#000000" d="`)
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; {
+			if !q.modules[y][x] {
+				x++
+				continue
+			}
+			start := x
+			for x < q.size && q.modules[y][x] {
+				x++
+			}
+			w := (x - start) * scale
+			fmt.Fprintf(&b, "M%d %dh%dv%dh-%dz", offset+start*scale, offset+y*scale, w, scale, w)
+		}
+	}
+	b.WriteString(`"/>`)
+
+	if opts.Logo != nil {
+		box := q.logoBox(opts.Logo.Bounds(), scale, offset)
+		bg := box.Inset(-logoPadding)
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="#ffffff"/>`, bg.Min.X, bg.Min.Y, bg.Dx(), bg.Dy())
+
+		var logo bytes.Buffer
+		if err := png.Encode(&logo, opts.Logo); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&b, `<image x="%d" y="%d" width="%d" height="%d" href="data:image/png;base64,%s"/>`,
+			box.Min.X, box.Min.Y, box.Dx(), box.Dy(), base64.StdEncoding.EncodeToString(logo.Bytes()))
+	}
+
+	b.WriteString(`</svg>`)
+	return []byte(b.String()), nil
+}
+
+// layout 计算每个模块的像素数和符号左上角的偏移.
+func (q *QRCode) layout(size int) (scale, offset int, err error) {
+	total := q.size + quietZone*2
+	scale = size / total
+	if scale < 1 {
+		return 0, 0, ErrSizeTooSmall
+	}
+	return scale, (size - q.size*scale) / 2, nil
+}
+
+// logoBox 返回 logo 在图片中的位置，保持 logo 的宽高比并居中.
+func (q *QRCode) logoBox(bounds image.Rectangle, scale, offset int) image.Rectangle {
+	symbol := q.size * scale
+	limit := int(float64(symbol) * logoRatio)
+
+	w, h := bounds.Dx(), bounds.Dy()
+	if w >= h {
+		w, h = limit, max(1, h*limit/max(1, w))
+	} else {
+		w, h = max(1, w*limit/h), limit
+	}
+
+	x := offset + (symbol-w)/2
+	y := offset + (symbol-h)/2
+	return image.Rect(x, y, x+w, y+h)
+}
+
+// drawScaled 使用最近邻插值将 src 缩放绘制到 dst 的 r 区域，透明像素与白色背景混合.
+func drawScaled(dst draw.Image, r image.Rectangle, src image.Image) {
+	sb := src.Bounds()
+	scaled := image.NewRGBA(image.Rect(0, 0, r.Dx(), r.Dy()))
+	for y := 0; y < r.Dy(); y++ {
+		sy := sb.Min.Y + y*sb.Dy()/r.Dy()
+		for x := 0; x < r.Dx(); x++ {
+			sx := sb.Min.X + x*sb.Dx()/r.Dx()
+			scaled.Set(x, y, src.At(sx, sy))
+		}
+	}
+	draw.Draw(dst, r, scaled, image.Point{}, draw.Over)
+}
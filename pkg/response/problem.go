@@ -0,0 +1,108 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const (
+	// ContentTypeProblem RFC 7807 错误响应的 Content-Type
+	ContentTypeProblem = "application/problem+json"
+	// ProblemTypePrefix problem type 的前缀，后接业务错误码，如 urn:miniblog:error:404101
+	ProblemTypePrefix = "urn:miniblog:error:"
+)
+
+// Problem RFC 7807 错误响应结构，code、request_id、data、errors 为扩展字段.
+type Problem struct {
+	Type      string       `json:"type"`                 // 错误类型
+	Title     string       `json:"title"`                // 错误信息
+	Status    int          `json:"status"`               // HTTP 状态码
+	Detail    string       `json:"detail,omitempty"`     // 错误原因
+	Instance  string       `json:"instance,omitempty"`   // 请求路径
+	Code      int          `json:"code"`                 // 业务错误码
+	RequestID string       `json:"request_id,omitempty"` // 请求 ID
+	Data      any          `json:"data,omitempty"`       // 附加数据
+	Errors    []FieldError `json:"errors,omitempty"`     // 字段校验错误
+}
+
+// FieldError 单个字段的校验错误.
+type FieldError struct {
+	Field    string   `json:"field"`
+	Messages []string `json:"messages"`
+}
+
+type problemKey struct{}
+
+// WithProblem 标记当前请求的错误以 application/problem+json 输出，instance 通常为请求路径.
+func WithProblem(ctx context.Context, instance string) context.Context {
+	return context.WithValue(ctx, problemKey{}, instance)
+}
+
+// problemFromContext 返回当前请求是否以 problem+json 输出错误以及 instance
+func problemFromContext(ctx context.Context) (string, bool) {
+	instance, ok := ctx.Value(problemKey{}).(string)
+	return instance, ok
+}
+
+// AcceptsProblem 判断请求的 Accept 头是否包含 application/problem+json.
+func AcceptsProblem(r *http.Request) bool {
+	for _, v := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(v, ",") {
+			mt, _, err := mime.ParseMediaType(part)
+			if err == nil && mt == ContentTypeProblem {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fieldErrors 将 ToMap 的结果转换为数组，字段按首次出现的顺序排列，不修改 m
+func fieldErrors(fields []string, m map[string][]string) []FieldError {
+	result := make([]FieldError, 0, len(m))
+	seen := make(map[string]bool, len(m))
+	for _, field := range fields {
+		if msgs, ok := m[field]; ok && !seen[field] {
+			result = append(result, FieldError{Field: field, Messages: msgs})
+			seen[field] = true
+		}
+	}
+	return result
+}
+
+// writeProblem 以 application/problem+json 写出失败结果
+func writeProblem(ctx context.Context, w http.ResponseWriter, instance string, f *failure) {
+	body, err := json.Marshal(Problem{
+		Type:      ProblemTypePrefix + strconv.Itoa(f.code),
+		Title:     f.message,
+		Status:    f.status,
+		Detail:    f.reason,
+		Instance:  instance,
+		Code:      f.code,
+		RequestID: f.requestID,
+		Data:      f.data,
+		Errors:    f.fields,
+	})
+	if err != nil {
+		logx.WithContext(ctx).Errorw("序列化 problem 失败", logx.Field("error", err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentTypeProblem)
+	w.WriteHeader(f.status)
+	if _, err := w.Write(body); err != nil {
+		logx.WithContext(ctx).Errorw("写出 problem 失败", logx.Field("error", err))
+	}
+}
@@ -7,20 +7,38 @@ package response
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strings"
 
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/trace"
 	"github.com/zeromicro/go-zero/rest/httpx"
 
 	"github.com/clin211/miniblog-v3/pkg/errorx"
+	"github.com/clin211/miniblog-v3/pkg/i18n"
+	"github.com/clin211/miniblog-v3/pkg/known"
 	"github.com/clin211/miniblog-v3/pkg/validate"
 )
 
 // responseBody 定义统一的响应结构体（不包含 HTTP 字段）
 type responseBody struct {
-	Code    int         `json:"code"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data"`
-	Reason  string      `json:"reason"`
+	Code      int         `json:"code"`
+	Message   string      `json:"message"`
+	Data      interface{} `json:"data"`
+	Reason    string      `json:"reason"`
+	RequestID string      `json:"request_id,omitempty"`
+}
+
+// failure 为错误转换后的响应内容，按请求选择的格式写出
+type failure struct {
+	status    int
+	code      int
+	message   string
+	reason    string
+	data      any
+	fields    []FieldError
+	requestID string
 }
 
 // SuccessCtx 返回成功结果，HTTP 200。
@@ -38,60 +56,86 @@ func FailCtx(ctx context.Context, w http.ResponseWriter, code int, message strin
 // WriteResponse 统一入口：
 // - 如果 v 为 error：
 //   - errorx.Errno：按 Errno.HTTP 写入 HTTP 状态，业务结构取 Code/Message/Data/Reason。
-//   - validate.ValidationErrors / validate.ValidationErrorsWithCode：HTTP 400，code=0，message="参数校验失败"，reason=[]string。
-//   - 其他 error：HTTP 404，code=404，message="Not Found"，reason=err.Error()。
+//   - validate.ValidationErrors / validate.ValidationErrorsWithCode：HTTP 400，code=400002，message="参数校验失败"，reason 为各字段错误。
+//   - 其他 error：HTTP 500，code=500001，不返回错误详情，通过 request_id 关联服务端日志。
 //
 // - 如果 v 非 error：HTTP 200，code=0，message="ok"，data=v。
+//
+// 错误信息按上下文中的语言输出，语言由 middleware.LocaleMiddleware 根据 Accept-Language 协商。
+// 请求经 middleware.ProblemMiddleware 选择 application/problem+json 时，错误按 RFC 7807 输出，字段校验错误放在 errors 数组中。
 func WriteResponse(ctx context.Context, w http.ResponseWriter, v any) {
-	if v == nil {
-		body := responseBody{Code: 0, Message: "ok", Data: nil, Reason: ""}
+	err, ok := v.(error)
+	if !ok {
+		// 成功对象
+		body := responseBody{Code: 0, Message: "ok", Data: v, Reason: ""}
 		httpx.WriteJsonCtx(ctx, w, http.StatusOK, body)
 		return
 	}
 
-	if err, ok := v.(error); ok {
-		// errorx.Errno 分支
-		if e, ok := err.(*errorx.Errno); ok {
-			body := responseBody{Code: e.Code, Message: e.Message, Data: e.Data, Reason: e.Reason}
-			httpx.WriteJsonCtx(ctx, w, e.HTTP, body)
-			return
-		}
+	f := toFailure(ctx, err)
+	if instance, ok := problemFromContext(ctx); ok {
+		writeProblem(ctx, w, instance, f)
+		return
+	}
+	body := responseBody{Code: f.code, Message: f.message, Data: f.data, Reason: f.reason, RequestID: f.requestID}
+	httpx.WriteJsonCtx(ctx, w, f.status, body)
+}
 
-		// validate.ValidationErrorsWithCode
-		if es, ok := err.(validate.ValidationErrorsWithCode); ok {
-			var reasonMsg string
-			for i, ve := range es {
-				if i > 0 {
-					reasonMsg += "; "
-				}
-				reasonMsg += ve.Error()
-			}
-			body := responseBody{Code: errorx.ErrInvalidParameter.Code, Message: "参数校验失败", Data: nil, Reason: reasonMsg}
-			httpx.WriteJsonCtx(ctx, w, http.StatusBadRequest, body)
-			return
-		}
+// toFailure 将错误转换为响应内容
+func toFailure(ctx context.Context, err error) *failure {
+	locale := i18n.FromContext(ctx)
+	f := &failure{requestID: RequestID(ctx)}
+
+	// errorx.Errno 分支
+	var e *errorx.Errno
+	if errors.As(err, &e) {
+		e = e.Localize(locale)
+		f.status, f.code, f.message, f.reason, f.data = e.HTTP, e.Code, e.Message, e.Reason, e.Data
+		return f
+	}
+
+	invalid := errorx.ErrInvalidParameter.Localize(locale)
+	f.status, f.code, f.message = invalid.HTTP, invalid.Code, invalid.Message
 
-		// validate.ValidationErrors（无 code 版本）
-		if es, ok := err.(validate.ValidationErrors); ok {
-			var reasonMsg string
-			for i, ve := range es {
-				if i > 0 {
-					reasonMsg += "; "
-				}
-				reasonMsg += ve.Error()
-			}
-			body := responseBody{Code: errorx.ErrInvalidParameter.Code, Message: "参数校验失败", Data: nil, Reason: reasonMsg}
-			httpx.WriteJsonCtx(ctx, w, http.StatusBadRequest, body)
-			return
+	// validate.ValidationErrorsWithCode
+	if es, ok := err.(validate.ValidationErrorsWithCode); ok {
+		es = es.Localize(locale)
+		reasons := make([]string, len(es))
+		fields := make([]string, len(es))
+		for i, ve := range es {
+			reasons[i] = ve.Describe(locale)
+			fields[i] = ve.Field
 		}
+		f.reason = strings.Join(reasons, "; ")
+		f.fields = fieldErrors(fields, es.ToMap())
+		return f
+	}
 
-		// 其他 error -> Not Found
-		body := responseBody{Code: errorx.ErrResourceNotFound.Code, Message: errorx.ErrResourceNotFound.Message, Data: nil, Reason: err.Error()}
-		httpx.WriteJsonCtx(ctx, w, http.StatusNotFound, body)
-		return
+	// validate.ValidationErrors（无 code 版本）
+	if es, ok := err.(validate.ValidationErrors); ok {
+		es = es.Localize(locale)
+		reasons := make([]string, len(es))
+		fields := make([]string, len(es))
+		for i, ve := range es {
+			reasons[i] = ve.Describe(locale)
+			fields[i] = ve.Field
+		}
+		f.reason = strings.Join(reasons, "; ")
+		f.fields = fieldErrors(fields, es.ToMap())
+		return f
 	}
 
-	// 成功对象
-	body := responseBody{Code: 0, Message: "ok", Data: v, Reason: ""}
-	httpx.WriteJsonCtx(ctx, w, http.StatusOK, body)
+	// 其他 error -> Internal Server Error，错误详情只记录到日志
+	logx.WithContext(ctx).Errorw("未处理的错误", logx.Field("error", err), logx.Field("request_id", f.requestID))
+	internal := errorx.InternalServerError.Localize(locale)
+	f.status, f.code, f.message = internal.HTTP, internal.Code, internal.Message
+	return f
+}
+
+// RequestID 返回上下文中的请求 ID，由 middleware.RequestIDMiddleware 写入，没有时使用链路追踪 ID.
+func RequestID(ctx context.Context) string {
+	if id, ok := ctx.Value(known.XRequestID).(string); ok && id != "" {
+		return id
+	}
+	return trace.TraceIDFromContext(ctx)
 }
@@ -0,0 +1,114 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/clin211/miniblog-v3/pkg/errorx"
+	"github.com/clin211/miniblog-v3/pkg/i18n"
+	"github.com/clin211/miniblog-v3/pkg/known"
+	"github.com/clin211/miniblog-v3/pkg/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+func newContext() context.Context {
+	return context.WithValue(context.Background(), known.XRequestID, "req-1")
+}
+
+func TestWriteResponseUnknownError(t *testing.T) {
+	rr := httptest.NewRecorder()
+	WriteResponse(newContext(), rr, errors.New("dial tcp 10.0.0.1:3306: connection refused"))
+
+	var body responseBody
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, errorx.InternalServerError.Code, body.Code)
+	assert.Equal(t, "req-1", body.RequestID)
+	// 内部错误详情不应返回给调用方
+	assert.Empty(t, body.Reason)
+}
+
+func TestWriteResponseProblem(t *testing.T) {
+	ctx := WithProblem(i18n.WithLocale(newContext(), i18n.EnUS), "/v1/users")
+	es := validate.ValidationErrorsWithCode{
+		validate.NewLengthError("Username", "ab", 3, 20),
+		validate.NewRequiredError("Email", ""),
+		validate.NewRequiredError("Username", "ab"),
+	}
+
+	rr := httptest.NewRecorder()
+	WriteResponse(ctx, rr, es)
+
+	var p Problem
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &p))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, ContentTypeProblem, rr.Header().Get("Content-Type"))
+	assert.Equal(t, "urn:miniblog:error:400002", p.Type)
+	assert.Equal(t, "Parameter verification failed.", p.Title)
+	assert.Equal(t, http.StatusBadRequest, p.Status)
+	assert.Equal(t, "/v1/users", p.Instance)
+	assert.Equal(t, "req-1", p.RequestID)
+	assert.Equal(t, []FieldError{
+		{Field: "Username", Messages: []string{"must be between 3 and 20 characters long", "is required"}},
+		{Field: "Email", Messages: []string{"is required"}},
+	}, p.Errors)
+}
+
+func TestWriteResponseProblemErrno(t *testing.T) {
+	ctx := WithProblem(newContext(), "/v1/users/1")
+
+	rr := httptest.NewRecorder()
+	WriteResponse(ctx, rr, errorx.ErrUserNotFound.WithReason("用户 1 不存在"))
+
+	var p Problem
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &p))
+	assert.Equal(t, errorx.ErrUserNotFound.HTTP, rr.Code)
+	assert.Equal(t, errorx.ErrUserNotFound.Code, p.Code)
+	assert.Equal(t, "用户 1 不存在", p.Detail)
+	assert.Empty(t, p.Errors)
+}
+
+func TestWriteResponseSuccessIgnoresProblem(t *testing.T) {
+	rr := httptest.NewRecorder()
+	WriteResponse(WithProblem(newContext(), "/"), rr, map[string]string{"id": "1"})
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Header().Get("Content-Type"), "application/json")
+}
+
+func TestAcceptsProblem(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{"application/problem+json", true},
+		{"application/json, application/problem+json;q=0.9", true},
+		{"application/json", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept", tt.accept)
+		assert.Equal(t, tt.want, AcceptsProblem(r), tt.accept)
+	}
+}
+
+func TestFieldErrors(t *testing.T) {
+	m := map[string][]string{"age": {"必须大于0"}, "email": {"不能为空"}}
+	got := fieldErrors([]string{"email", "age", "email"}, m)
+	assert.Equal(t, []FieldError{
+		{Field: "email", Messages: []string{"不能为空"}},
+		{Field: "age", Messages: []string{"必须大于0"}},
+	}, got)
+	// 不修改调用方的 map
+	assert.Len(t, m, 2)
+}
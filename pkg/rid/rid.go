@@ -7,11 +7,32 @@ package rid
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/clin211/miniblog-v3/pkg/id"
 )
 
-const defaultABC = "abcdefghijklmnopqrstuvwxyz1234567890"
+const (
+	defaultABC = "abcdefghijklmnopqrstuvwxyz1234567890"
+
+	// codeLength 编码长度，36^13 >= 2^64，编码可以还原为 Sonyflake ID
+	codeLength = 13
+	// legacyCodeLength 早期版本的编码长度，会截断 ID，不可还原且没有校验字符
+	legacyCodeLength = 6
+	// codeSalt 编码使用的盐值，所有机器保持一致，保证在任意机器上都能还原
+	codeSalt uint64 = 830142577
+)
+
+var (
+	// ErrInvalidID 表示资源标识符的格式不正确
+	ErrInvalidID = errors.New("rid: invalid resource id")
+	// ErrLegacyID 表示资源标识符为早期版本的格式，无法还原为数字 ID
+	ErrLegacyID = errors.New("rid: legacy resource id is not reversible")
+	// ErrNoGenerator 表示进程级的 Sonyflake 尚未设置
+	ErrNoGenerator = errors.New("rid: sonyflake is not initialized, call id.SetDefault on startup")
+)
 
 type ResourceID string
 
@@ -20,23 +41,126 @@ const (
 	UserID ResourceID = "mu"
 )
 
+// resources 所有已定义的资源类型，新增资源类型时需要在这里注册
+var resources = []ResourceID{UserID}
+
 // String 将资源标识符转换为字符串.
 func (rid ResourceID) String() string {
 	return string(rid)
 }
 
-// New 创建带前缀的唯一标识符.
-func (rid ResourceID) New() string {
-	sf := id.NewSonyflake()
+// New 使用进程级的 Sonyflake 创建带前缀的唯一标识符，格式为 前缀-编码+校验字符.
+// 服务启动时需要先通过 id.SetDefault 设置 Sonyflake.
+func (rid ResourceID) New(ctx context.Context) (string, error) {
+	sf := id.Default()
+	if sf == nil {
+		return "", ErrNoGenerator
+	}
 	// 通过 Sonyflake 生成数值 ID，再编码为短码
-	num := sf.Id(context.Background())
+	num, err := sf.Id(ctx)
+	if err != nil {
+		return "", err
+	}
 
 	// 使用自定义选项生成唯一标识符
-	uniqueStr := id.NewCode(
-		num,
+	uniqueStr := id.NewCode(num, codeOptions()...)
+	return rid.String() + "-" + uniqueStr + string(checkChar(rid.String()+uniqueStr)), nil
+}
+
+// Validate 检查 s 是否为该类型的资源标识符.
+func (rid ResourceID) Validate(s string) error {
+	typ, err := validate(s)
+	if err != nil {
+		return err
+	}
+	if typ != rid {
+		return fmt.Errorf("%w: prefix %q, want %q", ErrInvalidID, typ, rid)
+	}
+	return nil
+}
+
+// Validate 检查 s 的前缀、字符集、长度和校验字符，早期版本的标识符没有校验字符，只检查字符集和长度.
+func Validate(s string) error {
+	_, err := validate(s)
+	return err
+}
+
+// Parse 解析资源标识符，返回资源类型和编码前的 Sonyflake ID.
+// 得到的 ID 可以通过 id.DecomposeSonyflake 拆分出生成时间和机器 ID.
+func Parse(s string) (ResourceID, uint64, error) {
+	typ, err := validate(s)
+	if err != nil {
+		return "", 0, err
+	}
+
+	code := s[len(typ)+1:]
+	if len(code) == legacyCodeLength {
+		return typ, 0, ErrLegacyID
+	}
+	num, err := id.DecodeCode(code[:codeLength], codeOptions()...)
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: %w", ErrInvalidID, err)
+	}
+	return typ, num, nil
+}
+
+// validate 校验资源标识符并返回其资源类型.
+func validate(s string) (ResourceID, error) {
+	prefix, code, ok := strings.Cut(s, "-")
+	if !ok {
+		return "", fmt.Errorf("%w: missing prefix", ErrInvalidID)
+	}
+
+	typ := ResourceID(prefix)
+	if !isKnown(typ) {
+		return "", fmt.Errorf("%w: unknown prefix %q", ErrInvalidID, prefix)
+	}
+	for i := 0; i < len(code); i++ {
+		if strings.IndexByte(defaultABC, code[i]) < 0 {
+			return "", fmt.Errorf("%w: invalid character %q", ErrInvalidID, code[i])
+		}
+	}
+
+	switch len(code) {
+	case legacyCodeLength:
+		return typ, nil
+	case codeLength + 1:
+		if checkChar(prefix+code[:codeLength]) != code[codeLength] {
+			return "", fmt.Errorf("%w: checksum mismatch", ErrInvalidID)
+		}
+		return typ, nil
+	default:
+		return "", fmt.Errorf("%w: invalid length %d", ErrInvalidID, len(code))
+	}
+}
+
+// isKnown 判断资源类型是否已注册.
+func isKnown(typ ResourceID) bool {
+	for _, r := range resources {
+		if r == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// codeOptions 返回生成和还原编码时使用的选项.
+func codeOptions() []func(*id.CodeOptions) {
+	return []func(*id.CodeOptions){
 		id.WithCodeChars([]rune(defaultABC)),
-		id.WithCodeL(6),
-		id.WithCodeSalt(Salt()),
-	)
-	return rid.String() + "-" + uniqueStr
+		id.WithCodeL(codeLength),
+		id.WithCodeSalt(codeSalt),
+	}
+}
+
+// checkChar 使用 Luhn mod N 算法计算校验字符，可以发现单个字符错误和绝大多数相邻字符交换.
+func checkChar(s string) byte {
+	n := len(defaultABC)
+	factor, sum := 2, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		addend := factor * strings.IndexByte(defaultABC, s[i])
+		factor = 3 - factor
+		sum += addend/n + addend%n
+	}
+	return defaultABC[(n-sum%n)%n]
 }
@@ -0,0 +1,79 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package rid
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/clin211/miniblog-v3/pkg/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	id.SetDefault(id.NewSonyflake())
+	os.Exit(m.Run())
+}
+
+func TestNewAndParse(t *testing.T) {
+	before := time.Now()
+	s, err := UserID.New(t.Context())
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(s, "mu-"))
+	assert.Len(t, s, len("mu-")+codeLength+1)
+
+	require.NoError(t, Validate(s))
+	require.NoError(t, UserID.Validate(s))
+
+	typ, num, err := Parse(s)
+	require.NoError(t, err)
+	assert.Equal(t, UserID, typ)
+
+	parts := id.DecomposeSonyflake(num)
+	assert.Equal(t, uint16(1), parts.MachineID)
+	assert.WithinDuration(t, before, parts.Time, time.Second)
+}
+
+func TestValidate(t *testing.T) {
+	s, err := UserID.New(t.Context())
+	require.NoError(t, err)
+	code := s[len("mu-"):]
+
+	// 替换任意一个字符都会导致校验失败
+	for i := range code {
+		for _, c := range []byte(defaultABC) {
+			if c == code[i] {
+				continue
+			}
+			bad := "mu-" + code[:i] + string(c) + code[i+1:]
+			assert.ErrorIs(t, Validate(bad), ErrInvalidID, bad)
+		}
+	}
+
+	tests := []string{
+		"",
+		"mu",
+		"mu-",
+		"xx-" + code,
+		"mu-" + strings.ToUpper(code),
+		"mu-" + code + "a",
+		"mu-" + code[:10],
+	}
+	for _, tt := range tests {
+		assert.ErrorIs(t, Validate(tt), ErrInvalidID, tt)
+	}
+	assert.ErrorIs(t, ResourceID("mp").Validate(s), ErrInvalidID)
+}
+
+func TestLegacy(t *testing.T) {
+	// 早期版本的 6 位标识符仍然合法，但无法还原
+	require.NoError(t, UserID.Validate("mu-b8sccs"))
+	_, _, err := Parse("mu-b8sccs")
+	assert.ErrorIs(t, err, ErrLegacyID)
+}
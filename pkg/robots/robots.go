@@ -0,0 +1,82 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+// Package robots 根据配置渲染 robots.txt，不同环境使用不同的配置文件.
+package robots
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Rule 定义针对某个爬虫的抓取规则.
+type Rule struct {
+	UserAgent string   `json:",default=*"` // 爬虫标识
+	Allow     []string `json:",optional"`  // 允许抓取的路径
+	Disallow  []string `json:",optional"`  // 禁止抓取的路径
+}
+
+// Config 定义 robots.txt 配置，不同环境使用不同的配置文件.
+type Config struct {
+	// DisallowAll 为 true 时禁止所有爬虫抓取，开发和测试环境应开启，避免被搜索引擎收录.
+	DisallowAll bool `json:",optional"`
+	// Rules 为抓取规则列表.
+	Rules []Rule `json:",optional"`
+	// Sitemaps 为站点地图地址列表，通常为 sitemap 索引文件地址.
+	Sitemaps []string `json:",optional"`
+}
+
+// Render 根据配置渲染 robots.txt.
+func Render(c Config) string {
+	var b strings.Builder
+
+	if c.DisallowAll {
+		b.WriteString("User-agent: *\nDisallow: /\n")
+		return b.String()
+	}
+
+	rules := c.Rules
+	if len(rules) == 0 {
+		rules = []Rule{{UserAgent: "*"}}
+	}
+	for i, rule := range rules {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		ua := rule.UserAgent
+		if ua == "" {
+			ua = "*"
+		}
+		b.WriteString("User-agent: " + ua + "\n")
+		for _, p := range rule.Allow {
+			b.WriteString("Allow: " + p + "\n")
+		}
+		for _, p := range rule.Disallow {
+			b.WriteString("Disallow: " + p + "\n")
+		}
+		// 空的 Disallow 表示允许抓取全部路径
+		if len(rule.Allow) == 0 && len(rule.Disallow) == 0 {
+			b.WriteString("Disallow:\n")
+		}
+	}
+
+	if len(c.Sitemaps) > 0 {
+		b.WriteString("\n")
+		for _, s := range c.Sitemaps {
+			b.WriteString("Sitemap: " + s + "\n")
+		}
+	}
+	return b.String()
+}
+
+// Handler 返回输出 robots.txt 的 HTTP 处理函数，内容在创建时渲染一次.
+func Handler(c Config) http.HandlerFunc {
+	body := []byte(Render(c))
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package robots
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	assert.Equal(t, "User-agent: *\nDisallow: /\n", Render(Config{DisallowAll: true, Sitemaps: []string{"x"}}))
+	assert.Equal(t, "User-agent: *\nDisallow:\n", Render(Config{}))
+
+	got := Render(Config{
+		Rules: []Rule{
+			{UserAgent: "*", Disallow: []string{"/api/"}},
+			{UserAgent: "Baiduspider"},
+		},
+		Sitemaps: []string{"https://blog.example.com/sitemap.xml"},
+	})
+	expected := "User-agent: *\nDisallow: /api/\n\nUser-agent: Baiduspider\nDisallow:\n\nSitemap: https://blog.example.com/sitemap.xml\n"
+	assert.Equal(t, expected, got)
+}
+
+func TestHandler(t *testing.T) {
+	w := httptest.NewRecorder()
+	Handler(Config{DisallowAll: true})(w, httptest.NewRequest(http.MethodGet, "/robots.txt", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "User-agent: *\nDisallow: /\n", w.Body.String())
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+// Package useragent 提供轻量的 User-Agent 解析，识别浏览器、操作系统和设备类型，
+// 用于访问统计等只需要粗粒度分类的场景.
+package useragent
+
+import "strings"
+
+const (
+	// Other 表示无法识别的浏览器或操作系统
+	Other = "Other"
+
+	// DeviceDesktop 桌面设备
+	DeviceDesktop = "desktop"
+	// DeviceMobile 手机
+	DeviceMobile = "mobile"
+	// DeviceTablet 平板
+	DeviceTablet = "tablet"
+	// DeviceBot 爬虫或脚本
+	DeviceBot = "bot"
+)
+
+// UserAgent 为解析后的 User-Agent.
+type UserAgent struct {
+	Browser string // 浏览器名称，例如 Chrome、Safari
+	Version string // 浏览器主版本号，无法识别时为空
+	OS      string // 操作系统名称，例如 Windows、iOS
+	Device  string // 设备类型：desktop、mobile、tablet 或 bot
+	Bot     bool   // 是否为爬虫或脚本
+}
+
+// rule 为按顺序匹配的识别规则，token 为 User-Agent 中的特征片段.
+type rule struct {
+	name   string
+	tokens []string
+}
+
+// botTokens 爬虫和脚本的特征片段，统一转为小写匹配.
+var botTokens = []string{
+	"bot", "crawler", "spider", "slurp", "curl/", "wget/", "python-requests",
+	"go-http-client", "okhttp", "java/", "headlesschrome", "facebookexternalhit",
+}
+
+// browserRules 浏览器识别规则，基于 Chromium 的浏览器都带有 Chrome 标识，因此必须排在 Chrome 之前.
+var browserRules = []rule{
+	{name: "WeChat", tokens: []string{"MicroMessenger/"}},
+	{name: "QQ Browser", tokens: []string{"QQBrowser/", "MQQBrowser/"}},
+	{name: "UC Browser", tokens: []string{"UCBrowser/"}},
+	{name: "Samsung Internet", tokens: []string{"SamsungBrowser/"}},
+	{name: "Edge", tokens: []string{"Edg/", "EdgA/", "EdgiOS/", "Edge/"}},
+	{name: "Opera", tokens: []string{"OPR/", "OPiOS/", "Opera/"}},
+	{name: "Firefox", tokens: []string{"Firefox/", "FxiOS/"}},
+	{name: "Chrome", tokens: []string{"Chrome/", "CriOS/"}},
+	{name: "Safari", tokens: []string{"Version/"}},
+	{name: "IE", tokens: []string{"MSIE ", "Trident/"}},
+}
+
+// osRules 操作系统识别规则，Android 和 iOS 的 User-Agent 中带有 Linux 和 Mac OS X 标识，因此必须排在前面.
+var osRules = []rule{
+	{name: "HarmonyOS", tokens: []string{"HarmonyOS", "OpenHarmony"}},
+	{name: "Android", tokens: []string{"Android"}},
+	{name: "iOS", tokens: []string{"iPhone", "iPad", "iPod"}},
+	{name: "Chrome OS", tokens: []string{"CrOS"}},
+	{name: "Windows", tokens: []string{"Windows"}},
+	{name: "macOS", tokens: []string{"Macintosh", "Mac OS X"}},
+	{name: "Linux", tokens: []string{"Linux", "X11"}},
+}
+
+// Parse 解析 User-Agent 字符串.
+func Parse(s string) UserAgent {
+	ua := UserAgent{Browser: Other, OS: Other, Device: DeviceDesktop}
+	if s == "" {
+		return ua
+	}
+
+	lower := strings.ToLower(s)
+	for _, token := range botTokens {
+		if strings.Contains(lower, token) {
+			ua.Bot = true
+			ua.Device = DeviceBot
+			break
+		}
+	}
+
+	if name, token := match(s, browserRules); name != "" {
+		ua.Browser = name
+		ua.Version = majorVersion(s, token)
+	}
+	if name, _ := match(s, osRules); name != "" {
+		ua.OS = name
+	}
+	if !ua.Bot {
+		ua.Device = device(s, ua.OS)
+	}
+	return ua
+}
+
+// match 返回第一条命中的规则名称及命中的特征片段.
+func match(s string, rules []rule) (string, string) {
+	for _, r := range rules {
+		for _, token := range r.tokens {
+			if strings.Contains(s, token) {
+				return r.name, token
+			}
+		}
+	}
+	return "", ""
+}
+
+// device 根据 User-Agent 和操作系统判断设备类型.
+func device(s, os string) string {
+	switch {
+	case strings.Contains(s, "iPad") || strings.Contains(s, "Tablet"):
+		return DeviceTablet
+	case os == "Android" && !strings.Contains(s, "Mobile"):
+		// Android 平板的 User-Agent 不带 Mobile 标识
+		return DeviceTablet
+	case strings.Contains(s, "Mobile") || strings.Contains(s, "iPhone") || strings.Contains(s, "iPod"):
+		return DeviceMobile
+	default:
+		return DeviceDesktop
+	}
+}
+
+// majorVersion 提取特征片段之后的主版本号，例如 "Chrome/120.0.1" 返回 "120".
+func majorVersion(s, token string) string {
+	i := strings.Index(s, token)
+	if i < 0 {
+		return ""
+	}
+	rest := s[i+len(token):]
+	end := 0
+	for end < len(rest) && rest[end] >= '0' && rest[end] <= '9' {
+		end++
+	}
+	return rest[:end]
+}
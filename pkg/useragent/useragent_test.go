@@ -0,0 +1,77 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package useragent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		ua   string
+		want UserAgent
+	}{
+		{
+			name: "Chrome Windows",
+			ua:   "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			want: UserAgent{Browser: "Chrome", Version: "120", OS: "Windows", Device: DeviceDesktop},
+		},
+		{
+			name: "Edge macOS",
+			ua:   "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.2210.91",
+			want: UserAgent{Browser: "Edge", Version: "120", OS: "macOS", Device: DeviceDesktop},
+		},
+		{
+			name: "Safari iPhone",
+			ua:   "Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 Safari/604.1",
+			want: UserAgent{Browser: "Safari", Version: "17", OS: "iOS", Device: DeviceMobile},
+		},
+		{
+			name: "Safari iPad",
+			ua:   "Mozilla/5.0 (iPad; CPU OS 16_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.6 Mobile/15E148 Safari/604.1",
+			want: UserAgent{Browser: "Safari", Version: "16", OS: "iOS", Device: DeviceTablet},
+		},
+		{
+			name: "Firefox Linux",
+			ua:   "Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:121.0) Gecko/20100101 Firefox/121.0",
+			want: UserAgent{Browser: "Firefox", Version: "121", OS: "Linux", Device: DeviceDesktop},
+		},
+		{
+			name: "WeChat Android",
+			ua:   "Mozilla/5.0 (Linux; Android 13; PGT-AN10) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/107.0.5304.141 Mobile Safari/537.36 MicroMessenger/8.0.43.2480",
+			want: UserAgent{Browser: "WeChat", Version: "8", OS: "Android", Device: DeviceMobile},
+		},
+		{
+			name: "Android tablet",
+			ua:   "Mozilla/5.0 (Linux; Android 12; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/119.0.0.0 Safari/537.36",
+			want: UserAgent{Browser: "Chrome", Version: "119", OS: "Android", Device: DeviceTablet},
+		},
+		{
+			name: "Googlebot",
+			ua:   "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			want: UserAgent{Browser: Other, OS: Other, Device: DeviceBot, Bot: true},
+		},
+		{
+			name: "curl",
+			ua:   "curl/8.4.0",
+			want: UserAgent{Browser: Other, OS: Other, Device: DeviceBot, Bot: true},
+		},
+		{
+			name: "empty",
+			ua:   "",
+			want: UserAgent{Browser: Other, OS: Other, Device: DeviceDesktop},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Parse(tt.ua))
+		})
+	}
+}
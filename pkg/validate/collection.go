@@ -0,0 +1,132 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// 集合规则用于切片、数组和映射，dive 之后的规则应用到每个元素，例如:
+//
+//	Tags        []string     `json:"tags" valid:"max_items(5),unique,dive,required,length(1|20)"`
+//	Attachments []Attachment `json:"attachments" valid:"min_items(1),unique(URL)"`
+//
+// 结构体元素总会递归验证，不需要 dive.
+
+// isCollection 判断是否为切片、数组或映射
+func isCollection(v reflect.Value) bool {
+	return isCollectionKind(v.Kind())
+}
+
+func isCollectionKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// countItems 返回集合的元素个数和规则参数，nil 指针按空集合处理
+func countItems(rule string, field reflect.Value, params []string) (int, int, error) {
+	field = indirect(field)
+	count := 0
+	switch {
+	case isCollection(field):
+		count = field.Len()
+	case field.Kind() == reflect.Ptr && isCollectionKind(field.Type().Elem().Kind()):
+	default:
+		return 0, 0, newRuleError(ErrorCodeInvalidRule, nil, "%s规则只能用于切片、数组或映射", rule)
+	}
+	if len(params) != 1 {
+		return 0, 0, newRuleError(ErrorCodeInvalidRule, nil, "%s规则需要一个参数", rule)
+	}
+	n, err := strconv.Atoi(params[0])
+	if err != nil || n < 0 {
+		return 0, 0, newRuleError(ErrorCodeInvalidRule, nil, "%s规则的参数必须是非负整数", rule)
+	}
+	return count, n, nil
+}
+
+// validateMinItems 验证元素个数不少于指定值，空集合同样检查
+func validateMinItems(field reflect.Value, _ reflect.StructField, params []string) error {
+	count, n, err := countItems(RuleMinItems, field, params)
+	if err != nil {
+		return err
+	}
+	if count < n {
+		return newRuleError(ErrorCodeInvalidMinItems, map[string]string{"min": params[0]}, "至少需要 %d 个元素", n)
+	}
+	return nil
+}
+
+// validateMaxItems 验证元素个数不多于指定值
+func validateMaxItems(field reflect.Value, _ reflect.StructField, params []string) error {
+	count, n, err := countItems(RuleMaxItems, field, params)
+	if err != nil {
+		return err
+	}
+	if count > n {
+		return newRuleError(ErrorCodeInvalidMaxItems, map[string]string{"max": params[0]}, "最多允许 %d 个元素", n)
+	}
+	return nil
+}
+
+// validateUnique 验证元素不能重复，映射比较其值. 参数为结构体元素中用于比较的字段名，省略时比较整个元素
+func validateUnique(field reflect.Value, _ reflect.StructField, params []string) error {
+	field = indirect(field)
+	if field.Kind() == reflect.Ptr {
+		return nil
+	}
+	if !isCollection(field) {
+		return newRuleError(ErrorCodeInvalidRule, nil, "unique规则只能用于切片、数组或映射")
+	}
+
+	var name string
+	if len(params) > 0 {
+		name = params[0]
+	}
+
+	seen := make(map[interface{}]struct{}, field.Len())
+	check := func(elem reflect.Value) error {
+		elem = indirect(elem)
+		if name != "" {
+			if elem.Kind() != reflect.Struct {
+				return newRuleError(ErrorCodeInvalidRule, nil, "unique(%s)规则只能用于结构体元素", name)
+			}
+			elem = elem.FieldByName(name)
+			if !elem.IsValid() {
+				return newRuleError(ErrorCodeInvalidRule, map[string]string{"field": name}, "规则引用了不存在的字段: %s", name)
+			}
+		}
+		if !elem.Type().Comparable() {
+			return newRuleError(ErrorCodeInvalidRule, nil, "unique规则的元素类型无法比较")
+		}
+		key := elem.Interface()
+		if _, ok := seen[key]; ok {
+			value := fmt.Sprint(key)
+			return newRuleError(ErrorCodeInvalidUnique, map[string]string{"value": value}, "元素不能重复: %s", value)
+		}
+		seen[key] = struct{}{}
+		return nil
+	}
+
+	if field.Kind() == reflect.Map {
+		for _, key := range sortedMapKeys(field) {
+			if err := check(field.MapIndex(key)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for i := 0; i < field.Len(); i++ {
+		if err := check(field.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
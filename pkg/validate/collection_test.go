@@ -0,0 +1,145 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/clin211/miniblog-v3/pkg/i18n"
+)
+
+type attachment struct {
+	URL  string `json:"url" valid:"required,url"`
+	Name string `json:"name,optional" valid:"length(1|20)"`
+}
+
+type author struct {
+	Nickname string `json:"nickname" valid:"required"`
+}
+
+// Audit 用于测试嵌入结构体的字段展开
+type Audit struct {
+	Reviewer string `json:"reviewer" valid:"required"`
+}
+
+type postForm struct {
+	Audit
+	Title       string            `json:"title" valid:"required"`
+	Tags        []string          `json:"tags" valid:"max_items(3),unique,dive,required,length(1|5)"`
+	Attachments []attachment      `json:"attachments" valid:"min_items(1),unique(URL)"`
+	Author      *author           `json:"author"`
+	Editors     []*author         `json:"editors"`
+	Labels      map[string]string `json:"labels" valid:"dive,in(red|green)"`
+	Matrix      [][]int           `json:"matrix" valid:"dive,max_items(2),dive,range(1|9)"`
+	Page        int               `form:"page" valid:"range(1|100)"`
+}
+
+func validPostForm() *postForm {
+	return &postForm{
+		Audit:       Audit{Reviewer: "admin"},
+		Title:       "hello",
+		Tags:        []string{"go", "rpc"},
+		Attachments: []attachment{{URL: "https://example.com/a.png"}},
+		Author:      &author{Nickname: "clin"},
+		Editors:     []*author{{Nickname: "a"}, nil},
+		Labels:      map[string]string{"a": "red"},
+		Matrix:      [][]int{{1, 2}, {3}},
+		Page:        1,
+	}
+}
+
+// TestNestedValidation 测试嵌套结构体、切片和映射的验证及错误路径
+func TestNestedValidation(t *testing.T) {
+	if err := ValidateStructWithCustomRules(validPostForm()); err != nil {
+		t.Fatalf("合法的请求不应验证失败: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(f *postForm)
+		field  string
+		code   ErrorCode
+	}{
+		{"嵌套结构体指针", func(f *postForm) { f.Author.Nickname = "" }, "author.nickname", ErrorCodeRequired},
+		{"结构体切片", func(f *postForm) {
+			f.Attachments = append(f.Attachments, attachment{URL: "https://example.com/b.png"}, attachment{URL: "c"})
+		}, "attachments[2].url", ErrorCodeInvalidURL},
+		{"指针切片", func(f *postForm) { f.Editors[0].Nickname = "" }, "editors[0].nickname", ErrorCodeRequired},
+		{"dive 元素规则", func(f *postForm) { f.Tags[1] = "" }, "tags[1]", ErrorCodeRequired},
+		{"dive 映射", func(f *postForm) { f.Labels["b"] = "blue" }, "labels[b]", ErrorCodeInvalidEnum},
+		{"嵌套 dive 数量", func(f *postForm) { f.Matrix[1] = []int{1, 2, 3} }, "matrix[1]", ErrorCodeInvalidMaxItems},
+		{"嵌套 dive 元素", func(f *postForm) { f.Matrix[0][1] = 10 }, "matrix[0][1]", ErrorCodeInvalidRange},
+		{"min_items", func(f *postForm) { f.Attachments = nil }, "attachments", ErrorCodeInvalidMinItems},
+		{"max_items", func(f *postForm) { f.Tags = []string{"a", "b", "c", "d"} }, "tags", ErrorCodeInvalidMaxItems},
+		{"unique", func(f *postForm) { f.Tags = []string{"go", "go"} }, "tags", ErrorCodeInvalidUnique},
+		{"unique 字段", func(f *postForm) {
+			f.Attachments = append(f.Attachments, attachment{URL: f.Attachments[0].URL, Name: "copy"})
+		}, "attachments", ErrorCodeInvalidUnique},
+		{"嵌入结构体展开", func(f *postForm) { f.Reviewer = "" }, "reviewer", ErrorCodeRequired},
+		{"form 标签", func(f *postForm) { f.Page = 101 }, "page", ErrorCodeInvalidRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := validPostForm()
+			tt.modify(f)
+
+			err := ValidateStructWithCustomRules(f)
+			es, ok := err.(ValidationErrors)
+			if !ok || len(es) != 1 {
+				t.Fatalf("应该返回一个验证错误，实际为 %v", err)
+			}
+			if es[0].Field != tt.field || es[0].Code != tt.code {
+				t.Errorf("错误应为 %s [%s]，实际为 %s [%s]", tt.field, tt.code, es[0].Field, es[0].Code)
+			}
+		})
+	}
+}
+
+// TestCollectionMessages 测试集合规则的错误信息
+func TestCollectionMessages(t *testing.T) {
+	f := validPostForm()
+	f.Tags = []string{"go", "go"}
+	f.Attachments = nil
+
+	err := ValidateStructWithCustomRules(f)
+	es, ok := err.(ValidationErrors)
+	if !ok || len(es) != 2 {
+		t.Fatalf("应该返回两个验证错误，实际为 %v", err)
+	}
+
+	want := []struct{ zh, en string }{
+		{"元素不能重复: go", "must not contain duplicate items: go"},
+		{"至少需要 1 个元素", "must contain at least 1 items"},
+	}
+	for i, w := range want {
+		if es[i].Message != w.zh {
+			t.Errorf("%s 默认信息应为 %q，实际为 %q", es[i].Field, w.zh, es[i].Message)
+		}
+		if got := es[i].Localize(i18n.EnUS).Message; got != w.en {
+			t.Errorf("%s 英文信息应为 %q，实际为 %q", es[i].Field, w.en, got)
+		}
+	}
+}
+
+// TestCollectionInvalidRule 测试集合规则用在不支持的类型上
+func TestCollectionInvalidRule(t *testing.T) {
+	form := &struct {
+		Name string `json:"name" valid:"dive,required"`
+		Size int    `json:"size" valid:"min_items(1)"`
+	}{Name: "a", Size: 1}
+
+	err := ValidateStructWithCustomRules(form)
+	es, ok := err.(ValidationErrors)
+	if !ok || len(es) != 2 {
+		t.Fatalf("应该返回两个验证错误，实际为 %v", err)
+	}
+	for _, e := range es {
+		if e.Code != ErrorCodeInvalidRule {
+			t.Errorf("%s 的错误代码应为 %s，实际为 %s", e.Field, ErrorCodeInvalidRule, e.Code)
+		}
+	}
+}
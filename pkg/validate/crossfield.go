@@ -0,0 +1,222 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package validate
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// 跨字段规则引用的字段使用 Go 结构体字段名，多个字段用 | 分隔，错误信息中使用字段的 JSON 名称，例如:
+//
+//	ConfirmPassword string `valid:"eqfield(Password)"`
+//	WechatOpenid    string `valid:"required_if(RegisterSource|3)"`
+//	EndTime         int64  `valid:"gtfield(StartTime)"`
+//	Email           string `valid:"oneof_required(Phone),email"`
+
+// lookupFields 按字段名查找同一结构体中的字段，同时返回字段在错误信息中的名称，字段不存在时返回规则错误
+func lookupFields(parent reflect.Value, names []string) ([]reflect.Value, []string, error) {
+	if len(names) == 0 || names[0] == "" {
+		return nil, nil, newRuleError(ErrorCodeInvalidRule, nil, "跨字段规则需要字段名参数")
+	}
+	if parent.Kind() != reflect.Struct {
+		return nil, nil, newRuleError(ErrorCodeInvalidRule, nil, "跨字段规则只能用于结构体字段")
+	}
+	fields := make([]reflect.Value, len(names))
+	display := make([]string, len(names))
+	for i, name := range names {
+		sf, ok := parent.Type().FieldByName(name)
+		if !ok {
+			return nil, nil, newRuleError(ErrorCodeInvalidRule, map[string]string{"field": name}, "规则引用了不存在的字段: %s", name)
+		}
+		fields[i] = parent.FieldByIndex(sf.Index)
+		display[i] = fieldName(sf)
+	}
+	return fields, display, nil
+}
+
+// validateEqField 验证字段与另一个字段的值相同
+func validateEqField(parent, field reflect.Value, _ reflect.StructField, params []string) error {
+	others, names, err := lookupFields(parent, params[:min(len(params), 1)])
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(field.Interface(), others[0].Interface()) {
+		return newRuleError(ErrorCodeInvalidEqField, map[string]string{"field": names[0]}, "必须与字段 %s 相同", names[0])
+	}
+	return nil
+}
+
+// validateNeField 验证字段与另一个字段的值不同
+func validateNeField(parent, field reflect.Value, _ reflect.StructField, params []string) error {
+	others, names, err := lookupFields(parent, params[:min(len(params), 1)])
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(field.Interface(), others[0].Interface()) {
+		return newRuleError(ErrorCodeInvalidNeField, map[string]string{"field": names[0]}, "不能与字段 %s 相同", names[0])
+	}
+	return nil
+}
+
+// validateRequiredIf 另一个字段等于指定值之一时字段不能为空，参数为字段名和一个或多个值
+func validateRequiredIf(parent, field reflect.Value, _ reflect.StructField, params []string) error {
+	if len(params) < 2 {
+		return newRuleError(ErrorCodeInvalidRule, nil, "required_if规则需要字段名和至少一个值")
+	}
+	others, names, err := lookupFields(parent, params[:1])
+	if err != nil {
+		return err
+	}
+	if !field.IsZero() || !slices.Contains(params[1:], fmt.Sprint(indirect(others[0]).Interface())) {
+		return nil
+	}
+	values := strings.Join(params[1:], ", ")
+	return newRuleError(ErrorCodeRequiredIf, map[string]string{"field": names[0], "value": values},
+		"当字段 %s 为 %s 时不能为空", names[0], values)
+}
+
+// validateRequiredWith 任一指定字段不为空时字段不能为空
+func validateRequiredWith(parent, field reflect.Value, _ reflect.StructField, params []string) error {
+	others, names, err := lookupFields(parent, params)
+	if err != nil {
+		return err
+	}
+	if !field.IsZero() || !slices.ContainsFunc(others, func(v reflect.Value) bool { return !v.IsZero() }) {
+		return nil
+	}
+	fields := strings.Join(names, ", ")
+	return newRuleError(ErrorCodeRequiredWith, map[string]string{"fields": fields}, "当字段 %s 不为空时不能为空", fields)
+}
+
+// validateRequiredWithout 任一指定字段为空时字段不能为空
+func validateRequiredWithout(parent, field reflect.Value, _ reflect.StructField, params []string) error {
+	others, names, err := lookupFields(parent, params)
+	if err != nil {
+		return err
+	}
+	if !field.IsZero() || !slices.ContainsFunc(others, reflect.Value.IsZero) {
+		return nil
+	}
+	fields := strings.Join(names, ", ")
+	return newRuleError(ErrorCodeRequiredWithout, map[string]string{"fields": fields}, "当字段 %s 为空时不能为空", fields)
+}
+
+// validateGtField 验证字段大于另一个字段，任一字段为空时跳过验证
+func validateGtField(parent, field reflect.Value, _ reflect.StructField, params []string) error {
+	return validateFieldOrder(parent, field, params, 1, ErrorCodeInvalidGtField, "必须大于字段 %s")
+}
+
+// validateLtField 验证字段小于另一个字段，任一字段为空时跳过验证
+func validateLtField(parent, field reflect.Value, _ reflect.StructField, params []string) error {
+	return validateFieldOrder(parent, field, params, -1, ErrorCodeInvalidLtField, "必须小于字段 %s")
+}
+
+// validateFieldOrder 比较两个字段，want 为期望的比较结果
+func validateFieldOrder(parent, field reflect.Value, params []string, want int, code ErrorCode, format string) error {
+	others, names, err := lookupFields(parent, params[:min(len(params), 1)])
+	if err != nil {
+		return err
+	}
+	if field.IsZero() || others[0].IsZero() {
+		return nil
+	}
+	got, ok := compareValues(indirect(field), indirect(others[0]))
+	if !ok {
+		return newRuleError(ErrorCodeInvalidRule, nil, "字段 %s 的类型无法比较", names[0])
+	}
+	if got != want {
+		return newRuleError(code, map[string]string{"field": names[0]}, format, names[0])
+	}
+	return nil
+}
+
+// validateOneOfRequired 字段和指定字段中至少有一个不为空
+func validateOneOfRequired(parent, field reflect.Value, fieldType reflect.StructField, params []string) error {
+	others, names, err := lookupFields(parent, params)
+	if err != nil {
+		return err
+	}
+	if !field.IsZero() || slices.ContainsFunc(others, func(v reflect.Value) bool { return !v.IsZero() }) {
+		return nil
+	}
+	fields := strings.Join(append([]string{fieldName(fieldType)}, names...), ", ")
+	return newRuleError(ErrorCodeOneOfRequired, map[string]string{"fields": fields}, "字段 %s 至少需要填写一个", fields)
+}
+
+// dateLayouts 比较字符串日期时支持的格式
+var dateLayouts = []string{time.RFC3339, time.DateTime, time.DateOnly}
+
+// compareValues 比较数值、time.Time 和日期字符串，返回 -1、0、1，类型不支持时返回 false
+func compareValues(a, b reflect.Value) (int, bool) {
+	if ta, ok := a.Interface().(time.Time); ok {
+		if tb, ok := b.Interface().(time.Time); ok {
+			return ta.Compare(tb), true
+		}
+		return 0, false
+	}
+
+	switch {
+	case isInt(a) && isInt(b):
+		return cmp.Compare(a.Int(), b.Int()), true
+	case isUint(a) && isUint(b):
+		return cmp.Compare(a.Uint(), b.Uint()), true
+	case isNumber(a) && isNumber(b):
+		return cmp.Compare(toFloat(a), toFloat(b)), true
+	case a.Kind() == reflect.String && b.Kind() == reflect.String:
+		for _, layout := range dateLayouts {
+			ta, errA := time.Parse(layout, a.String())
+			tb, errB := time.Parse(layout, b.String())
+			if errA == nil && errB == nil {
+				return ta.Compare(tb), true
+			}
+		}
+	}
+	return 0, false
+}
+
+func isInt(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isUint(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func isNumber(v reflect.Value) bool {
+	return isInt(v) || isUint(v) || v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case isInt(v):
+		return float64(v.Int())
+	case isUint(v):
+		return float64(v.Uint())
+	default:
+		return v.Float()
+	}
+}
+
+// indirect 返回指针指向的值，nil 指针原样返回
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package validate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/clin211/miniblog-v3/pkg/i18n"
+)
+
+// crossFieldForm 测试跨字段规则的结构体
+type crossFieldForm struct {
+	Password        string
+	ConfirmPassword string `valid:"eqfield(Password)"`
+	OldPassword     string `valid:"nefield(Password)"`
+	RegisterSource  int
+	WechatOpenid    string `valid:"required_if(RegisterSource|3|4)"`
+	Province        string
+	City            string `valid:"required_with(Province)"`
+	Email           string `valid:"oneof_required(Phone),email"`
+	Phone           string
+	Nickname        string `valid:"required_without(Email|Phone)"`
+	StartTime       int64
+	EndTime         int64 `valid:"gtfield(StartTime)"`
+	StartDate       string
+	EndDate         string `valid:"gtfield(StartDate)"`
+	From            time.Time
+	To              time.Time  `valid:"gtfield(From)"`
+	Deadline        *time.Time `valid:"ltfield(To)"`
+}
+
+func validCrossFieldForm() crossFieldForm {
+	now := time.Now()
+	return crossFieldForm{
+		Password:        "secret",
+		ConfirmPassword: "secret",
+		OldPassword:     "old",
+		RegisterSource:  1,
+		Email:           "john@example.com",
+		Nickname:        "john",
+		StartTime:       100,
+		EndTime:         200,
+		StartDate:       "2025-01-01",
+		EndDate:         "2025-02-01",
+		From:            now,
+		To:              now.Add(time.Hour),
+	}
+}
+
+// codeOf 返回字段的错误代码，字段没有错误时返回空
+func codeOf(t *testing.T, err error, field string) ErrorCode {
+	t.Helper()
+	if err == nil {
+		return ""
+	}
+	es, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("应返回 ValidationErrors，实际为 %T: %v", err, err)
+	}
+	for _, e := range es {
+		if e.Field == field {
+			return e.Code
+		}
+	}
+	return ""
+}
+
+// TestCrossFieldRules 测试跨字段和条件规则
+func TestCrossFieldRules(t *testing.T) {
+	form := validCrossFieldForm()
+	if err := ValidateStructWithCustomRules(&form); err != nil {
+		t.Fatalf("有效数据验证失败: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(f *crossFieldForm)
+		field  string
+		code   ErrorCode
+	}{
+		{"eqfield", func(f *crossFieldForm) { f.ConfirmPassword = "other" }, "ConfirmPassword", ErrorCodeInvalidEqField},
+		{"eqfield 空值", func(f *crossFieldForm) { f.ConfirmPassword = "" }, "ConfirmPassword", ErrorCodeInvalidEqField},
+		{"nefield", func(f *crossFieldForm) { f.OldPassword = "secret" }, "OldPassword", ErrorCodeInvalidNeField},
+		{"required_if", func(f *crossFieldForm) { f.RegisterSource = 4 }, "WechatOpenid", ErrorCodeRequiredIf},
+		{"required_if 满足", func(f *crossFieldForm) { f.RegisterSource = 3; f.WechatOpenid = "openid" }, "WechatOpenid", ""},
+		{"required_with", func(f *crossFieldForm) { f.Province = "浙江" }, "City", ErrorCodeRequiredWith},
+		{"oneof_required", func(f *crossFieldForm) { f.Email = "" }, "Email", ErrorCodeOneOfRequired},
+		{"oneof_required 其他字段", func(f *crossFieldForm) { f.Email = ""; f.Phone = "13800138000" }, "Email", ""},
+		{"oneof_required 后续规则", func(f *crossFieldForm) { f.Email = "bad" }, "Email", ErrorCodeInvalidEmail},
+		{"required_without", func(f *crossFieldForm) { f.Nickname = ""; f.Phone = "" }, "Nickname", ErrorCodeRequiredWithout},
+		{"gtfield 数值", func(f *crossFieldForm) { f.EndTime = 100 }, "EndTime", ErrorCodeInvalidGtField},
+		{"gtfield 空值跳过", func(f *crossFieldForm) { f.EndTime = 0 }, "EndTime", ""},
+		{"gtfield 日期字符串", func(f *crossFieldForm) { f.EndDate = "2024-12-31" }, "EndDate", ErrorCodeInvalidGtField},
+		{"gtfield time.Time", func(f *crossFieldForm) { f.To = f.From.Add(-time.Second) }, "To", ErrorCodeInvalidGtField},
+		{"ltfield 指针", func(f *crossFieldForm) { d := f.To.Add(time.Minute); f.Deadline = &d }, "Deadline", ErrorCodeInvalidLtField},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := validCrossFieldForm()
+			tt.modify(&f)
+			err := ValidateStructWithCustomRules(&f)
+			if got := codeOf(t, err, tt.field); got != tt.code {
+				t.Errorf("字段 %s 的错误代码应为 %q，实际为 %q (%v)", tt.field, tt.code, got, err)
+			}
+		})
+	}
+}
+
+// TestCrossFieldMessages 测试跨字段规则的错误信息和本地化
+func TestCrossFieldMessages(t *testing.T) {
+	f := validCrossFieldForm()
+	f.RegisterSource = 3
+	f.Email = ""
+
+	err := ValidateStructWithCustomRules(&f)
+	es, ok := err.(ValidationErrors)
+	if !ok || len(es) != 2 {
+		t.Fatalf("应返回 2 个验证错误，实际为 %v", err)
+	}
+
+	want := []struct{ zh, en string }{
+		{"当字段 RegisterSource 为 3, 4 时不能为空", "is required when RegisterSource is 3, 4"},
+		{"字段 Email, Phone 至少需要填写一个", "at least one of Email, Phone is required"},
+	}
+	for i, e := range es {
+		if e.Message != want[i].zh {
+			t.Errorf("默认信息应为 %q，实际为 %q", want[i].zh, e.Message)
+		}
+		if got := e.Localize(i18n.EnUS).Message; got != want[i].en {
+			t.Errorf("英文信息应为 %q，实际为 %q", want[i].en, got)
+		}
+	}
+
+	withCode := es.WithCode()
+	if withCode[0].Code != ErrorCodeRequiredIf || withCode[0].Rule != "required_if(RegisterSource|3|4)" {
+		t.Errorf("转换后的错误不正确: %+v", withCode[0])
+	}
+}
+
+// TestCrossFieldUnknownField 测试引用不存在的字段
+func TestCrossFieldUnknownField(t *testing.T) {
+	type form struct {
+		Confirm string `valid:"eqfield(Missing)"`
+	}
+	err := ValidateStructWithCustomRules(&form{})
+	if got := codeOf(t, err, "Confirm"); got != ErrorCodeInvalidRule {
+		t.Errorf("错误代码应为 %q，实际为 %q", ErrorCodeInvalidRule, got)
+	}
+}
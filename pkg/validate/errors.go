@@ -7,6 +7,7 @@ package validate
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -44,15 +45,39 @@ const (
 	ErrorCodeInvalidMatches   ErrorCode = "INVALID_MATCHES"
 	ErrorCodeInvalidEnum      ErrorCode = "INVALID_ENUM"
 	ErrorCodeUnknownRule      ErrorCode = "UNKNOWN_RULE"
+	ErrorCodeInvalidRule      ErrorCode = "INVALID_RULE"
+	ErrorCodeInvalidType      ErrorCode = "INVALID_TYPE"
+
+	// 跨字段和条件规则相关的错误代码
+	ErrorCodeInvalidEqField  ErrorCode = "INVALID_EQFIELD"
+	ErrorCodeInvalidNeField  ErrorCode = "INVALID_NEFIELD"
+	ErrorCodeRequiredIf      ErrorCode = "REQUIRED_IF"
+	ErrorCodeRequiredWith    ErrorCode = "REQUIRED_WITH"
+	ErrorCodeRequiredWithout ErrorCode = "REQUIRED_WITHOUT"
+	ErrorCodeInvalidGtField  ErrorCode = "INVALID_GTFIELD"
+	ErrorCodeInvalidLtField  ErrorCode = "INVALID_LTFIELD"
+	ErrorCodeOneOfRequired   ErrorCode = "ONEOF_REQUIRED"
+
+	// 集合规则相关的错误代码
+	ErrorCodeInvalidMinItems ErrorCode = "INVALID_MIN_ITEMS"
+	ErrorCodeInvalidMaxItems ErrorCode = "INVALID_MAX_ITEMS"
+	ErrorCodeInvalidUnique   ErrorCode = "INVALID_UNIQUE"
+
+	// 密码策略相关的错误代码
+	ErrorCodePasswordTooWeak  ErrorCode = "PASSWORD_TOO_WEAK"
+	ErrorCodePasswordSimilar  ErrorCode = "PASSWORD_SIMILAR"
+	ErrorCodePasswordReused   ErrorCode = "PASSWORD_REUSED"
+	ErrorCodePasswordBreached ErrorCode = "PASSWORD_BREACHED"
 )
 
 // ValidationErrorWithCode 带错误代码的验证错误
 type ValidationErrorWithCode struct {
-	Code    ErrorCode   `json:"code"`
-	Field   string      `json:"field"`
-	Message string      `json:"message"`
-	Value   interface{} `json:"value"`
-	Rule    string      `json:"rule"`
+	Code    ErrorCode         `json:"code"`
+	Field   string            `json:"field"`
+	Message string            `json:"message"`
+	Value   interface{}       `json:"value"`
+	Rule    string            `json:"rule"`
+	Params  map[string]string `json:"params,omitempty"` // 错误信息模板的参数，用于本地化
 }
 
 // Error 实现 error 接口
@@ -155,55 +180,69 @@ func NewLengthError(field, value string, min, max int) *ValidationErrorWithCode
 		message = fmt.Sprintf("长度必须在 %d 到 %d 个字符之间", min, max)
 	}
 
-	return NewValidationError(
+	e := NewValidationError(
 		ErrorCodeInvalidLength,
 		field,
 		message,
 		value,
 		fmt.Sprintf("length(%d|%d)", min, max),
 	)
+	if min == max {
+		e.Params = map[string]string{"len": strconv.Itoa(min)}
+	} else {
+		e.Params = map[string]string{"min": strconv.Itoa(min), "max": strconv.Itoa(max)}
+	}
+	return e
 }
 
 // NewRangeError 创建范围错误
 func NewRangeError(field string, value interface{}, min, max float64) *ValidationErrorWithCode {
-	return NewValidationError(
+	e := NewValidationError(
 		ErrorCodeInvalidRange,
 		field,
 		fmt.Sprintf("值必须在 %v 到 %v 之间", min, max),
 		value,
 		fmt.Sprintf("range(%v|%v)", min, max),
 	)
+	e.Params = map[string]string{"min": fmt.Sprint(min), "max": fmt.Sprint(max)}
+	return e
 }
 
 // NewMatchesError 创建正则匹配错误
 func NewMatchesError(field, value, pattern string) *ValidationErrorWithCode {
-	return NewValidationError(
+	e := NewValidationError(
 		ErrorCodeInvalidMatches,
 		field,
 		fmt.Sprintf("值不符合正则表达式模式: %s", pattern),
 		value,
 		fmt.Sprintf("matches(%s)", pattern),
 	)
+	e.Params = map[string]string{"pattern": pattern}
+	return e
 }
 
 // NewEnumError 创建枚举值错误
 func NewEnumError(field string, value interface{}, allowedValues []string) *ValidationErrorWithCode {
-	return NewValidationError(
+	e := NewValidationError(
 		ErrorCodeInvalidEnum,
 		field,
 		fmt.Sprintf("值必须是以下之一: %s", strings.Join(allowedValues, ", ")),
 		value,
 		fmt.Sprintf("in(%s)", strings.Join(allowedValues, "|")),
 	)
+	e.Params = map[string]string{"values": strings.Join(allowedValues, ", ")}
+	return e
 }
 
 // NewUnknownRuleError 创建未知规则错误
 func NewUnknownRuleError(field, rule string, value interface{}) *ValidationErrorWithCode {
-	return NewValidationError(
+	e := NewValidationError(
 		ErrorCodeUnknownRule,
 		field,
 		fmt.Sprintf("未知的验证规则: %s", rule),
 		value,
 		rule,
 	)
+	e.Params = map[string]string{"rule": rule}
+	return e
 }
@@ -0,0 +1,86 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package validate
+
+import (
+	"errors"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ErrorDomain 验证错误在 gRPC ErrorInfo 中使用的 domain
+const ErrorDomain = "validate"
+
+// paramPrefix 错误信息参数在 ErrorInfo Metadata 中的键前缀
+const paramPrefix = "param."
+
+// statusMessage 验证错误的 gRPC 状态信息. 不使用 Error()，避免字段值（可能是密码或 token）
+// 出现在状态信息、客户端和链路日志中
+const statusMessage = "参数校验失败"
+
+// GRPCStatus 将验证错误转换为 InvalidArgument 状态，每个错误对应一个 ErrorInfo，
+// 可以直接作为 gRPC 方法的错误返回，调用方通过 FromGRPCError 还原.
+// 状态和详情中只包含字段名、错误码、错误信息和规则参数，不包含字段值
+func (es ValidationErrorsWithCode) GRPCStatus() *status.Status {
+	st := status.New(codes.InvalidArgument, statusMessage)
+
+	details := make([]protoadapt.MessageV1, 0, len(es))
+	for _, e := range es {
+		md := map[string]string{
+			"field":   e.Field,
+			"message": e.Message,
+			"rule":    e.Rule,
+		}
+		for k, v := range e.Params {
+			md[paramPrefix+k] = v
+		}
+		details = append(details, &errdetails.ErrorInfo{
+			Reason:   string(e.Code),
+			Domain:   ErrorDomain,
+			Metadata: md,
+		})
+	}
+	if ds, err := st.WithDetails(details...); err == nil {
+		return ds
+	}
+	return st
+}
+
+// FromGRPCError 从 gRPC 错误中还原验证错误，错误中没有验证错误详情时返回 false
+// 还原后的错误不包含字段值
+func FromGRPCError(err error) (ValidationErrorsWithCode, bool) {
+	var es ValidationErrorsWithCode
+	if errors.As(err, &es) {
+		return es, true
+	}
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		return nil, false
+	}
+	for _, d := range st.Details() {
+		info, ok := d.(*errdetails.ErrorInfo)
+		if !ok || info.Domain != ErrorDomain {
+			continue
+		}
+		e := NewValidationError(ErrorCode(info.Reason), info.Metadata["field"],
+			info.Metadata["message"], nil, info.Metadata["rule"])
+		for k, v := range info.Metadata {
+			if name, ok := strings.CutPrefix(k, paramPrefix); ok {
+				if e.Params == nil {
+					e.Params = make(map[string]string)
+				}
+				e.Params[name] = v
+			}
+		}
+		es = append(es, e)
+	}
+	return es, len(es) > 0
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package validate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestGRPCStatusRoundTrip 测试验证错误经过 gRPC 状态传递后可以还原
+func TestGRPCStatusRoundTrip(t *testing.T) {
+	es := ValidationErrorsWithCode{
+		NewValidationError(ErrorCodeInvalidLength, "Password", "密码长度必须在 6 到 32 个字符之间", "secret", "length(6|32)"),
+		NewValidationError(ErrorCodePasswordTooWeak, "Password", "密码必须包含数字", nil, "digit"),
+	}
+
+	// 模拟经过网络传输：只保留 status proto
+	st := status.Convert(es)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("状态码应为 InvalidArgument，实际为 %v", st.Code())
+	}
+	if strings.Contains(st.Message(), "secret") || strings.Contains(st.String(), "secret") {
+		t.Errorf("状态中不应包含字段值: %s", st.Message())
+	}
+	err := status.ErrorProto(st.Proto())
+
+	got, ok := FromGRPCError(err)
+	if !ok {
+		t.Fatal("应该能还原验证错误")
+	}
+	if len(got) != len(es) {
+		t.Fatalf("错误个数应为 %d，实际为 %d", len(es), len(got))
+	}
+	for i := range es {
+		if got[i].Code != es[i].Code || got[i].Field != es[i].Field ||
+			got[i].Message != es[i].Message || got[i].Rule != es[i].Rule {
+			t.Errorf("第 %d 个错误还原后不一致: %+v", i, got[i])
+		}
+		if got[i].Value != nil {
+			t.Errorf("还原后的错误不应包含字段值: %v", got[i].Value)
+		}
+	}
+
+	if _, ok := FromGRPCError(status.Error(codes.InvalidArgument, "参数错误")); ok {
+		t.Error("没有验证错误详情时应返回 false")
+	}
+	if _, ok := FromGRPCError(errors.New("other")); ok {
+		t.Error("非 gRPC 错误应返回 false")
+	}
+}
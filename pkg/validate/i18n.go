@@ -0,0 +1,115 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/clin211/miniblog-v3/pkg/i18n"
+)
+
+// ruleError 为单条规则验证失败的错误，记录错误代码和信息模板的参数，用于本地化
+type ruleError struct {
+	code    ErrorCode
+	rule    string
+	message string
+	params  map[string]string
+}
+
+// Error 实现 error 接口，返回默认语言的错误信息
+func (e *ruleError) Error() string {
+	return e.message
+}
+
+// newRuleError 创建规则验证错误，format 和 args 生成默认语言的错误信息
+func newRuleError(code ErrorCode, params map[string]string, format string, args ...interface{}) error {
+	return &ruleError{code: code, message: fmt.Sprintf(format, args...), params: params}
+}
+
+// newTypeError 创建字段类型不匹配的错误
+func newTypeError() error {
+	return newRuleError(ErrorCodeInvalidType, nil, "字段必须是字符串类型")
+}
+
+// localizeMessage 按错误代码和规则查找信息模板并填充参数.
+// 依次尝试 validate.<code>.<rule> 和 validate.<code>，模板中的占位符缺少参数时尝试下一个
+func localizeMessage(locale i18n.Locale, code ErrorCode, rule string, params map[string]string) (string, bool) {
+	if code == "" {
+		return "", false
+	}
+	name, _, _ := strings.Cut(rule, "(")
+	for _, key := range []string{"validate." + string(code) + "." + name, "validate." + string(code)} {
+		if msg, ok := i18n.Translate(locale, key, params); ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// Localize 返回 Message 翻译为指定语言的副本，目录中没有对应模板时保留原信息
+func (e *ValidationErrorWithCode) Localize(locale i18n.Locale) *ValidationErrorWithCode {
+	c := *e
+	if msg, ok := localizeMessage(locale, e.Code, e.Rule, e.Params); ok {
+		c.Message = msg
+	}
+	return &c
+}
+
+// Describe 返回指定语言的完整错误描述，格式与 Error 相同
+func (e *ValidationErrorWithCode) Describe(locale i18n.Locale) string {
+	msg, ok := i18n.Translate(locale, "validate.field_error_with_code", map[string]string{
+		"field":   e.Field,
+		"code":    string(e.Code),
+		"message": e.Localize(locale).Message,
+		"value":   fmt.Sprint(e.Value),
+		"rule":    e.Rule,
+	})
+	if !ok {
+		return e.Error()
+	}
+	return msg
+}
+
+// Localize 返回全部错误翻译为指定语言的副本
+func (es ValidationErrorsWithCode) Localize(locale i18n.Locale) ValidationErrorsWithCode {
+	result := make(ValidationErrorsWithCode, len(es))
+	for i, e := range es {
+		result[i] = e.Localize(locale)
+	}
+	return result
+}
+
+// Localize 返回 Message 翻译为指定语言的副本，目录中没有对应模板时保留原信息
+func (e *ValidationError) Localize(locale i18n.Locale) *ValidationError {
+	c := *e
+	if msg, ok := localizeMessage(locale, e.Code, e.Rule, e.Params); ok {
+		c.Message = msg
+	}
+	return &c
+}
+
+// Describe 返回指定语言的完整错误描述，格式与 Error 相同
+func (e *ValidationError) Describe(locale i18n.Locale) string {
+	msg, ok := i18n.Translate(locale, "validate.field_error", map[string]string{
+		"field":   e.Field,
+		"message": e.Localize(locale).Message,
+		"value":   fmt.Sprint(e.Value),
+	})
+	if !ok {
+		return e.Error()
+	}
+	return msg
+}
+
+// Localize 返回全部错误翻译为指定语言的副本
+func (es ValidationErrors) Localize(locale i18n.Locale) ValidationErrors {
+	result := make(ValidationErrors, len(es))
+	for i, e := range es {
+		result[i] = e.Localize(locale)
+	}
+	return result
+}
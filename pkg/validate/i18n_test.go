@@ -0,0 +1,71 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/clin211/miniblog-v3/pkg/i18n"
+	"google.golang.org/grpc/status"
+)
+
+type localizeForm struct {
+	Name  string `valid:"required"`
+	Code  string `valid:"length(4)"`
+	Title string `valid:"length(3|5)"`
+	Age   int    `valid:"range(1|120)"`
+}
+
+// TestLocalize 测试验证错误按语言输出
+func TestLocalize(t *testing.T) {
+	err := ValidateStructWithCustomRules(&localizeForm{Code: "12", Title: "ab", Age: 200})
+	es, ok := err.(ValidationErrors)
+	if !ok || len(es) != 4 {
+		t.Fatalf("应返回 4 个验证错误，实际为 %v", err)
+	}
+
+	want := map[string]struct{ zh, en string }{
+		"Name":  {"字段不能为空", "is required"},
+		"Code":  {"长度必须为 4 个字符", "must be exactly 4 characters long"},
+		"Title": {"长度必须在 3 到 5 个字符之间", "must be between 3 and 5 characters long"},
+		"Age":   {"值必须在 1 到 120 之间", "must be between 1 and 120"},
+	}
+	for _, e := range es {
+		w := want[e.Field]
+		if e.Message != w.zh {
+			t.Errorf("%s 默认信息应为 %q，实际为 %q", e.Field, w.zh, e.Message)
+		}
+		if got := e.Localize(i18n.EnUS).Message; got != w.en {
+			t.Errorf("%s 英文信息应为 %q，实际为 %q", e.Field, w.en, got)
+		}
+	}
+	if es[0].Code != ErrorCodeRequired || es[0].Rule != "required" {
+		t.Errorf("错误代码和规则不正确: %+v", es[0])
+	}
+	if got := es[0].Describe(i18n.EnUS); got != "Field 'Name' failed validation: is required (value: )" {
+		t.Errorf("英文描述不正确: %s", got)
+	}
+	if got := es[0].Describe(i18n.ZhCN); got != es[0].Error() {
+		t.Errorf("中文描述应与 Error 相同: %s", got)
+	}
+}
+
+// TestLocalizeAfterGRPC 测试经过 gRPC 传输后验证错误仍可按语言输出
+func TestLocalizeAfterGRPC(t *testing.T) {
+	e := NewLengthError("Username", "ab", 3, 20)
+	err := status.ErrorProto(status.Convert(ValidationErrorsWithCode{e}).Proto())
+
+	got, ok := FromGRPCError(err)
+	if !ok {
+		t.Fatal("应该能还原验证错误")
+	}
+	if msg := got.Localize(i18n.EnUS)[0].Message; msg != "must be between 3 and 20 characters long" {
+		t.Errorf("英文信息不正确: %s", msg)
+	}
+	if got[0].Message != e.Message {
+		t.Errorf("Localize 不应修改原错误: %s", got[0].Message)
+	}
+}
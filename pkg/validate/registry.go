@@ -0,0 +1,194 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// RuleKind 验证规则的类型
+type RuleKind string
+
+const (
+	// RuleKindSimple 无参数的规则，例如 email
+	RuleKindSimple RuleKind = "simple"
+	// RuleKindParametric 带参数的规则，例如 length(3|20)
+	RuleKindParametric RuleKind = "parametric"
+	// RuleKindAlias 规则别名，展开为一组规则，例如 username
+	RuleKindAlias RuleKind = "alias"
+)
+
+// RuleFunc 自定义无参数规则的验证函数，返回值是否合法. 空值不会调用验证函数
+type RuleFunc func(field reflect.Value) bool
+
+// ParametricRuleFunc 自定义带参数规则的验证函数，返回值是否合法. 参数不合法时返回 error，作为 INVALID_RULE 错误报告.
+// 空值不会调用验证函数
+type ParametricRuleFunc func(field reflect.Value, params []string) (bool, error)
+
+// ruleFunc 规则的验证函数，parent 为字段所在的结构体，用于跨字段规则
+type ruleFunc func(parent, field reflect.Value, fieldType reflect.StructField, params []string) error
+
+// ruleEntry 已注册的规则
+type ruleEntry struct {
+	info ValidationRule
+	fn   ruleFunc
+}
+
+var registry = struct {
+	sync.RWMutex
+	rules map[string]*ruleEntry
+}{rules: make(map[string]*ruleEntry)}
+
+// RegisterRule 注册无参数的验证规则，通常在 init 中调用. rule.Code 为验证失败时的错误代码，
+// rule.Message 为默认语言的错误信息. 其他语言的错误信息通过 i18n.Register 注册 validate.<Code>.
+// 名称为空或重复、Code 为空或 fn 为 nil 时 panic
+func RegisterRule(rule ValidationRule, fn RuleFunc) {
+	if fn == nil {
+		panic(fmt.Sprintf("validate: rule %q has nil func", rule.Name))
+	}
+	rule.Kind = RuleKindSimple
+	checkCustomRule(rule)
+	registerRule(rule, func(_, field reflect.Value, _ reflect.StructField, _ []string) error {
+		if field.IsZero() || fn(field) {
+			return nil
+		}
+		return newCustomRuleError(rule, nil)
+	})
+}
+
+// RegisterParametricRule 注册带参数的验证规则，通常在 init 中调用. rule.Params 为参数的名称，
+// 按顺序与标签中的参数对应，可以在 rule.Message 中用 {name} 引用. 其他要求同 RegisterRule
+func RegisterParametricRule(rule ValidationRule, fn ParametricRuleFunc) {
+	if fn == nil {
+		panic(fmt.Sprintf("validate: rule %q has nil func", rule.Name))
+	}
+	rule.Kind = RuleKindParametric
+	checkCustomRule(rule)
+	registerRule(rule, func(_, field reflect.Value, _ reflect.StructField, params []string) error {
+		if field.IsZero() {
+			return nil
+		}
+		ok, err := fn(field, params)
+		if err != nil {
+			return newRuleError(ErrorCodeInvalidRule, map[string]string{"rule": rule.Name}, "%s规则的参数不合法: %v", rule.Name, err)
+		}
+		if ok {
+			return nil
+		}
+		return newCustomRuleError(rule, params)
+	})
+}
+
+// RegisterAlias 注册规则别名，标签中的别名展开为 tag 中的规则，例如:
+//
+//	validate.RegisterAlias("username", "required,length(3|20),alphanum", "用户名")
+//
+// tag 中可以引用已注册的别名. 名称重复或 tag 中有未注册的规则时 panic
+func RegisterAlias(name, tag, description string) {
+	rules := expandAliases(parseValidationRules(tag))
+	for _, rule := range rules {
+		ruleName, _ := parseParametricRule(strings.TrimSpace(rule))
+		if _, ok := LookupRule(ruleName); !ok {
+			panic(fmt.Sprintf("validate: alias %q refers to unknown rule %q", name, ruleName))
+		}
+	}
+	expands := strings.Join(rules, ",")
+	registerRule(ValidationRule{
+		Name:        name,
+		Kind:        RuleKindAlias,
+		Description: description,
+		Example:     fmt.Sprintf(`valid:"%s"`, name),
+		Expands:     expands,
+		Tags:        []string{"别名"},
+	}, nil)
+}
+
+// Rules 返回全部已注册的规则和别名，按名称排序
+func Rules() []ValidationRule {
+	registry.RLock()
+	defer registry.RUnlock()
+	rules := make([]ValidationRule, 0, len(registry.rules))
+	for _, e := range registry.rules {
+		rules = append(rules, e.info)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+	return rules
+}
+
+// LookupRule 返回已注册的规则或别名
+func LookupRule(name string) (ValidationRule, bool) {
+	e, ok := lookupRule(name)
+	if !ok {
+		return ValidationRule{}, false
+	}
+	return e.info, true
+}
+
+// checkCustomRule 检查自定义规则的声明
+func checkCustomRule(rule ValidationRule) {
+	if rule.Code == "" {
+		panic(fmt.Sprintf("validate: rule %q has empty error code", rule.Name))
+	}
+	if rule.Message == "" {
+		panic(fmt.Sprintf("validate: rule %q has empty message", rule.Name))
+	}
+}
+
+// registerRule 注册规则，名称为空、包含标签的分隔符或已注册时 panic
+func registerRule(info ValidationRule, fn ruleFunc) {
+	if info.Name == "" || strings.ContainsAny(info.Name, ",|() \t") {
+		panic(fmt.Sprintf("validate: invalid rule name %q", info.Name))
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.rules[info.Name]; ok {
+		panic(fmt.Sprintf("validate: rule %q already registered", info.Name))
+	}
+	registry.rules[info.Name] = &ruleEntry{info: info, fn: fn}
+}
+
+// lookupRule 查找已注册的规则
+func lookupRule(name string) (*ruleEntry, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	e, ok := registry.rules[name]
+	return e, ok
+}
+
+// expandAliases 将规则列表中的别名展开
+func expandAliases(rules []string) []string {
+	result := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		if e, ok := lookupRule(strings.TrimSpace(rule)); ok && e.info.Kind == RuleKindAlias {
+			result = append(result, parseValidationRules(e.info.Expands)...)
+			continue
+		}
+		result = append(result, rule)
+	}
+	return result
+}
+
+// newCustomRuleError 创建自定义规则的验证错误，按 rule.Params 将参数填充到错误信息中
+func newCustomRuleError(rule ValidationRule, params []string) error {
+	var values map[string]string
+	var oldnew []string
+	for i, name := range rule.Params {
+		if i >= len(params) {
+			break
+		}
+		if values == nil {
+			values = make(map[string]string, len(rule.Params))
+		}
+		values[name] = params[i]
+		oldnew = append(oldnew, "{"+name+"}", params[i])
+	}
+	return newRuleError(rule.Code, values, "%s", strings.NewReplacer(oldnew...).Replace(rule.Message))
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package validate
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/clin211/miniblog-v3/pkg/i18n"
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// 注册表是全局的，测试用的规则只注册一次
+func init() {
+	RegisterRule(ValidationRule{
+		Name:        "test_slug",
+		Code:        "INVALID_SLUG",
+		Message:     "只能包含小写字母、数字和连字符",
+		Description: "URL 友好的标识",
+	}, func(field reflect.Value) bool {
+		return field.Kind() == reflect.String && slugPattern.MatchString(field.String())
+	})
+	RegisterParametricRule(ValidationRule{
+		Name:    "test_multiple_of",
+		Code:    "INVALID_MULTIPLE",
+		Params:  []string{"n"},
+		Message: "必须是 {n} 的倍数",
+	}, func(field reflect.Value, params []string) (bool, error) {
+		if len(params) != 1 {
+			return false, strconv.ErrSyntax
+		}
+		n, err := strconv.ParseInt(params[0], 10, 64)
+		if err != nil || n == 0 {
+			return false, strconv.ErrSyntax
+		}
+		return field.CanInt() && field.Int()%n == 0, nil
+	})
+	RegisterAlias("test_name", "required,length(3|10)", "名称")
+	RegisterAlias("test_handle", "test_name,test_slug", "标识")
+
+	i18n.Register(i18n.EnUS, map[string]string{
+		"validate.INVALID_SLUG":     "may only contain lowercase letters, digits and hyphens",
+		"validate.INVALID_MULTIPLE": "must be a multiple of {n}",
+	})
+}
+
+type customRuleForm struct {
+	Handle string   `json:"handle" valid:"test_handle"`
+	Size   int      `json:"size" valid:"test_multiple_of(4)"`
+	Tags   []string `json:"tags" valid:"dive,test_slug"`
+}
+
+// TestCustomRules 测试自定义规则和别名
+func TestCustomRules(t *testing.T) {
+	valid := customRuleForm{Handle: "go-zero", Size: 8, Tags: []string{"a-b"}}
+	if err := ValidateStructWithCustomRules(&valid); err != nil {
+		t.Fatalf("合法的请求不应验证失败: %v", err)
+	}
+	if err := ValidateStructWithCustomRules(&customRuleForm{Handle: "abc"}); err != nil {
+		t.Fatalf("自定义规则应跳过空值: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		form   customRuleForm
+		field  string
+		code   ErrorCode
+		rule   string
+		zh, en string
+	}{
+		{"别名展开", customRuleForm{Handle: ""}, "handle", ErrorCodeRequired, "required", "字段不能为空", "is required"},
+		{"嵌套别名", customRuleForm{Handle: "Go_Zero"}, "handle", "INVALID_SLUG", "test_slug",
+			"只能包含小写字母、数字和连字符", "may only contain lowercase letters, digits and hyphens"},
+		{"带参数的规则", customRuleForm{Handle: "abc", Size: 6}, "size", "INVALID_MULTIPLE", "test_multiple_of(4)",
+			"必须是 4 的倍数", "must be a multiple of 4"},
+		{"dive 使用自定义规则", customRuleForm{Handle: "abc", Tags: []string{"ok", "Not OK"}}, "tags[1]", "INVALID_SLUG", "test_slug",
+			"只能包含小写字母、数字和连字符", "may only contain lowercase letters, digits and hyphens"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateStructWithCustomRules(&tt.form)
+			es, ok := err.(ValidationErrors)
+			if !ok || len(es) != 1 {
+				t.Fatalf("应该返回一个验证错误，实际为 %v", err)
+			}
+			e := es[0]
+			if e.Field != tt.field || e.Code != tt.code || e.Rule != tt.rule {
+				t.Errorf("错误应为 %s [%s] %s，实际为 %s [%s] %s", tt.field, tt.code, tt.rule, e.Field, e.Code, e.Rule)
+			}
+			if e.Message != tt.zh {
+				t.Errorf("默认信息应为 %q，实际为 %q", tt.zh, e.Message)
+			}
+			if got := e.Localize(i18n.EnUS).Message; got != tt.en {
+				t.Errorf("英文信息应为 %q，实际为 %q", tt.en, got)
+			}
+		})
+	}
+}
+
+// TestCustomRuleInvalidParams 测试自定义规则的参数不合法
+func TestCustomRuleInvalidParams(t *testing.T) {
+	form := &struct {
+		Size int `json:"size" valid:"test_multiple_of(x)"`
+	}{Size: 3}
+
+	err := ValidateStructWithCustomRules(form)
+	es, ok := err.(ValidationErrors)
+	if !ok || len(es) != 1 || es[0].Code != ErrorCodeInvalidRule {
+		t.Fatalf("应该返回 %s 错误，实际为 %v", ErrorCodeInvalidRule, err)
+	}
+}
+
+// TestRuleCatalog 测试规则目录
+func TestRuleCatalog(t *testing.T) {
+	rules := Rules()
+	if !sort.SliceIsSorted(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name }) {
+		t.Error("规则目录应按名称排序")
+	}
+
+	for _, name := range []string{RuleRequired, RuleEmail, "length", RuleEqField, RuleDive, RuleUnique, RuleSSN} {
+		if _, ok := LookupRule(name); !ok {
+			t.Errorf("内置规则 %s 应在目录中", name)
+		}
+	}
+
+	slug, _ := LookupRule("test_slug")
+	if slug.Kind != RuleKindSimple || slug.Code != "INVALID_SLUG" || slug.Description != "URL 友好的标识" {
+		t.Errorf("自定义规则信息不正确: %+v", slug)
+	}
+	length, _ := GetRuleByName("length")
+	if length.Kind != RuleKindParametric || length.Code != ErrorCodeInvalidLength {
+		t.Errorf("内置规则信息不正确: %+v", length)
+	}
+	handle, _ := LookupRule("test_handle")
+	if handle.Kind != RuleKindAlias || handle.Expands != "required,length(3|10),test_slug" {
+		t.Errorf("别名信息不正确: %+v", handle)
+	}
+	if len(GetRulesByTag("别名")) < 2 {
+		t.Error("应该能按标签查找别名")
+	}
+}
+
+// TestRegisterRulePanics 测试非法的注册
+func TestRegisterRulePanics(t *testing.T) {
+	ok := func(reflect.Value) bool { return true }
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"名称重复", func() { RegisterRule(ValidationRule{Name: RuleEmail, Code: "X", Message: "x"}, ok) }},
+		{"名称包含分隔符", func() { RegisterRule(ValidationRule{Name: "a,b", Code: "X", Message: "x"}, ok) }},
+		{"缺少错误代码", func() { RegisterRule(ValidationRule{Name: "test_no_code", Message: "x"}, ok) }},
+		{"缺少验证函数", func() { RegisterRule(ValidationRule{Name: "test_no_func", Code: "X", Message: "x"}, nil) }},
+		{"别名引用未知规则", func() { RegisterAlias("test_bad_alias", "required,no_such_rule", "") }},
+		{"别名与规则重名", func() { RegisterAlias(RuleRequired, "email", "") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("应该 panic")
+				}
+			}()
+			tt.fn()
+		})
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v3.git.
+
+package validate
+
+import "fmt"
+
+// RequestRule 校验一个请求消息
+type RequestRule func(req any) error
+
+// RequestRules 按 gRPC 方法全名保存请求消息的校验函数，由 middleware.ValidateInterceptor 在调用 handler 前执行.
+// protobuf 生成的消息不能添加标签，校验函数与生成代码分开维护，重新生成时不会丢失
+type RequestRules map[string]RequestRule
+
+// ForRequest 将校验具体请求类型的函数适配为 RequestRule，请求类型不匹配时返回错误
+func ForRequest[T any](fn func(req *T) error) RequestRule {
+	return func(req any) error {
+		r, ok := req.(*T)
+		if !ok {
+			return fmt.Errorf("validate: unexpected request type %T", req)
+		}
+		return fn(r)
+	}
+}
@@ -5,6 +5,11 @@
 
 package validate
 
+import (
+	"fmt"
+	"reflect"
+)
+
 // 常用验证规则常量
 const (
 	// 基础验证规则
@@ -44,6 +49,38 @@ const (
 	RuleSSN = "ssn"
 )
 
+// 跨字段和条件验证规则，参数为同一结构体中的字段名
+const (
+	// RuleEqField 与指定字段的值相同，例如 eqfield(Password)
+	RuleEqField = "eqfield"
+	// RuleNeField 与指定字段的值不同，例如 nefield(OldPassword)
+	RuleNeField = "nefield"
+	// RuleRequiredIf 指定字段等于某个值时必填，例如 required_if(RegisterSource|3)
+	RuleRequiredIf = "required_if"
+	// RuleRequiredWith 任一指定字段不为空时必填，例如 required_with(Province|City)
+	RuleRequiredWith = "required_with"
+	// RuleRequiredWithout 任一指定字段为空时必填，例如 required_without(Phone)
+	RuleRequiredWithout = "required_without"
+	// RuleGtField 大于指定字段，例如 gtfield(StartTime)
+	RuleGtField = "gtfield"
+	// RuleLtField 小于指定字段，例如 ltfield(EndTime)
+	RuleLtField = "ltfield"
+	// RuleOneOfRequired 与指定字段至少填写一个，例如 oneof_required(Phone)
+	RuleOneOfRequired = "oneof_required"
+)
+
+// 集合验证规则，用于切片、数组和映射
+const (
+	// RuleDive 之后的规则应用到集合的每个元素，例如 dive,required,url
+	RuleDive = "dive"
+	// RuleMinItems 元素个数不少于指定值，例如 min_items(1)
+	RuleMinItems = "min_items"
+	// RuleMaxItems 元素个数不多于指定值，例如 max_items(10)
+	RuleMaxItems = "max_items"
+	// RuleUnique 元素不能重复，结构体元素可以指定比较的字段，例如 unique(URL)
+	RuleUnique = "unique"
+)
+
 // 常用长度规则
 const (
 	// 用户名长度规则
@@ -103,102 +140,153 @@ const (
 
 // ValidationRule 验证规则结构体
 type ValidationRule struct {
-	Name        string   `json:"name"`
-	Description string   `json:"description"`
-	Example     string   `json:"example"`
-	Tags        []string `json:"tags"`
+	Name        string    `json:"name"`
+	Kind        RuleKind  `json:"kind"`
+	Code        ErrorCode `json:"code,omitempty"`    // 验证失败时的错误代码
+	Params      []string  `json:"params,omitempty"`  // 参数名称，按顺序与标签中的参数对应
+	Message     string    `json:"message,omitempty"` // 自定义规则默认语言的错误信息，可以用 {name} 引用参数
+	Expands     string    `json:"expands,omitempty"` // 别名展开后的规则
+	Description string    `json:"description"`
+	Example     string    `json:"example"`
+	Tags        []string  `json:"tags"`
+}
+
+// 注册内置规则
+func init() {
+	// 基础验证规则
+	registerRule(ValidationRule{Name: RuleRequired, Kind: RuleKindSimple, Code: ErrorCodeRequired,
+		Description: "字段不能为空", Example: `valid:"required"`, Tags: []string{"基础", "必填"}}, simpleRule(validateRequired))
+	registerRule(ValidationRule{Name: RuleEmail, Kind: RuleKindSimple, Code: ErrorCodeInvalidEmail,
+		Description: "邮箱格式验证", Example: `valid:"required,email"`, Tags: []string{"基础", "邮箱"}}, simpleRule(validateEmail))
+	registerRule(ValidationRule{Name: RuleURL, Kind: RuleKindSimple, Code: ErrorCodeInvalidURL,
+		Description: "URL格式验证", Example: `valid:"url"`, Tags: []string{"基础", "URL"}}, simpleRule(validateURL))
+	registerRule(ValidationRule{Name: RuleUUID, Kind: RuleKindSimple, Code: ErrorCodeInvalidUUID,
+		Description: "UUID格式验证", Example: `valid:"uuid"`, Tags: []string{"基础", "UUID"}}, simpleRule(validateUUID))
+	registerRule(ValidationRule{Name: RuleJSON, Kind: RuleKindSimple, Code: ErrorCodeInvalidJSON,
+		Description: "JSON格式验证", Example: `valid:"json"`, Tags: []string{"基础", "JSON"}}, simpleRule(validateJSON))
+
+	// 字符类型验证规则
+	registerRule(ValidationRule{Name: RuleAlpha, Kind: RuleKindSimple, Code: ErrorCodeInvalidAlpha,
+		Description: "只能包含字母", Example: `valid:"alpha"`, Tags: []string{"字符", "字母"}}, simpleRule(validateAlpha))
+	registerRule(ValidationRule{Name: RuleNumeric, Kind: RuleKindSimple, Code: ErrorCodeInvalidNumeric,
+		Description: "只能包含数字", Example: `valid:"numeric"`, Tags: []string{"字符", "数字"}}, simpleRule(validateNumeric))
+	registerRule(ValidationRule{Name: RuleAlphanum, Kind: RuleKindSimple, Code: ErrorCodeInvalidAlphanum,
+		Description: "只能包含字母和数字", Example: `valid:"alphanum"`, Tags: []string{"字符", "字母数字"}}, simpleRule(validateAlphanum))
+	registerRule(ValidationRule{Name: RuleASCII, Kind: RuleKindSimple, Code: ErrorCodeInvalidASCII,
+		Description: "只能包含ASCII字符", Example: `valid:"ascii"`, Tags: []string{"字符", "ASCII"}}, simpleRule(validateASCII))
+
+	// 网络相关验证规则
+	registerRule(ValidationRule{Name: RuleIPv4, Kind: RuleKindSimple, Code: ErrorCodeInvalidIPv4,
+		Description: "IPv4地址验证", Example: `valid:"ipv4"`, Tags: []string{"网络", "IP"}}, simpleRule(validateIPv4))
+	registerRule(ValidationRule{Name: RuleIPv6, Kind: RuleKindSimple, Code: ErrorCodeInvalidIPv6,
+		Description: "IPv6地址验证", Example: `valid:"ipv6"`, Tags: []string{"网络", "IP"}}, simpleRule(validateIPv6))
+	registerGovalidatorRule(RulePort, ErrorCodeInvalidPort, "端口号验证", "网络")
+	registerGovalidatorRule(RuleDNS, ErrorCodeInvalidDNS, "DNS名称验证", "网络")
+	registerGovalidatorRule(RuleHost, ErrorCodeInvalidHost, "主机名验证", "网络")
+	registerGovalidatorRule(RuleMAC, ErrorCodeInvalidMAC, "MAC地址验证", "网络")
+
+	// 数据格式验证规则
+	registerGovalidatorRule(RuleBase64, ErrorCodeInvalidBase64, "Base64编码验证", "格式")
+	registerGovalidatorRule(RuleDataURI, ErrorCodeInvalidDataURI, "Data URI验证", "格式")
+	registerGovalidatorRule(RuleRFC3339, ErrorCodeInvalidRFC3339, "RFC3339时间格式验证", "格式")
+	registerGovalidatorRule(RuleSemver, ErrorCodeInvalidSemver, "语义化版本验证", "格式")
+	registerGovalidatorRule(RuleULID, ErrorCodeInvalidULID, "ULID验证", "格式")
+	registerGovalidatorRule(RuleYYYYMMDD, ErrorCodeInvalidYYYYMMDD, "YYYYMMDD日期格式验证", "格式")
+
+	// 地理位置验证规则
+	registerGovalidatorRule(RuleLatitude, ErrorCodeInvalidLatitude, "纬度验证", "地理")
+	registerGovalidatorRule(RuleLongitude, ErrorCodeInvalidLongitude, "经度验证", "地理")
+
+	// 其他验证规则
+	registerGovalidatorRule(RuleSSN, ErrorCodeInvalidSSN, "SSN验证", "其他")
+
+	// 带参数的验证规则
+	registerRule(ValidationRule{Name: "length", Kind: RuleKindParametric, Code: ErrorCodeInvalidLength, Params: []string{"min", "max"},
+		Description: "字符串长度验证，一个参数时为固定长度", Example: `valid:"length(3|20)"`, Tags: []string{"长度", "范围"}}, parametricRule(validateLength))
+	registerRule(ValidationRule{Name: "range", Kind: RuleKindParametric, Code: ErrorCodeInvalidRange, Params: []string{"min", "max"},
+		Description: "数值范围验证", Example: `valid:"range(1|100)"`, Tags: []string{"数值", "范围"}}, parametricRule(validateRange))
+	registerRule(ValidationRule{Name: "matches", Kind: RuleKindParametric, Code: ErrorCodeInvalidMatches, Params: []string{"pattern"},
+		Description: "正则表达式匹配验证", Example: `valid:"matches(^1[3-9]\\d{9}$)"`, Tags: []string{"正则", "模式"}}, parametricRule(validateMatches))
+	registerRule(ValidationRule{Name: "in", Kind: RuleKindParametric, Code: ErrorCodeInvalidEnum, Params: []string{"values"},
+		Description: "枚举值验证", Example: `valid:"in(active|inactive|pending)"`, Tags: []string{"枚举", "选择"}}, parametricRule(validateIn))
+
+	// 跨字段和条件验证规则
+	registerRule(ValidationRule{Name: RuleEqField, Kind: RuleKindParametric, Code: ErrorCodeInvalidEqField, Params: []string{"field"},
+		Description: "与指定字段的值相同", Example: `valid:"required,eqfield(Password)"`, Tags: []string{"跨字段", "比较"}}, validateEqField)
+	registerRule(ValidationRule{Name: RuleNeField, Kind: RuleKindParametric, Code: ErrorCodeInvalidNeField, Params: []string{"field"},
+		Description: "与指定字段的值不同", Example: `valid:"nefield(OldPassword)"`, Tags: []string{"跨字段", "比较"}}, validateNeField)
+	registerRule(ValidationRule{Name: RuleRequiredIf, Kind: RuleKindParametric, Code: ErrorCodeRequiredIf, Params: []string{"field", "value"},
+		Description: "指定字段等于任一给定值时必填", Example: `valid:"required_if(RegisterSource|3)"`, Tags: []string{"跨字段", "必填"}}, validateRequiredIf)
+	registerRule(ValidationRule{Name: RuleRequiredWith, Kind: RuleKindParametric, Code: ErrorCodeRequiredWith, Params: []string{"fields"},
+		Description: "任一指定字段不为空时必填", Example: `valid:"required_with(Province|City)"`, Tags: []string{"跨字段", "必填"}}, validateRequiredWith)
+	registerRule(ValidationRule{Name: RuleRequiredWithout, Kind: RuleKindParametric, Code: ErrorCodeRequiredWithout, Params: []string{"fields"},
+		Description: "任一指定字段为空时必填", Example: `valid:"required_without(Phone)"`, Tags: []string{"跨字段", "必填"}}, validateRequiredWithout)
+	registerRule(ValidationRule{Name: RuleGtField, Kind: RuleKindParametric, Code: ErrorCodeInvalidGtField, Params: []string{"field"},
+		Description: "大于指定字段，支持数值、time.Time 和日期字符串", Example: `valid:"gtfield(StartTime)"`, Tags: []string{"跨字段", "比较"}}, validateGtField)
+	registerRule(ValidationRule{Name: RuleLtField, Kind: RuleKindParametric, Code: ErrorCodeInvalidLtField, Params: []string{"field"},
+		Description: "小于指定字段，支持数值、time.Time 和日期字符串", Example: `valid:"ltfield(EndTime)"`, Tags: []string{"跨字段", "比较"}}, validateLtField)
+	registerRule(ValidationRule{Name: RuleOneOfRequired, Kind: RuleKindParametric, Code: ErrorCodeOneOfRequired, Params: []string{"fields"},
+		Description: "与指定字段至少填写一个", Example: `valid:"oneof_required(Phone)"`, Tags: []string{"跨字段", "必填"}}, validateOneOfRequired)
+
+	// 集合验证规则，dive 在应用规则前处理，没有验证函数
+	registerRule(ValidationRule{Name: RuleDive, Kind: RuleKindSimple,
+		Description: "之后的规则应用到切片、数组或映射的每个元素", Example: `valid:"max_items(5),dive,required,length(1|20)"`, Tags: []string{"集合", "元素"}}, nil)
+	registerRule(ValidationRule{Name: RuleMinItems, Kind: RuleKindParametric, Code: ErrorCodeInvalidMinItems, Params: []string{"min"},
+		Description: "元素个数不少于指定值，空集合同样检查", Example: `valid:"min_items(1)"`, Tags: []string{"集合", "数量"}}, parametricRule(validateMinItems))
+	registerRule(ValidationRule{Name: RuleMaxItems, Kind: RuleKindParametric, Code: ErrorCodeInvalidMaxItems, Params: []string{"max"},
+		Description: "元素个数不多于指定值", Example: `valid:"max_items(10)"`, Tags: []string{"集合", "数量"}}, parametricRule(validateMaxItems))
+	registerRule(ValidationRule{Name: RuleUnique, Kind: RuleKindParametric, Code: ErrorCodeInvalidUnique, Params: []string{"field"},
+		Description: "元素不能重复，结构体元素可以指定比较的字段，参数可以省略", Example: `valid:"unique"`, Tags: []string{"集合", "唯一"}}, parametricRule(validateUnique))
+}
+
+// simpleRule 将无参数的内置验证函数转换为 ruleFunc
+func simpleRule(fn func(reflect.Value, reflect.StructField) error) ruleFunc {
+	return func(_, field reflect.Value, fieldType reflect.StructField, _ []string) error {
+		return fn(field, fieldType)
+	}
 }
 
-// GetCommonRules 获取常用验证规则
+// parametricRule 将带参数的内置验证函数转换为 ruleFunc
+func parametricRule(fn func(reflect.Value, reflect.StructField, []string) error) ruleFunc {
+	return func(_, field reflect.Value, fieldType reflect.StructField, params []string) error {
+		return fn(field, fieldType, params)
+	}
+}
+
+// registerGovalidatorRule 注册由 govalidator 实现的无参数规则
+func registerGovalidatorRule(name string, code ErrorCode, description, tag string) {
+	registerRule(ValidationRule{
+		Name:        name,
+		Kind:        RuleKindSimple,
+		Code:        code,
+		Description: description,
+		Example:     fmt.Sprintf(`valid:"%s"`, name),
+		Tags:        []string{tag},
+	}, func(_, field reflect.Value, fieldType reflect.StructField, _ []string) error {
+		return validateWithGovalidator(field, fieldType, name)
+	})
+}
+
+// GetCommonRules 获取全部已注册的验证规则和别名，键为规则名称
 func GetCommonRules() map[string]ValidationRule {
-	return map[string]ValidationRule{
-		// 基础验证规则
-		"required": {
-			Name:        "required",
-			Description: "字段不能为空",
-			Example:     `valid:"required"`,
-			Tags:        []string{"基础", "必填"},
-		},
-		"email": {
-			Name:        "email",
-			Description: "邮箱格式验证",
-			Example:     `valid:"required,email"`,
-			Tags:        []string{"基础", "邮箱"},
-		},
-		"url": {
-			Name:        "url",
-			Description: "URL格式验证",
-			Example:     `valid:"url"`,
-			Tags:        []string{"基础", "URL"},
-		},
-
-		// 字符类型验证规则
-		"alpha": {
-			Name:        "alpha",
-			Description: "只能包含字母",
-			Example:     `valid:"alpha"`,
-			Tags:        []string{"字符", "字母"},
-		},
-		"numeric": {
-			Name:        "numeric",
-			Description: "只能包含数字",
-			Example:     `valid:"numeric"`,
-			Tags:        []string{"字符", "数字"},
-		},
-		"alphanum": {
-			Name:        "alphanum",
-			Description: "只能包含字母和数字",
-			Example:     `valid:"alphanum"`,
-			Tags:        []string{"字符", "字母数字"},
-		},
-
-		// 长度验证规则
-		"length": {
-			Name:        "length",
-			Description: "字符串长度验证",
-			Example:     `valid:"length(3|20)"`,
-			Tags:        []string{"长度", "范围"},
-		},
-
-		// 数值范围验证规则
-		"range": {
-			Name:        "range",
-			Description: "数值范围验证",
-			Example:     `valid:"range(1|100)"`,
-			Tags:        []string{"数值", "范围"},
-		},
-
-		// 正则表达式验证规则
-		"matches": {
-			Name:        "matches",
-			Description: "正则表达式匹配验证",
-			Example:     `valid:"matches(^1[3-9]\\d{9}$)"`,
-			Tags:        []string{"正则", "模式"},
-		},
-
-		// 枚举值验证规则
-		"in": {
-			Name:        "in",
-			Description: "枚举值验证",
-			Example:     `valid:"in(active|inactive|pending)"`,
-			Tags:        []string{"枚举", "选择"},
-		},
+	rules := Rules()
+	result := make(map[string]ValidationRule, len(rules))
+	for _, rule := range rules {
+		result[rule.Name] = rule
 	}
+	return result
 }
 
 // GetRuleByName 根据规则名称获取规则信息
 func GetRuleByName(name string) (ValidationRule, bool) {
-	rules := GetCommonRules()
-	rule, exists := rules[name]
-	return rule, exists
+	return LookupRule(name)
 }
 
-// GetRulesByTag 根据标签获取规则列表
+// GetRulesByTag 根据标签获取规则列表，按名称排序
 func GetRulesByTag(tag string) []ValidationRule {
-	rules := GetCommonRules()
 	var result []ValidationRule
 
-	for _, rule := range rules {
+	for _, rule := range Rules() {
 		for _, ruleTag := range rule.Tags {
 			if ruleTag == tag {
 				result = append(result, rule)
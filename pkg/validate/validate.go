@@ -6,9 +6,11 @@
 package validate
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/asaskevich/govalidator"
@@ -21,9 +23,12 @@ type Validator interface {
 
 // ValidationError 验证错误
 type ValidationError struct {
-	Field   string      `json:"field"`
-	Message string      `json:"message"`
-	Value   interface{} `json:"value"`
+	Field   string            `json:"field"`
+	Message string            `json:"message"`
+	Value   interface{}       `json:"value"`
+	Code    ErrorCode         `json:"code,omitempty"`   // 错误代码，用于本地化
+	Rule    string            `json:"rule,omitempty"`   // 验证失败的规则
+	Params  map[string]string `json:"params,omitempty"` // 错误信息模板的参数
 }
 
 // Error 实现 error 接口
@@ -52,6 +57,31 @@ func (es ValidationErrors) HasErrors() bool {
 	return len(es) > 0
 }
 
+// ToMap 转换为错误映射
+func (es ValidationErrors) ToMap() map[string][]string {
+	result := make(map[string][]string)
+	for _, err := range es {
+		result[err.Field] = append(result[err.Field], err.Message)
+	}
+	return result
+}
+
+// WithCode 转换为带错误代码的验证错误，可以作为 gRPC 方法的错误返回
+func (es ValidationErrors) WithCode() ValidationErrorsWithCode {
+	result := make(ValidationErrorsWithCode, len(es))
+	for i, e := range es {
+		result[i] = &ValidationErrorWithCode{
+			Code:    e.Code,
+			Field:   e.Field,
+			Message: e.Message,
+			Value:   e.Value,
+			Rule:    e.Rule,
+			Params:  e.Params,
+		}
+	}
+	return result
+}
+
 // ValidateStruct 验证结构体
 func ValidateStruct(v interface{}) error {
 	// 使用 govalidator 进行基础验证
@@ -67,10 +97,9 @@ func ValidateStruct(v interface{}) error {
 	return nil
 }
 
-// ValidateStructWithCustomRules 使用自定义规则验证结构体
+// ValidateStructWithCustomRules 使用自定义规则验证结构体.
+// 会递归验证嵌套的结构体、指针、切片、数组和映射，错误的 Field 为 JSON 路径，例如 attachments[2].url
 func ValidateStructWithCustomRules(v interface{}) error {
-	var errors ValidationErrors
-
 	val := reflect.ValueOf(v)
 	if val.Kind() == reflect.Ptr {
 		val = val.Elem()
@@ -80,41 +109,185 @@ func ValidateStructWithCustomRules(v interface{}) error {
 		return fmt.Errorf("只能验证结构体类型")
 	}
 
+	if es := validateStruct(val, ""); es.HasErrors() {
+		return es
+	}
+
+	return nil
+}
+
+// validateStruct 验证结构体的全部字段，path 为结构体自身的路径
+func validateStruct(val reflect.Value, path string) ValidationErrors {
+	var es ValidationErrors
+
 	typ := val.Type()
 	for i := 0; i < val.NumField(); i++ {
-		field := val.Field(i)
 		fieldType := typ.Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
 
 		// 获取验证标签
 		tag := fieldType.Tag.Get("valid")
-		if tag == "" || tag == "-" {
+		if tag == "-" {
 			continue
 		}
 
-		// 执行自定义验证
-		if err := validateField(field, fieldType, tag); err != nil {
-			errors = append(errors, &ValidationError{
-				Field:   fieldType.Name,
-				Message: err.Error(),
-				Value:   field.Interface(),
-			})
+		// 与 encoding/json 一致，没有 json 名称的嵌入结构体的字段直接展开
+		fieldPath := path
+		if name, _, _ := strings.Cut(fieldType.Tag.Get("json"), ","); !fieldType.Anonymous || name != "" {
+			fieldPath = joinPath(path, fieldName(fieldType))
+		}
+
+		es = append(es, validateValue(val, val.Field(i), fieldType, fieldPath, tag)...)
+	}
+
+	return es
+}
+
+// validateValue 按标签验证字段或集合元素，再验证其中嵌套的值.
+// 标签中 dive 之前的规则作用于值本身，之后的规则作用于集合的每个元素
+func validateValue(parent, field reflect.Value, fieldType reflect.StructField, path, tag string) ValidationErrors {
+	var es ValidationErrors
+
+	rules, elemRules, dive := splitDive(tag)
+	if len(rules) > 0 {
+		if err := validateField(parent, field, fieldType, strings.Join(rules, ",")); err != nil {
+			es = append(es, newFieldError(path, field, err))
 		}
 	}
 
-	if errors.HasErrors() {
-		return errors
+	return append(es, validateNested(parent, field, fieldType, path, elemRules, dive)...)
+}
+
+// validateNested 递归验证结构体字段和集合元素，nil 指针跳过
+func validateNested(parent, field reflect.Value, fieldType reflect.StructField, path string, elemRules []string, dive bool) ValidationErrors {
+	field = indirect(field)
+	if field.Kind() == reflect.Interface && !field.IsNil() {
+		field = indirect(field.Elem())
+	}
+
+	var es ValidationErrors
+	switch field.Kind() {
+	case reflect.Struct:
+		if !dive {
+			return validateStruct(field, path)
+		}
+	case reflect.Slice, reflect.Array:
+		if !dive && !hasNested(field.Type().Elem()) {
+			return nil
+		}
+		for i := 0; i < field.Len(); i++ {
+			es = append(es, validateValue(parent, field.Index(i), fieldType,
+				fmt.Sprintf("%s[%d]", path, i), strings.Join(elemRules, ","))...)
+		}
+		return es
+	case reflect.Map:
+		if !dive && !hasNested(field.Type().Elem()) {
+			return nil
+		}
+		for _, key := range sortedMapKeys(field) {
+			es = append(es, validateValue(parent, field.MapIndex(key), fieldType,
+				fmt.Sprintf("%s[%v]", path, key.Interface()), strings.Join(elemRules, ","))...)
+		}
+		return es
+	case reflect.Ptr:
+		// nil 指针没有需要验证的元素
+		return nil
 	}
 
+	if dive {
+		err := newRuleError(ErrorCodeInvalidRule, nil, "dive规则只能用于切片、数组或映射")
+		return ValidationErrors{newFieldError(path, field, err)}
+	}
 	return nil
 }
 
-// validateField 验证单个字段
-func validateField(field reflect.Value, fieldType reflect.StructField, tag string) error {
+// splitDive 展开别名后按 dive 拆分验证规则，返回作用于值本身和作用于元素的规则
+func splitDive(tag string) ([]string, []string, bool) {
+	if tag == "" {
+		return nil, nil, false
+	}
+	rules := expandAliases(parseValidationRules(tag))
+	for i, rule := range rules {
+		if strings.TrimSpace(rule) == RuleDive {
+			return rules[:i], rules[i+1:], true
+		}
+	}
+	return rules, nil, false
+}
+
+// hasNested 判断类型中是否可能包含需要验证的字段
+func hasNested(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map, reflect.Interface:
+		return true
+	}
+	return false
+}
+
+// sortedMapKeys 返回排序后的映射键，保证错误顺序稳定
+func sortedMapKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	return keys
+}
+
+// newFieldError 根据规则错误创建字段验证错误
+func newFieldError(path string, field reflect.Value, err error) *ValidationError {
+	ve := &ValidationError{
+		Field:   path,
+		Message: err.Error(),
+	}
+	if field.IsValid() && field.CanInterface() {
+		ve.Value = field.Interface()
+	}
+	var re *ruleError
+	if errors.As(err, &re) {
+		ve.Code, ve.Rule, ve.Params = re.code, re.rule, re.params
+	}
+	return ve
+}
+
+// fieldName 返回字段在错误路径中的名称，依次使用 json、form、path 标签，都没有时使用字段名
+func fieldName(fieldType reflect.StructField) string {
+	for _, key := range []string{"json", "form", "path"} {
+		name, _, _ := strings.Cut(fieldType.Tag.Get(key), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return fieldType.Name
+}
+
+// joinPath 拼接字段路径
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// validateField 验证单个字段，parent 为字段所在的结构体，用于跨字段规则
+func validateField(parent, field reflect.Value, fieldType reflect.StructField, tag string) error {
 	// 解析验证标签
 	rules := parseValidationRules(tag)
 
 	for _, rule := range rules {
-		if err := applyValidationRule(field, fieldType, rule); err != nil {
+		// 忽略多余的逗号
+		if strings.TrimSpace(rule) == "" {
+			continue
+		}
+		if err := applyValidationRule(parent, field, fieldType, rule); err != nil {
+			var re *ruleError
+			if errors.As(err, &re) && re.rule == "" {
+				re.rule = strings.TrimSpace(rule)
+			}
 			return err
 		}
 	}
@@ -127,67 +300,30 @@ func parseValidationRules(tag string) []string {
 	return strings.Split(tag, ",")
 }
 
-// applyValidationRule 应用验证规则
-func applyValidationRule(field reflect.Value, fieldType reflect.StructField, rule string) error {
+// applyValidationRule 在注册表中查找规则并应用
+func applyValidationRule(parent, field reflect.Value, fieldType reflect.StructField, rule string) error {
 	rule = strings.TrimSpace(rule)
 
 	// 检查是否是带参数的规则
-	if strings.Contains(rule, "(") && strings.Contains(rule, ")") {
-		return applyParametricRule(field, fieldType, rule)
-	}
-
-	// 应用无参数规则
-	return applySimpleRule(field, fieldType, rule)
-}
-
-// applySimpleRule 应用简单规则
-func applySimpleRule(field reflect.Value, fieldType reflect.StructField, rule string) error {
-	switch rule {
-	case "required":
-		return validateRequired(field, fieldType)
-	case "email":
-		return validateEmail(field, fieldType)
-	case "alphanum":
-		return validateAlphanum(field, fieldType)
-	case "numeric":
-		return validateNumeric(field, fieldType)
-	case "alpha":
-		return validateAlpha(field, fieldType)
-	case "ascii":
-		return validateASCII(field, fieldType)
-	case "url":
-		return validateURL(field, fieldType)
-	case "ipv4":
-		return validateIPv4(field, fieldType)
-	case "ipv6":
-		return validateIPv6(field, fieldType)
-	case "uuid":
-		return validateUUID(field, fieldType)
-	case "json":
-		return validateJSON(field, fieldType)
-	default:
-		// 尝试使用 govalidator 的内置验证器
-		return validateWithGovalidator(field, fieldType, rule)
-	}
-}
-
-// applyParametricRule 应用带参数的规则
-func applyParametricRule(field reflect.Value, fieldType reflect.StructField, rule string) error {
-	// 解析规则名称和参数
-	ruleName, params := parseParametricRule(rule)
-
-	switch ruleName {
-	case "length":
-		return validateLength(field, fieldType, params)
-	case "range":
-		return validateRange(field, fieldType, params)
-	case "matches":
-		return validateMatches(field, fieldType, params)
-	case "in":
-		return validateIn(field, fieldType, params)
-	default:
-		return fmt.Errorf("未知的带参数验证规则: %s", ruleName)
+	name, params := rule, []string(nil)
+	parametric := strings.Contains(rule, "(") && strings.Contains(rule, ")")
+	if parametric {
+		name, params = parseParametricRule(rule)
+	}
+
+	e, ok := lookupRule(name)
+	if !ok {
+		if parametric {
+			return newRuleError(ErrorCodeUnknownRule, map[string]string{"rule": name}, "未知的带参数验证规则: %s", name)
+		}
+		return newRuleError(ErrorCodeUnknownRule, map[string]string{"rule": name}, "未知的验证规则: %s", name)
 	}
+	if e.fn == nil {
+		// 别名带参数或 dive 出现在不支持的位置
+		return newRuleError(ErrorCodeInvalidRule, map[string]string{"rule": name}, "%s规则不能在这里使用", name)
+	}
+
+	return e.fn(parent, field, fieldType, params)
 }
 
 // parseParametricRule 解析带参数的规则
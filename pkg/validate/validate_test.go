@@ -89,10 +89,10 @@ func TestRequiredField(t *testing.T) {
 		foundPassword := false
 
 		for _, validationError := range validationErrors {
-			if validationError.Field == "ID" {
+			if validationError.Field == "id" {
 				foundID = true
 			}
-			if validationError.Field == "Password" {
+			if validationError.Field == "password" {
 				foundPassword = true
 			}
 		}
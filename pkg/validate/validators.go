@@ -6,7 +6,6 @@
 package validate
 
 import (
-	"fmt"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -18,7 +17,7 @@ import (
 // validateRequired 验证必填字段
 func validateRequired(field reflect.Value, _ reflect.StructField) error {
 	if field.IsZero() {
-		return fmt.Errorf("字段不能为空")
+		return newRuleError(ErrorCodeRequired, nil, "字段不能为空")
 	}
 	return nil
 }
@@ -30,7 +29,7 @@ func validateEmail(field reflect.Value, _ reflect.StructField) error {
 	}
 
 	if field.Kind() != reflect.String {
-		return fmt.Errorf("邮箱字段必须是字符串类型")
+		return newTypeError()
 	}
 
 	email := field.String()
@@ -39,7 +38,7 @@ func validateEmail(field reflect.Value, _ reflect.StructField) error {
 	}
 
 	if !govalidator.IsEmail(email) {
-		return fmt.Errorf("邮箱格式不正确")
+		return newRuleError(ErrorCodeInvalidEmail, nil, "邮箱格式不正确")
 	}
 
 	return nil
@@ -52,13 +51,13 @@ func validateAlphanum(field reflect.Value, _ reflect.StructField) error {
 	}
 
 	if field.Kind() != reflect.String {
-		return fmt.Errorf("字段必须是字符串类型")
+		return newTypeError()
 	}
 
 	value := field.String()
 	// 允许下划线，因为用户名通常包含下划线
 	if !govalidator.IsAlphanumeric(value) && !strings.Contains(value, "_") {
-		return fmt.Errorf("只能包含字母、数字和下划线")
+		return newRuleError(ErrorCodeInvalidAlphanum, nil, "只能包含字母、数字和下划线")
 	}
 
 	return nil
@@ -71,12 +70,12 @@ func validateNumeric(field reflect.Value, _ reflect.StructField) error {
 	}
 
 	if field.Kind() != reflect.String {
-		return fmt.Errorf("字段必须是字符串类型")
+		return newTypeError()
 	}
 
 	value := field.String()
 	if !govalidator.IsNumeric(value) {
-		return fmt.Errorf("只能包含数字")
+		return newRuleError(ErrorCodeInvalidNumeric, nil, "只能包含数字")
 	}
 
 	return nil
@@ -89,12 +88,12 @@ func validateAlpha(field reflect.Value, _ reflect.StructField) error {
 	}
 
 	if field.Kind() != reflect.String {
-		return fmt.Errorf("字段必须是字符串类型")
+		return newTypeError()
 	}
 
 	value := field.String()
 	if !govalidator.IsAlpha(value) {
-		return fmt.Errorf("只能包含字母")
+		return newRuleError(ErrorCodeInvalidAlpha, nil, "只能包含字母")
 	}
 
 	return nil
@@ -107,12 +106,12 @@ func validateASCII(field reflect.Value, _ reflect.StructField) error {
 	}
 
 	if field.Kind() != reflect.String {
-		return fmt.Errorf("字段必须是字符串类型")
+		return newTypeError()
 	}
 
 	value := field.String()
 	if !govalidator.IsASCII(value) {
-		return fmt.Errorf("只能包含ASCII字符")
+		return newRuleError(ErrorCodeInvalidASCII, nil, "只能包含ASCII字符")
 	}
 
 	return nil
@@ -125,12 +124,12 @@ func validateURL(field reflect.Value, _ reflect.StructField) error {
 	}
 
 	if field.Kind() != reflect.String {
-		return fmt.Errorf("字段必须是字符串类型")
+		return newTypeError()
 	}
 
 	value := field.String()
 	if !govalidator.IsURL(value) {
-		return fmt.Errorf("URL格式不正确")
+		return newRuleError(ErrorCodeInvalidURL, nil, "URL格式不正确")
 	}
 
 	return nil
@@ -143,12 +142,12 @@ func validateIPv4(field reflect.Value, _ reflect.StructField) error {
 	}
 
 	if field.Kind() != reflect.String {
-		return fmt.Errorf("字段必须是字符串类型")
+		return newTypeError()
 	}
 
 	value := field.String()
 	if !govalidator.IsIPv4(value) {
-		return fmt.Errorf("IPv4地址格式不正确")
+		return newRuleError(ErrorCodeInvalidIPv4, nil, "IPv4地址格式不正确")
 	}
 
 	return nil
@@ -161,12 +160,12 @@ func validateIPv6(field reflect.Value, _ reflect.StructField) error {
 	}
 
 	if field.Kind() != reflect.String {
-		return fmt.Errorf("字段必须是字符串类型")
+		return newTypeError()
 	}
 
 	value := field.String()
 	if !govalidator.IsIPv6(value) {
-		return fmt.Errorf("IPv6地址格式不正确")
+		return newRuleError(ErrorCodeInvalidIPv6, nil, "IPv6地址格式不正确")
 	}
 
 	return nil
@@ -179,12 +178,12 @@ func validateUUID(field reflect.Value, _ reflect.StructField) error {
 	}
 
 	if field.Kind() != reflect.String {
-		return fmt.Errorf("字段必须是字符串类型")
+		return newTypeError()
 	}
 
 	value := field.String()
 	if !govalidator.IsUUID(value) {
-		return fmt.Errorf("UUID格式不正确")
+		return newRuleError(ErrorCodeInvalidUUID, nil, "UUID格式不正确")
 	}
 
 	return nil
@@ -197,12 +196,12 @@ func validateJSON(field reflect.Value, _ reflect.StructField) error {
 	}
 
 	if field.Kind() != reflect.String {
-		return fmt.Errorf("字段必须是字符串类型")
+		return newTypeError()
 	}
 
 	value := field.String()
 	if !govalidator.IsJSON(value) {
-		return fmt.Errorf("JSON格式不正确")
+		return newRuleError(ErrorCodeInvalidJSON, nil, "JSON格式不正确")
 	}
 
 	return nil
@@ -215,40 +214,40 @@ func validateLength(field reflect.Value, _ reflect.StructField, params []string)
 	}
 
 	if field.Kind() != reflect.String {
-		return fmt.Errorf("字段必须是字符串类型")
+		return newTypeError()
 	}
 
 	value := field.String()
 	length := len(value)
 
 	if len(params) == 0 {
-		return fmt.Errorf("length规则需要参数")
+		return newRuleError(ErrorCodeInvalidRule, nil, "length规则需要参数")
 	}
 
 	if len(params) == 1 {
 		// 固定长度
 		expectedLength, err := strconv.Atoi(params[0])
 		if err != nil {
-			return fmt.Errorf("长度参数必须是数字")
+			return newRuleError(ErrorCodeInvalidRule, nil, "长度参数必须是数字")
 		}
 
 		if length != expectedLength {
-			return fmt.Errorf("长度必须为 %d 个字符", expectedLength)
+			return newRuleError(ErrorCodeInvalidLength, map[string]string{"len": params[0]}, "长度必须为 %d 个字符", expectedLength)
 		}
 	} else if len(params) == 2 {
 		// 范围长度
 		minLength, err := strconv.Atoi(params[0])
 		if err != nil {
-			return fmt.Errorf("最小长度参数必须是数字")
+			return newRuleError(ErrorCodeInvalidRule, nil, "最小长度参数必须是数字")
 		}
 
 		maxLength, err := strconv.Atoi(params[1])
 		if err != nil {
-			return fmt.Errorf("最大长度参数必须是数字")
+			return newRuleError(ErrorCodeInvalidRule, nil, "最大长度参数必须是数字")
 		}
 
 		if length < minLength || length > maxLength {
-			return fmt.Errorf("长度必须在 %d 到 %d 个字符之间", minLength, maxLength)
+			return newRuleError(ErrorCodeInvalidLength, map[string]string{"min": params[0], "max": params[1]}, "长度必须在 %d 到 %d 个字符之间", minLength, maxLength)
 		}
 	}
 
@@ -262,17 +261,17 @@ func validateRange(field reflect.Value, _ reflect.StructField, params []string)
 	}
 
 	if len(params) != 2 {
-		return fmt.Errorf("range规则需要两个参数")
+		return newRuleError(ErrorCodeInvalidRule, nil, "range规则需要两个参数")
 	}
 
 	min, err := strconv.ParseFloat(params[0], 64)
 	if err != nil {
-		return fmt.Errorf("最小值参数必须是数字")
+		return newRuleError(ErrorCodeInvalidRule, nil, "最小值参数必须是数字")
 	}
 
 	max, err := strconv.ParseFloat(params[1], 64)
 	if err != nil {
-		return fmt.Errorf("最大值参数必须是数字")
+		return newRuleError(ErrorCodeInvalidRule, nil, "最大值参数必须是数字")
 	}
 
 	var value float64
@@ -287,16 +286,16 @@ func validateRange(field reflect.Value, _ reflect.StructField, params []string)
 	case reflect.String:
 		val, err := strconv.ParseFloat(field.String(), 64)
 		if err != nil {
-			return fmt.Errorf("字段值必须是数字")
+			return newRuleError(ErrorCodeInvalidNumeric, nil, "字段值必须是数字")
 		}
 		value = val
 	default:
-		return fmt.Errorf("range规则只能用于数值类型")
+		return newRuleError(ErrorCodeInvalidRule, nil, "range规则只能用于数值类型")
 	}
 
 	// 检查是否在范围内（包含边界值）
 	if value < min || value > max {
-		return fmt.Errorf("值必须在 %v 到 %v 之间", min, max)
+		return newRuleError(ErrorCodeInvalidRange, map[string]string{"min": params[0], "max": params[1]}, "值必须在 %v 到 %v 之间", min, max)
 	}
 
 	return nil
@@ -309,11 +308,11 @@ func validateMatches(field reflect.Value, _ reflect.StructField, params []string
 	}
 
 	if field.Kind() != reflect.String {
-		return fmt.Errorf("字段必须是字符串类型")
+		return newTypeError()
 	}
 
 	if len(params) == 0 {
-		return fmt.Errorf("matches规则需要正则表达式参数")
+		return newRuleError(ErrorCodeInvalidRule, nil, "matches规则需要正则表达式参数")
 	}
 
 	pattern := params[0]
@@ -321,11 +320,11 @@ func validateMatches(field reflect.Value, _ reflect.StructField, params []string
 
 	matched, err := regexp.MatchString(pattern, value)
 	if err != nil {
-		return fmt.Errorf("正则表达式格式错误: %v", err)
+		return newRuleError(ErrorCodeInvalidRule, nil, "正则表达式格式错误: %v", err)
 	}
 
 	if !matched {
-		return fmt.Errorf("值不符合正则表达式模式: %s", pattern)
+		return newRuleError(ErrorCodeInvalidMatches, map[string]string{"pattern": pattern}, "值不符合正则表达式模式: %s", pattern)
 	}
 
 	return nil
@@ -338,7 +337,7 @@ func validateIn(field reflect.Value, _ reflect.StructField, params []string) err
 	}
 
 	if len(params) == 0 {
-		return fmt.Errorf("in规则需要至少一个参数")
+		return newRuleError(ErrorCodeInvalidRule, nil, "in规则需要至少一个参数")
 	}
 
 	for _, param := range params {
@@ -361,11 +360,11 @@ func validateIn(field reflect.Value, _ reflect.StructField, params []string) err
 				return nil
 			}
 		default:
-			return fmt.Errorf("in规则不支持该字段类型")
+			return newRuleError(ErrorCodeInvalidRule, nil, "in规则不支持该字段类型")
 		}
 	}
 
-	return fmt.Errorf("值必须是以下之一: %s", strings.Join(params, ", "))
+	return newRuleError(ErrorCodeInvalidEnum, map[string]string{"values": strings.Join(params, ", ")}, "值必须是以下之一: %s", strings.Join(params, ", "))
 }
 
 // validateWithGovalidator 使用 govalidator 的内置验证器
@@ -375,7 +374,7 @@ func validateWithGovalidator(field reflect.Value, _ reflect.StructField, rule st
 	}
 
 	if field.Kind() != reflect.String {
-		return fmt.Errorf("字段必须是字符串类型")
+		return newTypeError()
 	}
 
 	value := field.String()
@@ -384,60 +383,60 @@ func validateWithGovalidator(field reflect.Value, _ reflect.StructField, rule st
 	switch rule {
 	case "base64":
 		if !govalidator.IsBase64(value) {
-			return fmt.Errorf("必须是有效的Base64编码")
+			return newRuleError(ErrorCodeInvalidBase64, nil, "必须是有效的Base64编码")
 		}
 	case "datauri":
 		if !govalidator.IsDataURI(value) {
-			return fmt.Errorf("必须是有效的Data URI")
+			return newRuleError(ErrorCodeInvalidDataURI, nil, "必须是有效的Data URI")
 		}
 	case "port":
 		if !govalidator.IsPort(value) {
-			return fmt.Errorf("必须是有效的端口号")
+			return newRuleError(ErrorCodeInvalidPort, nil, "必须是有效的端口号")
 		}
 	case "dns":
 		if !govalidator.IsDNSName(value) {
-			return fmt.Errorf("必须是有效的DNS名称")
+			return newRuleError(ErrorCodeInvalidDNS, nil, "必须是有效的DNS名称")
 		}
 	case "host":
 		if !govalidator.IsHost(value) {
-			return fmt.Errorf("必须是有效的主机名")
+			return newRuleError(ErrorCodeInvalidHost, nil, "必须是有效的主机名")
 		}
 	case "mac":
 		if !govalidator.IsMAC(value) {
-			return fmt.Errorf("必须是有效的MAC地址")
+			return newRuleError(ErrorCodeInvalidMAC, nil, "必须是有效的MAC地址")
 		}
 	case "latitude":
 		if !govalidator.IsLatitude(value) {
-			return fmt.Errorf("必须是有效的纬度")
+			return newRuleError(ErrorCodeInvalidLatitude, nil, "必须是有效的纬度")
 		}
 	case "longitude":
 		if !govalidator.IsLongitude(value) {
-			return fmt.Errorf("必须是有效的经度")
+			return newRuleError(ErrorCodeInvalidLongitude, nil, "必须是有效的经度")
 		}
 	case "ssn":
 		if !govalidator.IsSSN(value) {
-			return fmt.Errorf("必须是有效的SSN")
+			return newRuleError(ErrorCodeInvalidSSN, nil, "必须是有效的SSN")
 		}
 	case "semver":
 		if !govalidator.IsSemver(value) {
-			return fmt.Errorf("必须是有效的语义化版本")
+			return newRuleError(ErrorCodeInvalidSemver, nil, "必须是有效的语义化版本")
 		}
 	case "rfc3339":
 		if !govalidator.IsRFC3339(value) {
-			return fmt.Errorf("必须是有效的RFC3339时间格式")
+			return newRuleError(ErrorCodeInvalidRFC3339, nil, "必须是有效的RFC3339时间格式")
 		}
 	case "ulid":
 		if !govalidator.IsULID(value) {
-			return fmt.Errorf("必须是有效的ULID")
+			return newRuleError(ErrorCodeInvalidULID, nil, "必须是有效的ULID")
 		}
 	case "yyyymmdd":
 		// 使用正则表达式验证YYYYMMDD格式
 		matched, _ := regexp.MatchString(`^\d{4}(0[1-9]|1[0-2])(0[1-9]|[12]\d|3[01])$`, value)
 		if !matched {
-			return fmt.Errorf("必须是有效的YYYYMMDD日期格式")
+			return newRuleError(ErrorCodeInvalidYYYYMMDD, nil, "必须是有效的YYYYMMDD日期格式")
 		}
 	default:
-		return fmt.Errorf("未知的验证规则: %s", rule)
+		return newRuleError(ErrorCodeUnknownRule, map[string]string{"rule": rule}, "未知的验证规则: %s", rule)
 	}
 
 	return nil